@@ -0,0 +1,131 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+type textValueRequest struct {
+	Value string `json:"value"`
+}
+
+func (e *env) createText(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("createText", "ctx", ctx)
+
+	var text models.TranslatedText
+	err := c.ShouldBindJSON(&text)
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid request body"))
+		return
+	}
+
+	created, err := e.textManager.Create(ctx, text)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (e *env) putText(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("putText", "ctx", ctx)
+
+	text, err := parseTextValue(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	stored, err := e.textManager.Put(ctx, text)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stored)
+}
+
+func (e *env) patchText(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("patchText", "ctx", ctx)
+
+	text, err := parseTextValue(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	updated, err := e.textManager.Update(ctx, text)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+func (e *env) deleteText(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("deleteText", "ctx", ctx)
+
+	err := e.textManager.Delete(ctx, c.Param("key"), c.Param("language"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	httputil.SendOK(c)
+}
+
+func (e *env) getTextHistory(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("getTextHistory", "ctx", ctx)
+
+	revisions, err := e.textHistory.Get(ctx, c.Param("key"), c.Query("language"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+func (e *env) restoreTextRevision(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("restoreTextRevision", "ctx", ctx)
+
+	revisionID, err := strconv.Atoi(c.Param("revisionId"))
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid revision id"))
+		return
+	}
+
+	restored, err := e.textHistory.Restore(ctx, c.Param("key"), revisionID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, restored)
+}
+
+func parseTextValue(c *gin.Context) (models.TranslatedText, error) {
+	var body textValueRequest
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		return models.TranslatedText{}, httputil.BadRequest("Invalid request body")
+	}
+
+	return models.TranslatedText{
+		Key:      c.Param("key"),
+		Language: c.Param("language"),
+		Value:    body.Value,
+	}, nil
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	stdctx "context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/service"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateText(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	body := models.TranslatedText{Key: "NEW_TEXT_KEY", Language: "en", Value: "en-new-val"}
+	req := createTestBodyRequest(http.MethodPost, "/v1/admin/texts", "", body)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	var created models.TranslatedText
+	err := json.NewDecoder(res.Body).Decode(&created)
+	assert.NoError(err)
+	assert.Equal("NEW_TEXT_KEY", created.Key)
+	assert.Equal("en", created.Language)
+	assert.Equal("en-new-val", created.Value)
+
+	req = createTestRequest("/v1/texts/key/NEW_TEXT_KEY", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	// Already existing text
+	req = createTestBodyRequest(http.MethodPost, "/v1/admin/texts", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusConflict, res.Code)
+
+	// Unsupported language
+	body = models.TranslatedText{Key: "NEW_TEXT_KEY", Language: "xy", Value: "xy-new-val"}
+	req = createTestBodyRequest(http.MethodPost, "/v1/admin/texts", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	// Invalid key
+	body = models.TranslatedText{Key: "NEW TEXT KEY", Language: "en", Value: "en-new-val"}
+	req = createTestBodyRequest(http.MethodPost, "/v1/admin/texts", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	// Invalid message format
+	body = models.TranslatedText{Key: "OTHER_NEW_KEY", Language: "en", Value: "{count, plural, one {#}}"}
+	req = createTestBodyRequest(http.MethodPost, "/v1/admin/texts", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	// Missing value
+	body = models.TranslatedText{Key: "OTHER_NEW_KEY", Language: "en"}
+	req = createTestBodyRequest(http.MethodPost, "/v1/admin/texts", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+}
+
+func TestUpdateText(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	path := "/v1/admin/texts/key/TEST_TEXT_KEY/language/en"
+	req := createTestBodyRequest(http.MethodPatch, path, "", textValueRequest{Value: "en-updated-val"})
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var texts models.Texts
+	err := json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("en-updated-val", texts["TEST_TEXT_KEY"])
+
+	// Missing text
+	path = "/v1/admin/texts/key/ONLY_SV_TEXT_KEY/language/en"
+	req = createTestBodyRequest(http.MethodPatch, path, "", textValueRequest{Value: "en-only-val"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+}
+
+func TestUpdateTextInvalidatesCache(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestRequest("/v1/texts/group/MOBILE_APP", "en")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	path := "/v1/admin/texts/key/TEST_TEXT_KEY/language/en"
+	req = createTestBodyRequest(http.MethodPatch, path, "", textValueRequest{Value: "en-updated-val"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var texts models.Texts
+	err := json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("en-updated-val", texts["TEST_TEXT_KEY"])
+}
+
+func TestCreateTextConcurrently(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+
+	// The text is created by another request after the existence check.
+	textRepo := racingTextRepo{TextRepository: repository.NewTextRepository(e.db)}
+	manager := service.NewTextManager(repository.NewLanguageRepository(e.db), textRepo, service.ChangeListeners{})
+
+	ctx := context.New(stdctx.Background(), "TestCreateTextConcurrently", "")
+	_, err := manager.Create(ctx, models.TranslatedText{Key: "TEST_TEXT_KEY", Language: "en", Value: "en-other-val"})
+	httpErr, ok := err.(*httputil.Error)
+	assert.True(ok)
+	assert.Equal(http.StatusConflict, httpErr.StatusCode)
+}
+
+type racingTextRepo struct {
+	repository.TextRepository
+}
+
+func (r racingTextRepo) Find(ctx *context.Context, key, language string) (models.TranslatedText, error) {
+	return models.TranslatedText{}, repository.ErrNotFound
+}
+
+func TestPutText(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	// Creates missing text
+	path := "/v1/admin/texts/key/ONLY_SV_TEXT_KEY/language/en"
+	req := createTestBodyRequest(http.MethodPut, path, "", textValueRequest{Value: "en-only-val"})
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	// Replaces existing text
+	req = createTestBodyRequest(http.MethodPut, path, "", textValueRequest{Value: "en-replaced-val"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var stored models.TranslatedText
+	err := json.NewDecoder(res.Body).Decode(&stored)
+	assert.NoError(err)
+	assert.Equal("en-replaced-val", stored.Value)
+
+	req = createTestRequest("/v1/texts/key/ONLY_SV_TEXT_KEY/history?language=en", "")
+	res = performTestRequest(server.Handler, req)
+	var revisions []models.TextRevision
+	err = json.NewDecoder(res.Body).Decode(&revisions)
+	assert.NoError(err)
+	assert.Equal(2, len(revisions))
+	assert.Equal(models.Updated, revisions[0].Action)
+	assert.Equal(models.Created, revisions[1].Action)
+
+	// Unsupported language
+	path = "/v1/admin/texts/key/ONLY_SV_TEXT_KEY/language/xy"
+	req = createTestBodyRequest(http.MethodPut, path, "", textValueRequest{Value: "xy-val"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+}
+
+func TestPutTextNotifiesAction(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+
+	recorder := &changeRecorder{}
+	manager := service.NewTextManager(repository.NewLanguageRepository(e.db), repository.NewTextRepository(e.db), recorder)
+
+	ctx := context.New(stdctx.Background(), "TestPutTextNotifiesAction", "")
+	_, err := manager.Put(ctx, models.TranslatedText{Key: "ONLY_SV_TEXT_KEY", Language: "en", Value: "en-only-val"})
+	assert.NoError(err)
+	_, err = manager.Put(ctx, models.TranslatedText{Key: "ONLY_SV_TEXT_KEY", Language: "en", Value: "en-replaced-val"})
+	assert.NoError(err)
+
+	assert.Equal(2, len(recorder.changes))
+	assert.Equal(models.Created, recorder.changes[0].Action)
+	assert.Equal(models.Updated, recorder.changes[1].Action)
+}
+
+type changeRecorder struct {
+	changes []models.Change
+}
+
+func (r *changeRecorder) OnChange(ctx *context.Context, change models.Change) {
+	r.changes = append(r.changes, change)
+}
+
+func TestDeleteText(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	path := "/v1/admin/texts/key/TEST_TEXT_KEY/language/en"
+	req := createTestBodyRequest(http.MethodDelete, path, "", nil)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+
+	req = createTestBodyRequest(http.MethodDelete, path, "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+}
+
+func TestTextHistoryAndRestore(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	path := "/v1/admin/texts/key/TEST_TEXT_KEY/language/en"
+	req := createTestBodyRequest(http.MethodPatch, path, "", textValueRequest{Value: "en-mistake-val"})
+	req.Header.Set("X-User-ID", "translator-1")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY/history?language=en", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var revisions []models.TextRevision
+	err := json.NewDecoder(res.Body).Decode(&revisions)
+	assert.NoError(err)
+	assert.Equal(2, len(revisions))
+	assert.Equal("en-mistake-val", revisions[0].Value)
+	assert.Equal(models.Updated, revisions[0].Action)
+	assert.Equal("", revisions[0].ChangedBy, "the author is only taken from the authenticated principal")
+	assert.Equal("en-text-val", revisions[1].Value)
+	assert.Equal(models.Created, revisions[1].Action)
+
+	// All languages
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY/history", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	var allRevisions []models.TextRevision
+	err = json.NewDecoder(res.Body).Decode(&allRevisions)
+	assert.NoError(err)
+	assert.Equal(3, len(allRevisions))
+
+	// Restore the original value
+	restorePath := fmt.Sprintf("/v1/admin/texts/key/TEST_TEXT_KEY/history/%d/restore", revisions[1].ID)
+	req = createTestBodyRequest(http.MethodPost, restorePath, "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "en")
+	res = performTestRequest(server.Handler, req)
+	var texts models.Texts
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("en-text-val", texts["TEST_TEXT_KEY"])
+
+	// Revision of another key
+	restorePath = fmt.Sprintf("/v1/admin/texts/key/OTHER_TEXT_KEY/history/%d/restore", revisions[1].ID)
+	req = createTestBodyRequest(http.MethodPost, restorePath, "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+
+	// Missing history
+	req = createTestRequest("/v1/texts/key/MISSING_KEY/history", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/CzarSimon/text-service/go/pkg/service"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	principalKey = "principal"
+	bearerPrefix = "Bearer "
+)
+
+type apiKeyRequest struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// authorize authenticates the caller by an API key or a bearer token and
+// requires the caller to have at least the given role.
+// Every caller is let through if authentication is disabled.
+func (e *env) authorize(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !e.cfg.authEnabled {
+			c.Next()
+			return
+		}
+
+		principal, err := e.authenticate(c)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		if !service.HasRole(principal, role) {
+			c.Error(httputil.Forbidden("Requires role: " + role))
+			c.Abort()
+			return
+		}
+
+		c.Set(principalKey, principal)
+		c.Next()
+	}
+}
+
+func (e *env) authenticate(c *gin.Context) (context.Principal, error) {
+	ctx := createContext(c)
+
+	apiKey := c.GetHeader(httputil.APIKeyHeader)
+	if apiKey != "" {
+		return e.authenticator.AuthenticateAPIKey(ctx, apiKey)
+	}
+
+	authorization := c.GetHeader(httputil.AuthorizationHeader)
+	if strings.HasPrefix(authorization, bearerPrefix) {
+		return e.authenticator.AuthenticateToken(ctx, strings.TrimPrefix(authorization, bearerPrefix))
+	}
+
+	return context.Principal{}, httputil.Unauthorized("No credentials provided")
+}
+
+func (e *env) getAPIKeys(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("getAPIKeys", "ctx", ctx)
+
+	keys, err := e.apiKeyManager.GetAll(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+func (e *env) createAPIKey(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("createAPIKey", "ctx", ctx)
+
+	var body apiKeyRequest
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid request body"))
+		return
+	}
+
+	key, err := e.apiKeyManager.Create(ctx, body.Name, body.Role)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+func (e *env) deleteAPIKey(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("deleteAPIKey", "ctx", ctx)
+
+	err := e.apiKeyManager.Delete(ctx, c.Param("keyId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	httputil.SendOK(c)
+}
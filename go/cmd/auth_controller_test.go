@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/service"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/CzarSimon/text-service/go/pkg/utils/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+var testJWTSecret = []byte("test-jwt-secret")
+
+func TestAuthenticationRequired(t *testing.T) {
+	assert := assert.New(t)
+	e := createAuthTestEnv()
+	server := newServer(e)
+
+	req := createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusUnauthorized, res.Code)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	req.Header.Set(httputil.APIKeyHeader, "invalid-key")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusUnauthorized, res.Code)
+
+	forged, err := jwt.SignHS256(jwt.Claims{Subject: "mallory", Role: models.AdminRole}, []byte("other-secret"))
+	assert.NoError(err)
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	req.Header.Set(httputil.AuthorizationHeader, "Bearer "+forged)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusUnauthorized, res.Code)
+
+	req = createTestRequest("/health", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+}
+
+func TestTokenRoles(t *testing.T) {
+	assert := assert.New(t)
+	e := createAuthTestEnv()
+	server := newServer(e)
+
+	reader := createTestToken(t, "reader-user", models.ReaderRole)
+	translator := createTestToken(t, "translator-user", models.TranslatorRole)
+	admin := createTestToken(t, "admin-user", models.AdminRole)
+
+	req := createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	req.Header.Set(httputil.AuthorizationHeader, "Bearer "+reader)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("private, max-age=60", res.Header().Get(httputil.CacheControlHeader))
+
+	draft := textValueRequest{Value: "sv-draft-val"}
+	req = createTestBodyRequest(http.MethodPut, "/v1/admin/drafts/key/TEST_TEXT_KEY/language/sv", "", draft)
+	req.Header.Set(httputil.AuthorizationHeader, "Bearer "+reader)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusForbidden, res.Code)
+
+	req = createTestBodyRequest(http.MethodPut, "/v1/admin/drafts/key/TEST_TEXT_KEY/language/sv", "", draft)
+	req.Header.Set(httputil.AuthorizationHeader, "Bearer "+translator)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var stored models.TextDraft
+	err := json.NewDecoder(res.Body).Decode(&stored)
+	assert.NoError(err)
+	assert.Equal("translator-user", stored.ChangedBy)
+
+	publish := publishRequest{Key: "TEST_TEXT_KEY"}
+	req = createTestBodyRequest(http.MethodPost, "/v1/admin/drafts/publish", "", publish)
+	req.Header.Set(httputil.AuthorizationHeader, "Bearer "+translator)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusForbidden, res.Code)
+
+	req = createTestBodyRequest(http.MethodPost, "/v1/admin/drafts/publish", "", publish)
+	req.Header.Set(httputil.AuthorizationHeader, "Bearer "+admin)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY/history?language=sv", "")
+	req.Header.Set(httputil.AuthorizationHeader, "Bearer "+reader)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var revisions []models.TextRevision
+	err = json.NewDecoder(res.Body).Decode(&revisions)
+	assert.NoError(err)
+	assert.Equal("admin-user", revisions[0].ChangedBy)
+
+	expired, err := jwt.SignHS256(jwt.Claims{
+		Subject: "admin-user", Role: models.AdminRole, ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+	}, testJWTSecret)
+	assert.NoError(err)
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	req.Header.Set(httputil.AuthorizationHeader, "Bearer "+expired)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusUnauthorized, res.Code)
+
+	unknownRole := createTestToken(t, "someone", "owner")
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	req.Header.Set(httputil.AuthorizationHeader, "Bearer "+unknownRole)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusUnauthorized, res.Code)
+}
+
+func TestPreviewRequiresTranslator(t *testing.T) {
+	assert := assert.New(t)
+	e := createAuthTestEnv()
+	server := newServer(e)
+
+	reader := createTestToken(t, "reader-user", models.ReaderRole)
+	translator := createTestToken(t, "translator-user", models.TranslatorRole)
+
+	draft := textValueRequest{Value: "sv-draft-val"}
+	req := createTestBodyRequest(http.MethodPut, "/v1/admin/drafts/key/TEST_TEXT_KEY/language/sv", "", draft)
+	req.Header.Set(httputil.AuthorizationHeader, "Bearer "+translator)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	req.Header.Set(httputil.AuthorizationHeader, "Bearer "+reader)
+	req.Header.Set(httputil.PreviewHeader, "true")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Contains(res.Body.String(), `"sv-text-val"`)
+	assert.Equal("private, max-age=60", res.Header().Get(httputil.CacheControlHeader))
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	req.Header.Set(httputil.AuthorizationHeader, "Bearer "+translator)
+	req.Header.Set(httputil.PreviewHeader, "true")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Contains(res.Body.String(), `"sv-draft-val"`)
+	assert.Equal("private, no-cache", res.Header().Get(httputil.CacheControlHeader))
+}
+
+func TestAPIKeys(t *testing.T) {
+	assert := assert.New(t)
+	e := createAuthTestEnv()
+	server := newServer(e)
+	admin := createTestToken(t, "admin-user", models.AdminRole)
+
+	body := apiKeyRequest{Name: "web-frontend", Role: models.ReaderRole}
+	req := createTestBodyRequest(http.MethodPost, "/v1/admin/api-keys", "", body)
+	req.Header.Set(httputil.AuthorizationHeader, "Bearer "+admin)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	var key models.APIKey
+	err := json.NewDecoder(res.Body).Decode(&key)
+	assert.NoError(err)
+	assert.Equal("web-frontend", key.Name)
+	assert.NotEmpty(key.Key)
+
+	req = createTestBodyRequest(http.MethodPost, "/v1/admin/api-keys", "", apiKeyRequest{Name: "invalid", Role: "owner"})
+	req.Header.Set(httputil.AuthorizationHeader, "Bearer "+admin)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	req.Header.Set(httputil.APIKeyHeader, key.Key)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/admin/api-keys", "")
+	req.Header.Set(httputil.APIKeyHeader, key.Key)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusForbidden, res.Code)
+
+	req = createTestRequest("/v1/admin/api-keys", "")
+	req.Header.Set(httputil.AuthorizationHeader, "Bearer "+admin)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var keys []models.APIKey
+	err = json.NewDecoder(res.Body).Decode(&keys)
+	assert.NoError(err)
+	assert.Equal(1, len(keys))
+	assert.Empty(keys[0].Key)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/admin/api-keys/"+key.ID, "", nil)
+	req.Header.Set(httputil.AuthorizationHeader, "Bearer "+admin)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	req.Header.Set(httputil.APIKeyHeader, key.Key)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusUnauthorized, res.Code)
+}
+
+func createAuthTestEnv() *env {
+	e := createTestEnv()
+	e.cfg.authEnabled = true
+	e.authenticator = service.NewAuthenticator(repository.NewAPIKeyRepository(e.db), jwt.NewHS256Verifier(testJWTSecret))
+	return e
+}
+
+func createTestToken(t *testing.T, subject, role string) string {
+	token, err := jwt.SignHS256(jwt.Claims{Subject: subject, Role: role}, testJWTSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	stdctx "context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/id"
+)
+
+const usage = `Usage: text-service [command]
+
+Without a command the http server is started.
+
+Commands:
+  create-api-key NAME ROLE                    issues an API key with the role reader, translator or admin
+  gettext-import LANGUAGE FILE [PROJECT]      imports the texts of a language from a .po or .mo file
+  gettext-export LANGUAGE FILE [PROJECT]      exports the texts of a language to a .po file, or a .mo file if FILE ends with .mo`
+
+// runCommand runs a command given on the command line instead of starting the server.
+func runCommand(e *env, args []string) {
+	ctx := context.New(stdctx.Background(), id.New(), "")
+
+	var err error
+	switch args[0] {
+	case "create-api-key":
+		err = createAPIKeyCommand(e, ctx, args[1:])
+	case "gettext-import":
+		err = gettextImportCommand(e, ctx, args[1:])
+	case "gettext-export":
+		err = gettextExportCommand(e, ctx, args[1:])
+	default:
+		err = fmt.Errorf("unknown command: %s", args[0])
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(1)
+	}
+}
+
+func createAPIKeyCommand(e *env, ctx *context.Context, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("create-api-key expects a NAME and a ROLE")
+	}
+
+	key, err := e.apiKeyManager.Create(ctx, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created api key. id=%s role=%s\n%s\n", key.ID, key.Role, key.Key)
+	return nil
+}
+
+func gettextImportCommand(e *env, ctx *context.Context, args []string) error {
+	if len(args) != 2 && len(args) != 3 {
+		return fmt.Errorf("gettext-import expects a LANGUAGE, a FILE and optionally a PROJECT")
+	}
+	if len(args) == 3 {
+		ctx.Project = args[2]
+	}
+
+	data, err := ioutil.ReadFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	catalog, err := parseGettextCatalog(data)
+	if err != nil {
+		return err
+	}
+
+	result, err := e.gettextConverter.Import(ctx, args[0], catalog)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported gettext catalog. language=%s created=%d updated=%d skipped=%d\n", result.Language, result.Created, result.Updated, result.Skipped)
+	return nil
+}
+
+func gettextExportCommand(e *env, ctx *context.Context, args []string) error {
+	if len(args) != 2 && len(args) != 3 {
+		return fmt.Errorf("gettext-export expects a LANGUAGE, a FILE and optionally a PROJECT")
+	}
+	if len(args) == 3 {
+		ctx.Project = args[2]
+	}
+
+	body, err := e.exportGettextCatalog(ctx, args[0], strings.HasSuffix(args[1], ".mo"))
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(args[1], body, 0644)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported gettext catalog. language=%s file=%s\n", args[0], args[1])
+	return nil
+}
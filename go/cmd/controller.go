@@ -1,11 +1,22 @@
 package main
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/service"
 	"github.com/CzarSimon/text-service/go/pkg/utils/context"
 	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
 	"github.com/CzarSimon/text-service/go/pkg/utils/logger"
+	"github.com/CzarSimon/text-service/go/pkg/utils/resource"
 	"github.com/gin-gonic/gin"
 )
 
@@ -15,8 +26,9 @@ func (e *env) getTextByKey(c *gin.Context) {
 	ctx := createContext(c)
 	controllerLog.Debugw("getTextByKey", "ctx", ctx)
 
-	if ctx.Language == "" {
-		c.Error(httputil.BadRequest("No language specified"))
+	err := e.negotiateLanguage(c, ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -26,15 +38,16 @@ func (e *env) getTextByKey(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, texts)
+	e.sendCacheableTexts(c, ctx, texts)
 }
 
 func (e *env) getTextGroup(c *gin.Context) {
 	ctx := createContext(c)
 	controllerLog.Debugw("getTextByKey", "ctx", ctx)
 
-	if ctx.Language == "" {
-		c.Error(httputil.BadRequest("No language specified"))
+	err := e.negotiateLanguage(c, ctx)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -44,12 +57,205 @@ func (e *env) getTextGroup(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, texts)
+	e.sendCacheableTexts(c, ctx, texts)
+}
+
+func (e *env) listTexts(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("listTexts", "ctx", ctx)
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(service.DefaultListLimit)))
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid limit"))
+		return
+	}
+
+	filter := models.TextFilter{
+		Language: c.Query("language"),
+		Group:    c.Query("group"),
+		Prefix:   c.Query("prefix"),
+	}
+	if updatedSince := c.Query("updatedSince"); updatedSince != "" {
+		filter.UpdatedSince, err = time.Parse(time.RFC3339, updatedSince)
+		if err != nil {
+			c.Error(httputil.BadRequest("Invalid updatedSince, expected an RFC 3339 timestamp"))
+			return
+		}
+	}
+
+	page, err := e.textLister.List(ctx, filter, c.Query("cursor"), limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+type renderRequest struct {
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+func (e *env) renderText(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("renderText", "ctx", ctx)
+
+	err := e.negotiateLanguage(c, ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var body renderRequest
+	err = c.ShouldBindJSON(&body)
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid request body"))
+		return
+	}
+
+	texts, err := e.textRenderer.Render(ctx, c.Param("key"), body.Arguments)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sendTexts(c, texts)
+}
+
+// negotiateLanguage picks the best supported language for the request's
+// Accept-Language header, sets it on the context and echoes it back
+// in the Content-Language header.
+func (e *env) negotiateLanguage(c *gin.Context, ctx *context.Context) error {
+	c.Header(httputil.VaryHeader, httputil.AcceptLanguage+", "+httputil.PreviewHeader)
+	if ctx.Language == "" {
+		return httputil.BadRequest("No language specified")
+	}
+
+	language, err := e.languageNegotiator.Negotiate(ctx, ctx.Language)
+	if err != nil {
+		return err
+	}
+
+	ctx.Language = language
+	c.Header(httputil.ContentLanguage, language)
+	return nil
+}
+
+// sendTexts sends resolved texts and lists the keys served from a fallback
+// language in the X-Fallback-Languages header as key=language pairs.
+func sendTexts(c *gin.Context, texts models.ResolvedTexts) {
+	if len(texts.Fallbacks) > 0 {
+		c.Header(httputil.FallbackLanguagesHeader, formatFallbacks(texts.Fallbacks))
+	}
+
+	c.JSON(http.StatusOK, texts.Texts)
+}
+
+// sendCacheableTexts sends resolved texts in the negotiated format along with ETag, Last-Modified
+// and Cache-Control headers, or 304 Not Modified if the client's copy is current. Previews are
+// only validated by ETag, as discarding a draft does not leave a modification time behind.
+func (e *env) sendCacheableTexts(c *gin.Context, ctx *context.Context, texts models.ResolvedTexts) {
+	format, err := e.negotiateTextFormat(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = format.encode(&buf, texts.Texts)
+	var collision *resource.NameCollisionError
+	if errors.As(err, &collision) {
+		c.Error(httputil.Conflict(fmt.Sprintf("Texts cannot be sent as %s: %s", format.name, collision.Error())))
+		return
+	}
+	if err != nil {
+		controllerLog.Errorw("Failed to encode texts", "format", format.name, "error", err, "ctx", ctx)
+		c.Error(httputil.ErrInternalServerError)
+		return
+	}
+	body := buf.Bytes()
+
+	fallbacks := formatFallbacks(texts.Fallbacks)
+	etag := httputil.ETag(body, []byte(ctx.Language), []byte(fallbacks))
+	c.Writer.Header().Add(httputil.VaryHeader, httputil.AcceptHeader)
+	c.Header(httputil.ETagHeader, etag)
+	switch {
+	case ctx.Preview:
+		c.Header(httputil.CacheControlHeader, "private, no-cache")
+	case e.cfg.authEnabled:
+		// Authenticated responses must not be served by shared caches to callers without credentials.
+		c.Header(httputil.CacheControlHeader, fmt.Sprintf("private, max-age=%d", e.cfg.maxAge))
+	default:
+		c.Header(httputil.CacheControlHeader, fmt.Sprintf("public, max-age=%d", e.cfg.maxAge))
+	}
+	if fallbacks != "" {
+		c.Header(httputil.FallbackLanguagesHeader, fallbacks)
+	}
+
+	lastModified := texts.LastModified
+	if ctx.Preview {
+		lastModified = time.Time{}
+	}
+	if !lastModified.IsZero() {
+		c.Header(httputil.LastModifiedHeader, lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if httputil.NotModified(c.Request, etag, lastModified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, format.mediaType+"; charset=utf-8", body)
+}
+
+func formatFallbacks(fallbacks map[string]string) string {
+	pairs := make([]string, 0, len(fallbacks))
+	for key, language := range fallbacks {
+		pairs = append(pairs, key+"="+language)
+	}
+
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// wantsFormat checks if the client asked for a format, either by name in
+// the format query parameter or by its media type in the Accept header.
+func wantsFormat(c *gin.Context, format, mediaType string) bool {
+	if f := c.Query("format"); f != "" {
+		return f == format
+	}
+
+	return strings.Contains(c.GetHeader(httputil.AcceptHeader), mediaType)
+}
+
+// readImportBody reads the body of an import request, which may be at most the configured max import size.
+func (e *env) readImportBody(c *gin.Context) ([]byte, error) {
+	data, err := ioutil.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, e.cfg.maxImportSize))
+	if _, ok := err.(*http.MaxBytesError); ok {
+		errorMsg := fmt.Sprintf("Request body exceeds the max import size of %d bytes", e.cfg.maxImportSize)
+		return nil, httputil.NewError(errorMsg, http.StatusRequestEntityTooLarge)
+	}
+	if err != nil {
+		return nil, httputil.BadRequest("Failed to read request body")
+	}
+
+	return data, nil
 }
 
 func createContext(c *gin.Context) *context.Context {
 	requestID := httputil.GetRequestID(c)
 	locale := httputil.GetLocale(c)
 
-	return context.New(c.Request.Context(), requestID, locale)
+	ctx := context.New(c.Request.Context(), requestID, locale)
+	ctx.Preview, _ = strconv.ParseBool(c.GetHeader(httputil.PreviewHeader))
+	if principal, ok := c.Get(principalKey); ok {
+		ctx.Principal = principal.(context.Principal)
+		ctx.User = ctx.Principal.ID
+		// Drafts are unpublished, only translators may preview them when callers are authenticated.
+		ctx.Preview = ctx.Preview && service.HasRole(ctx.Principal, models.TranslatorRole)
+	}
+	if project := c.Param("project"); project != "" {
+		ctx.Project = project
+	}
+	return ctx
 }
@@ -1,13 +1,16 @@
 package main
 
 import (
+	"bytes"
 	stdctx "context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/CzarSimon/text-service/go/pkg/models"
 	"github.com/CzarSimon/text-service/go/pkg/repository"
@@ -153,9 +156,386 @@ func TestGetTextsByGroupFail(t *testing.T) {
 	assert.Equal(http.StatusNotFound, res.Code)
 }
 
+func TestGetTextsLanguageNegotiation(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	// Browser style header with regional subtag and q-values
+	path := "/v1/texts/key/TEST_TEXT_KEY"
+	req := createTestRequest(path, "sv-SE,sv;q=0.9,en;q=0.8")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("sv", res.Header().Get(httputil.ContentLanguage))
+	assert.Equal("Accept-Language, X-Preview", res.Header().Get(httputil.VaryHeader))
+
+	var texts models.Texts
+	err := json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("sv-text-val", texts["TEST_TEXT_KEY"])
+
+	// Highest quality supported language
+	path = "/v1/texts/group/MOBILE_APP"
+	req = createTestRequest(path, "fr-FR, fr;q=0.9, en;q=0.8, sv;q=0.7")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("en", res.Header().Get(httputil.ContentLanguage))
+
+	// Excluded language
+	req = createTestRequest(path, "sv;q=0, fr")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	// Excluded language is not reached by truncating a regional range
+	req = createTestRequest(path, "sv-SE, sv;q=0, en;q=0.5")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("en", res.Header().Get(httputil.ContentLanguage))
+}
+
+func TestRenderText(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	ctx := context.New(stdctx.Background(), "TestRenderText", "")
+	_, err := e.textManager.Create(ctx, models.TranslatedText{
+		Key:      "UNREAD_MESSAGES",
+		Language: "en",
+		Value:    "{name}, you have {count, plural, =0 {no messages} one {# message} other {# messages}}",
+	})
+	assert.NoError(err)
+
+	path := "/v1/texts/key/UNREAD_MESSAGES/render"
+	args := map[string]interface{}{"name": "Ada", "count": 3}
+	req := createTestBodyRequest(http.MethodPost, path, "en", renderRequest{Arguments: args})
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var texts models.Texts
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("Ada, you have 3 messages", texts["UNREAD_MESSAGES"])
+
+	// Missing argument
+	args = map[string]interface{}{"name": "Ada"}
+	req = createTestBodyRequest(http.MethodPost, path, "en", renderRequest{Arguments: args})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	// Missing text
+	req = createTestBodyRequest(http.MethodPost, path, "sv", renderRequest{Arguments: args})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+}
+
+func TestGetTextsConditional(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	path := "/v1/texts/group/MOBILE_APP"
+	req := createTestRequest(path, "en")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("public, max-age=60", res.Header().Get(httputil.CacheControlHeader))
+	etag := res.Header().Get(httputil.ETagHeader)
+	assert.NotEqual("", etag)
+	lastModified := res.Header().Get(httputil.LastModifiedHeader)
+	assert.NotEqual("", lastModified)
+
+	// Same content, same ETag
+	req = createTestRequest(path, "en")
+	req.Header.Set(httputil.IfNoneMatchHeader, etag)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotModified, res.Code)
+	assert.Equal(0, res.Body.Len())
+	assert.Equal(etag, res.Header().Get(httputil.ETagHeader))
+
+	// Not modified since
+	req = createTestRequest(path, "en")
+	req.Header.Set(httputil.IfModifiedSinceHeader, lastModified)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotModified, res.Code)
+
+	modified, err := http.ParseTime(lastModified)
+	assert.NoError(err)
+	req = createTestRequest(path, "en")
+	req.Header.Set(httputil.IfModifiedSinceHeader, modified.Add(-time.Hour).Format(http.TimeFormat))
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	// If-None-Match takes precedence over If-Modified-Since
+	req = createTestRequest(path, "en")
+	req.Header.Set(httputil.IfNoneMatchHeader, `"other-etag"`)
+	req.Header.Set(httputil.IfModifiedSinceHeader, lastModified)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	// Other language, other ETag
+	req = createTestRequest(path, "sv")
+	req.Header.Set(httputil.IfNoneMatchHeader, etag)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.NotEqual(etag, res.Header().Get(httputil.ETagHeader))
+
+	// Changed content
+	ctx := context.New(stdctx.Background(), "TestGetTextsConditional", "")
+	_, err = e.textManager.Update(ctx, models.TranslatedText{
+		Key: "TEST_TEXT_KEY", Language: "en", Value: "en-updated-val",
+	})
+	assert.NoError(err)
+
+	req = createTestRequest(path, "en")
+	req.Header.Set(httputil.IfNoneMatchHeader, etag)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.NotEqual(etag, res.Header().Get(httputil.ETagHeader))
+	etag = res.Header().Get(httputil.ETagHeader)
+
+	// Removed member, no text updated but other content
+	body := groupMembersRequest{Keys: []string{"OTHER_TEXT_KEY"}}
+	req = createTestBodyRequest(http.MethodDelete, "/v1/groups/MOBILE_APP/members", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest(path, "en")
+	req.Header.Set(httputil.IfNoneMatchHeader, etag)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.NotContains(res.Body.String(), "OTHER_TEXT_KEY")
+}
+
+func TestGetTextsLastModified(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	ctx := context.New(stdctx.Background(), "TestGetTextsLastModified", "sv")
+	getLastModified := func() time.Time {
+		texts, err := e.textGetter.GetGroup(ctx, "MOBILE_APP")
+		assert.NoError(err)
+		return texts.LastModified
+	}
+
+	// Latest update of the resolved texts
+	lastModified := getLastModified()
+	assert.False(lastModified.IsZero())
+	time.Sleep(10 * time.Millisecond)
+	_, err := e.textManager.Update(ctx, models.TranslatedText{
+		Key:      "ONLY_SV_TEXT_KEY",
+		Language: "sv",
+		Value:    "Uppdaterad",
+	})
+	assert.NoError(err)
+	assert.True(getLastModified().After(lastModified))
+	lastModified = getLastModified()
+
+	// Removed member
+	time.Sleep(10 * time.Millisecond)
+	body := groupMembersRequest{Keys: []string{"OTHER_TEXT_KEY"}}
+	req := createTestBodyRequest(http.MethodDelete, "/v1/groups/MOBILE_APP/members", "", body)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.True(getLastModified().After(lastModified))
+	lastModified = getLastModified()
+
+	// Deleted member text
+	time.Sleep(10 * time.Millisecond)
+	err = e.textManager.Delete(ctx, "TEST_TEXT_KEY", "sv")
+	assert.NoError(err)
+	assert.True(getLastModified().After(lastModified))
+	lastModified = getLastModified()
+
+	// Changed current release
+	time.Sleep(10 * time.Millisecond)
+	req = createTestBodyRequest(http.MethodPost, "/v1/releases", "", releaseRequest{ID: "v1"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+	req = createTestBodyRequest(http.MethodPut, "/v1/current-release", "", currentReleaseRequest{ID: "v1"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.True(getLastModified().After(lastModified))
+
+	req = createTestRequest("/v1/texts/key/ONLY_SV_TEXT_KEY", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.NotEqual("", res.Header().Get(httputil.LastModifiedHeader))
+}
+
+func TestGetTextsWithFallback(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	body := languageRequest{ID: "fi", Fallback: "sv"}
+	req := createTestBodyRequest(http.MethodPost, "/v1/languages", "", body)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	body = languageRequest{ID: "sv-FI"}
+	req = createTestBodyRequest(http.MethodPost, "/v1/languages", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	// Configured fallback
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "fi")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("TEST_TEXT_KEY=sv", res.Header().Get(httputil.FallbackLanguagesHeader))
+
+	var texts models.Texts
+	err := json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("sv-text-val", texts["TEST_TEXT_KEY"])
+
+	// Parent language fallback
+	ctx := context.New(stdctx.Background(), "TestGetTextsWithFallback", "")
+	_, err = e.textManager.Put(ctx, models.TranslatedText{
+		Key: "TEST_TEXT_KEY", Language: "sv-FI", Value: "sv-FI-text-val",
+	})
+	assert.NoError(err)
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP", "sv-FI")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	fallbacks := res.Header().Get(httputil.FallbackLanguagesHeader)
+	assert.Equal("ONLY_SV_TEXT_KEY=sv,OTHER_TEXT_KEY=sv", fallbacks)
+
+	texts = models.Texts{}
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal(3, len(texts))
+	assert.Equal("sv-FI-text-val", texts["TEST_TEXT_KEY"])
+	assert.Equal("sv-other-val", texts["OTHER_TEXT_KEY"])
+
+	// No fallback
+	req = createTestRequest("/v1/texts/group/MOBILE_APP", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("", res.Header().Get(httputil.FallbackLanguagesHeader))
+}
+
+func TestGetTextsWithDefaultFallback(t *testing.T) {
+	assert := assert.New(t)
+	os.Setenv("DEFAULT_FALLBACK_LANGUAGE", "sv")
+	defer os.Unsetenv("DEFAULT_FALLBACK_LANGUAGE")
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestRequest("/v1/texts/key/ONLY_SV_TEXT_KEY", "en")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("ONLY_SV_TEXT_KEY=sv", res.Header().Get(httputil.FallbackLanguagesHeader))
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var texts models.Texts
+	err := json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal(3, len(texts))
+	assert.Equal("en-text-val", texts["TEST_TEXT_KEY"])
+	assert.Equal("sv-only-val", texts["ONLY_SV_TEXT_KEY"])
+}
+
+func TestGetTextsWithDisabledDefaultFallback(t *testing.T) {
+	assert := assert.New(t)
+	os.Setenv("DEFAULT_FALLBACK_LANGUAGE", "fr")
+	defer os.Unsetenv("DEFAULT_FALLBACK_LANGUAGE")
+	e := createTestEnv()
+	server := newServer(e)
+
+	disabled := false
+	req := createTestBodyRequest(http.MethodPost, "/v1/languages", "", languageRequest{ID: "fr", Enabled: &disabled})
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	ctx := context.New(stdctx.Background(), "TestGetTextsWithDisabledDefaultFallback", "")
+	err := repository.NewTextRepository(e.db).Save(ctx, models.TranslatedText{Key: "ONLY_SV_TEXT_KEY", Language: "fr", Value: "fr-only-val"})
+	assert.NoError(err)
+
+	// Disabled default fallback
+	req = createTestRequest("/v1/texts/key/ONLY_SV_TEXT_KEY", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP", "*")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+}
+
+func TestListTexts(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	keys := make([]string, 0)
+	cursor := ""
+	for i := 0; i < 3; i++ {
+		page := performTestListTexts(t, server.Handler, "/v1/texts?limit=3&cursor="+cursor)
+		for _, text := range page.Texts {
+			keys = append(keys, text.Key+"/"+text.Language)
+		}
+		cursor = page.NextCursor
+	}
+	assert.Equal("", cursor)
+	assert.Equal([]string{
+		"NOT_IN_GROUP/en", "NOT_IN_GROUP/sv", "ONLY_SV_TEXT_KEY/sv", "OTHER_TEXT_KEY/en",
+		"OTHER_TEXT_KEY/sv", "TEST_TEXT_KEY/en", "TEST_TEXT_KEY/sv",
+	}, keys)
+
+	page := performTestListTexts(t, server.Handler, "/v1/texts?language=en")
+	assert.Equal(3, len(page.Texts))
+	assert.Equal("", page.NextCursor)
+
+	page = performTestListTexts(t, server.Handler, "/v1/texts?group=MOBILE_APP&language=sv&limit=2")
+	assert.Equal(2, len(page.Texts))
+	assert.Equal("ONLY_SV_TEXT_KEY", page.Texts[0].Key)
+	page = performTestListTexts(t, server.Handler, "/v1/texts?group=MOBILE_APP&language=sv&limit=2&cursor="+page.NextCursor)
+	assert.Equal(1, len(page.Texts))
+	assert.Equal("TEST_TEXT_KEY", page.Texts[0].Key)
+	assert.Equal("", page.NextCursor)
+
+	page = performTestListTexts(t, server.Handler, "/v1/texts?prefix=O")
+	assert.Equal(3, len(page.Texts))
+
+	page = performTestListTexts(t, server.Handler, "/v1/texts?prefix=_")
+	assert.Equal(0, len(page.Texts))
+
+	since := time.Now()
+	body := models.TranslatedText{Value: "en-updated-val"}
+	req := createTestBodyRequest(http.MethodPut, "/v1/admin/texts/key/OTHER_TEXT_KEY/language/en", "", body)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	page = performTestListTexts(t, server.Handler, "/v1/texts?updatedSince="+url.QueryEscape(since.Format(time.RFC3339Nano)))
+	assert.Equal(1, len(page.Texts))
+	assert.Equal("en-updated-val", page.Texts[0].Value)
+
+	for _, path := range []string{"/v1/texts?limit=0", "/v1/texts?cursor=invalid", "/v1/texts?updatedSince=yesterday"} {
+		req = createTestRequest(path, "")
+		res = performTestRequest(server.Handler, req)
+		assert.Equal(http.StatusBadRequest, res.Code, path)
+	}
+}
+
+func performTestListTexts(t *testing.T, handler http.Handler, path string) models.TextPage {
+	req := createTestRequest(path, "")
+	res := performTestRequest(handler, req)
+	assert.Equal(t, http.StatusOK, res.Code, path)
+
+	var page models.TextPage
+	err := json.NewDecoder(res.Body).Decode(&page)
+	assert.NoError(t, err)
+	return page
+}
+
 func createTestEnv() *env {
 	os.Setenv("STORAGE", "memory")
 	os.Setenv("MIGRATIONS_PATH", "../resources/db")
+	os.Setenv("AUTH_ENABLED", "false")
 
 	cfg := getConfig()
 	e := getEnv(cfg)
@@ -166,8 +546,12 @@ func createTestEnv() *env {
 
 	ctx := context.New(stdctx.Background(), "createTestEnv", "")
 	errs := make([]error, 13)
-	errs[0] = langRepo.Save(ctx, "sv")
-	errs[1] = langRepo.Save(ctx, "en")
+	errs[0] = langRepo.Save(ctx, models.Language{
+		ID: "sv", EnglishName: "Swedish", NativeName: "Svenska", Enabled: true,
+	})
+	errs[1] = langRepo.Save(ctx, models.Language{
+		ID: "en", EnglishName: "English", NativeName: "English", Enabled: true,
+	})
 	errs[2] = textRepo.Save(ctx, models.TranslatedText{
 		Key: "TEST_TEXT_KEY", Language: "sv", Value: "sv-text-val",
 	})
@@ -215,6 +599,23 @@ func createTestRequest(route, language string) *http.Request {
 	return req
 }
 
+func createTestBodyRequest(method, route, language string, body interface{}) *http.Request {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		log.Fatal("Failed to marshal request body", zap.Error(err))
+	}
+
+	req, err := http.NewRequest(method, route, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		log.Fatal("Failed to create request", zap.Error(err))
+	}
+
+	req.Header.Set(httputil.RequestIDHeader, id.New())
+	req.Header.Set(httputil.AcceptLanguage, language)
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
 func ensureNoErrors(errs []error) {
 	for i, err := range errs {
 		if err != nil {
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+type publishRequest struct {
+	Key      string `json:"key"`
+	Language string `json:"language"`
+	Group    string `json:"group"`
+}
+
+func (e *env) getDrafts(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("getDrafts", "ctx", ctx)
+
+	drafts, err := e.draftManager.GetAll(ctx, c.Query("key"), c.Query("language"), c.Query("group"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, drafts)
+}
+
+func (e *env) putDraft(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("putDraft", "ctx", ctx)
+
+	text, err := parseTextValue(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	draft, err := e.draftManager.Save(ctx, text)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
+
+func (e *env) discardDraft(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("discardDraft", "ctx", ctx)
+
+	err := e.draftManager.Discard(ctx, c.Param("key"), c.Param("language"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	httputil.SendOK(c)
+}
+
+func (e *env) publishDrafts(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("publishDrafts", "ctx", ctx)
+
+	var body publishRequest
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid request body"))
+		return
+	}
+
+	published, err := e.draftManager.Publish(ctx, body.Key, body.Language, body.Group)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, published)
+}
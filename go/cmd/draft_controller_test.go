@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveAndPreviewDraft(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	body := textValueRequest{Value: "sv-draft-val"}
+	req := createTestBodyRequest(http.MethodPut, "/v1/admin/drafts/key/TEST_TEXT_KEY/language/sv", "", body)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var draft models.TextDraft
+	err := json.NewDecoder(res.Body).Decode(&draft)
+	assert.NoError(err)
+	assert.Equal("sv-draft-val", draft.Value)
+	assert.Equal("sv-text-val", draft.PublishedValue)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var texts models.Texts
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("sv-text-val", texts["TEST_TEXT_KEY"])
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	req.Header.Set(httputil.PreviewHeader, "true")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("private, no-cache", res.Header().Get(httputil.CacheControlHeader))
+
+	texts = models.Texts{}
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("sv-draft-val", texts["TEST_TEXT_KEY"])
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP", "sv")
+	req.Header.Set(httputil.PreviewHeader, "true")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	texts = models.Texts{}
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal(3, len(texts))
+	assert.Equal("sv-draft-val", texts["TEST_TEXT_KEY"])
+	assert.Equal("sv-other-val", texts["OTHER_TEXT_KEY"])
+
+	body = textValueRequest{Value: "{count, plural, one {#}}"}
+	req = createTestBodyRequest(http.MethodPut, "/v1/admin/drafts/key/TEST_TEXT_KEY/language/sv", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/admin/drafts/key/TEST_TEXT_KEY/language/sv", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/admin/drafts/key/TEST_TEXT_KEY/language/sv", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	req.Header.Set(httputil.PreviewHeader, "true")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	texts = models.Texts{}
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("sv-text-val", texts["TEST_TEXT_KEY"])
+}
+
+func TestPublishDrafts(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	drafts := []models.TranslatedText{
+		{Key: "TEST_TEXT_KEY", Language: "sv", Value: "sv-draft-val"},
+		{Key: "TEST_TEXT_KEY", Language: "en", Value: "en-draft-val"},
+		{Key: "NOT_IN_GROUP", Language: "sv", Value: "sv-non-group-draft"},
+	}
+	for _, draft := range drafts {
+		route := "/v1/admin/drafts/key/" + draft.Key + "/language/" + draft.Language
+		req := createTestBodyRequest(http.MethodPut, route, "", textValueRequest{Value: draft.Value})
+		res := performTestRequest(server.Handler, req)
+		assert.Equal(http.StatusOK, res.Code)
+	}
+
+	req := createTestRequest("/v1/admin/drafts?group=MOBILE_APP", "")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var staged []models.TextDraft
+	err := json.NewDecoder(res.Body).Decode(&staged)
+	assert.NoError(err)
+	assert.Equal(2, len(staged))
+
+	req = createTestBodyRequest(http.MethodPost, "/v1/admin/drafts/publish", "", publishRequest{})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	req = createTestBodyRequest(http.MethodPost, "/v1/admin/drafts/publish", "", publishRequest{Group: "MOBILE_APP", Language: "sv"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var published []models.TextDraft
+	err = json.NewDecoder(res.Body).Decode(&published)
+	assert.NoError(err)
+	assert.Equal(1, len(published))
+	assert.Equal("TEST_TEXT_KEY", published[0].Key)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var texts models.Texts
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("sv-draft-val", texts["TEST_TEXT_KEY"])
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	texts = models.Texts{}
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("en-text-val", texts["TEST_TEXT_KEY"])
+
+	req = createTestBodyRequest(http.MethodPost, "/v1/admin/drafts/publish", "", publishRequest{Key: "NOT_IN_GROUP"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY/history?language=sv", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var revisions []models.TextRevision
+	err = json.NewDecoder(res.Body).Decode(&revisions)
+	assert.NoError(err)
+	assert.Equal(models.Published, revisions[0].Action)
+	assert.Equal("sv-draft-val", revisions[0].Value)
+
+	req = createTestRequest("/v1/admin/drafts", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	staged = nil
+	err = json.NewDecoder(res.Body).Decode(&staged)
+	assert.NoError(err)
+	assert.Equal(1, len(staged))
+	assert.Equal("en", staged[0].Language)
+}
@@ -3,18 +3,48 @@ package main
 import (
 	"database/sql"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/CzarSimon/text-service/go/pkg/repository"
 	"github.com/CzarSimon/text-service/go/pkg/service"
+	"github.com/CzarSimon/text-service/go/pkg/utils/cache"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
 	"github.com/CzarSimon/text-service/go/pkg/utils/dbutil"
 	"github.com/CzarSimon/text-service/go/pkg/utils/environ"
+	"github.com/CzarSimon/text-service/go/pkg/utils/jwt"
 	"go.uber.org/zap"
 )
 
+const changeBufferSize = 100
+
 type env struct {
-	cfg        config
-	db         *sql.DB
-	textGetter service.TextGetter
+	cfg                  config
+	db                   *sql.DB
+	textGetter           service.TextGetter
+	textRenderer         service.TextRenderer
+	textManager          service.TextManager
+	textHistory          service.TextHistory
+	languageManager      service.LanguageManager
+	groupManager         service.GroupManager
+	releaseManager       service.ReleaseManager
+	draftManager         service.DraftManager
+	projectManager       service.ProjectManager
+	apiKeyManager        service.APIKeyManager
+	webhookManager       service.WebhookManager
+	webhookNotifier      service.WebhookNotifier
+	webhookWorker        service.WebhookWorker
+	reportGenerator      service.ReportGenerator
+	textStats            service.TextStats
+	textSearch           service.TextSearch
+	textLister           service.TextLister
+	gettextConverter     service.GettextConverter
+	xliffConverter       service.XLIFFConverter
+	spreadsheetConverter service.SpreadsheetConverter
+	changes              service.ChangeBroadcaster
+	authenticator        service.Authenticator
+	languageNegotiator   service.LanguageNegotiator
+	textFormats          []textFormat
 }
 
 func (e *env) Close() error {
@@ -32,12 +62,96 @@ func getEnv(cfg config) *env {
 	languageRepo := repository.NewLanguageRepository(db)
 	textRepo := repository.NewTextRepository(db)
 	groupRepo := repository.NewGroupRepository(db)
+	historyRepo := repository.NewHistoryRepository(db)
+	releaseRepo := repository.NewReleaseRepository(db)
+	draftRepo := repository.NewDraftRepository(db)
+	projectRepo := repository.NewProjectRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	webhookRepo := repository.NewWebhookRepository(db)
+	reportRepo := repository.NewReportRepository(db)
+	searchRepo := repository.NewSearchRepository(db, cfg.db.Driver())
+	gettextRepo := repository.NewGettextRepository(db)
+
+	listeners := service.ChangeListeners{}
+	textGetter := service.NewTextGetter(languageRepo, textRepo, groupRepo, releaseRepo, draftRepo, cfg.defaultFallback)
+	negotiator := service.NewLanguageNegotiator(languageRepo, cfg.defaultFallback)
+	if cfg.cacheSize > 0 {
+		cachedGetter := service.NewCachedTextGetter(textGetter, cache.New("texts", cfg.cacheSize, cfg.cacheTTL))
+		cachedNegotiator := service.NewCachedLanguageNegotiator(negotiator, cache.New("languages", cfg.cacheSize, cfg.cacheTTL))
+		listeners = append(listeners, cachedGetter, cachedNegotiator)
+		textGetter = cachedGetter
+		negotiator = cachedNegotiator
+	}
+	textStats := service.NewTextStats(languageRepo, reportRepo, projectRepo)
+	changes := service.NewChangeBroadcaster(changeBufferSize)
+	webhookNotifier := service.NewWebhookNotifier()
+	listeners = append(listeners, textStats, webhookNotifier, changes)
+
+	textManager := service.NewTextManager(languageRepo, textRepo, listeners)
 
 	return &env{
-		cfg:        cfg,
-		db:         db,
-		textGetter: service.NewTextGetter(languageRepo, textRepo, groupRepo),
+		cfg:                  cfg,
+		db:                   db,
+		textGetter:           textGetter,
+		textRenderer:         service.NewTextRenderer(textGetter),
+		textManager:          textManager,
+		textHistory:          service.NewTextHistory(historyRepo, textManager),
+		languageManager:      service.NewLanguageManager(languageRepo, listeners),
+		groupManager:         service.NewGroupManager(groupRepo, listeners),
+		releaseManager:       service.NewReleaseManager(releaseRepo, languageRepo, textRepo, groupRepo, listeners),
+		draftManager:         service.NewDraftManager(languageRepo, draftRepo, listeners),
+		projectManager:       service.NewProjectManager(projectRepo, listeners),
+		apiKeyManager:        service.NewAPIKeyManager(apiKeyRepo),
+		webhookManager:       service.NewWebhookManager(webhookRepo),
+		webhookNotifier:      webhookNotifier,
+		webhookWorker:        service.NewWebhookWorker(webhookRepo, webhookNotifier, cfg.webhook),
+		reportGenerator:      service.NewReportGenerator(reportRepo, languageRepo, groupRepo),
+		textStats:            textStats,
+		textSearch:           service.NewTextSearch(searchRepo),
+		textLister:           service.NewTextLister(textRepo),
+		gettextConverter:     service.NewGettextConverter(languageRepo, textRepo, gettextRepo, listeners),
+		xliffConverter:       service.NewXLIFFConverter(languageRepo, textRepo, groupRepo, listeners),
+		spreadsheetConverter: service.NewSpreadsheetConverter(languageRepo, textRepo, groupRepo, listeners),
+		changes:              changes,
+		authenticator:        service.NewAuthenticator(apiKeyRepo, getTokenVerifier(cfg)),
+		languageNegotiator:   negotiator,
+		textFormats:          newTextFormats(cfg),
+	}
+}
+
+func getTokenVerifier(cfg config) *jwt.Verifier {
+	if cfg.jwtKeyFile == "" {
+		return nil
+	}
+
+	verifier, err := jwt.NewVerifierFromFile(cfg.jwtKeyFile)
+	if err != nil {
+		log.Panicw("Failed to load jwt key file", "path", cfg.jwtKeyFile, "error", err)
+	}
+
+	return verifier
+}
+
+// checkDefaultFallback warns if the default fallback language is not an enabled language.
+// It is then left out of fallback chains until the language is created or enabled.
+func (e *env) checkDefaultFallback(ctx *context.Context) {
+	if e.cfg.defaultFallback == "" {
+		return
+	}
+
+	languages, err := e.languageManager.GetAll(ctx, true)
+	if err != nil {
+		log.Errorw("Failed to check the default fallback language", "error", err)
+		return
+	}
+
+	for _, language := range languages {
+		if language.ID == e.cfg.defaultFallback {
+			return
+		}
 	}
+
+	log.Warnw("The default fallback language is not an enabled language and will be skipped", "language", e.cfg.defaultFallback)
 }
 
 func (e *env) checkHealth() error {
@@ -45,9 +159,20 @@ func (e *env) checkHealth() error {
 }
 
 type config struct {
-	db             dbutil.Config
-	port           string
-	migrationsPath string
+	db               dbutil.Config
+	port             string
+	grpcPort         string
+	migrationsPath   string
+	defaultFallback  string
+	cacheSize        int
+	cacheTTL         time.Duration
+	maxAge           int
+	authEnabled      bool
+	jwtKeyFile       string
+	webhook          service.WebhookConfig
+	streamHeartbeat  time.Duration
+	i18nextSeparator string
+	maxImportSize    int64
 }
 
 func getConfig() config {
@@ -59,10 +184,57 @@ func getConfig() config {
 	}
 
 	return config{
-		db:             getDBConfig(storageType),
-		port:           environ.Get("SERVICE_PORT", "8080"),
-		migrationsPath: migrationPath,
+		db:               getDBConfig(storageType),
+		port:             environ.Get("SERVICE_PORT", "8080"),
+		grpcPort:         environ.Get("GRPC_PORT", ""),
+		migrationsPath:   migrationPath,
+		defaultFallback:  environ.Get("DEFAULT_FALLBACK_LANGUAGE", ""),
+		cacheSize:        mustParseInt(environ.Get("CACHE_SIZE", "10000")),
+		cacheTTL:         mustParseDuration(environ.Get("CACHE_TTL", "1m")),
+		maxAge:           mustParseInt(environ.Get("HTTP_CACHE_MAX_AGE", "60")),
+		authEnabled:      mustParseBool(environ.Get("AUTH_ENABLED", "false")),
+		jwtKeyFile:       environ.Get("JWT_KEY_FILE", ""),
+		webhook:          getWebhookConfig(),
+		streamHeartbeat:  mustParseDuration(environ.Get("STREAM_HEARTBEAT_INTERVAL", "15s")),
+		i18nextSeparator: environ.Get("I18NEXT_KEY_SEPARATOR", "."),
+		maxImportSize:    int64(mustParseInt(environ.Get("MAX_IMPORT_SIZE", "10485760"))),
+	}
+}
+
+func getWebhookConfig() service.WebhookConfig {
+	return service.WebhookConfig{
+		PollInterval: mustParseDuration(environ.Get("WEBHOOK_POLL_INTERVAL", "5s")),
+		MaxAttempts:  mustParseInt(environ.Get("WEBHOOK_MAX_ATTEMPTS", "8")),
+		RetryBackoff: mustParseDuration(environ.Get("WEBHOOK_RETRY_BACKOFF", "10s")),
+		Timeout:      mustParseDuration(environ.Get("WEBHOOK_TIMEOUT", "10s")),
+	}
+}
+
+func mustParseInt(value string) int {
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		log.Panicw("Failed to parse integer", "value", value, "error", err)
 	}
+
+	return i
+}
+
+func mustParseBool(value string) bool {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Panicw("Failed to parse boolean", "value", value, "error", err)
+	}
+
+	return b
+}
+
+func mustParseDuration(value string) time.Duration {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Panicw("Failed to parse duration", "value", value, "error", err)
+	}
+
+	return d
 }
 
 func getDBConfig(storageType string) dbutil.Config {
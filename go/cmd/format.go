@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/CzarSimon/text-service/go/pkg/utils/resource"
+	"github.com/gin-gonic/gin"
+)
+
+const jsonMediaType = "application/json"
+
+// textFormat encoding of resolved texts which clients may ask for by name
+// in the format query parameter or by media type in the Accept header.
+type textFormat struct {
+	name      string
+	mediaType string
+	encode    func(w io.Writer, texts map[string]string) error
+}
+
+// newTextFormats registers the formats texts can be sent in, the first being the default.
+func newTextFormats(cfg config) []textFormat {
+	return []textFormat{
+		{name: "json", mediaType: jsonMediaType, encode: encodeJSON},
+		{name: "android", mediaType: "application/vnd.android.strings+xml", encode: resource.WriteAndroid},
+		{name: "strings", mediaType: "text/vnd.apple.strings", encode: resource.WriteStrings},
+		{name: "stringsdict", mediaType: "application/vnd.apple.stringsdict+xml", encode: resource.WriteStringsdict},
+		{name: "properties", mediaType: "text/x-java-properties", encode: resource.WriteProperties},
+		{name: "yaml", mediaType: "application/yaml", encode: resource.WriteYAML},
+		{name: "i18next", mediaType: "application/vnd.i18next+json", encode: resource.I18nextWriter(cfg.i18nextSeparator)},
+	}
+}
+
+// negotiateTextFormat picks the format named in the format query parameter, or otherwise
+// the first format whose media type is accepted, defaulting to JSON.
+func (e *env) negotiateTextFormat(c *gin.Context) (textFormat, error) {
+	if name := c.Query("format"); name != "" {
+		for _, f := range e.textFormats {
+			if f.name == name {
+				return f, nil
+			}
+		}
+
+		return textFormat{}, httputil.BadRequest(fmt.Sprintf("Unsupported format: %s", name))
+	}
+
+	accept := c.GetHeader(httputil.AcceptHeader)
+	for _, f := range e.textFormats[1:] {
+		if strings.Contains(accept, f.mediaType) {
+			return f, nil
+		}
+	}
+
+	return e.textFormats[0], nil
+}
+
+func encodeJSON(w io.Writer, texts map[string]string) error {
+	body, err := json.Marshal(texts)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(body)
+	return err
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTextsByGroupMobileFormats(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	body := models.TranslatedText{Key: "FILE_COUNT", Language: "en", Value: "{n, plural, one {# file} other {# files}}"}
+	req := createTestBodyRequest(http.MethodPost, "/v1/admin/texts", "", body)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+	req = createTestBodyRequest(http.MethodPost, "/v1/groups/MOBILE_APP/members", "", groupMembersRequest{Keys: []string{"FILE_COUNT"}})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP?format=android", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("application/vnd.android.strings+xml; charset=utf-8", res.Header().Get("Content-Type"))
+	assert.Equal([]string{"Accept-Language, X-Preview", "Accept"}, res.Header()[httputil.VaryHeader])
+	assert.Equal(`<?xml version="1.0" encoding="utf-8"?>
+<resources>
+    <plurals name="FILE_COUNT">
+        <item quantity="one">%1$d file</item>
+        <item quantity="other">%1$d files</item>
+    </plurals>
+    <string name="OTHER_TEXT_KEY">en-other-val</string>
+    <string name="TEST_TEXT_KEY">en-text-val</string>
+</resources>
+`, res.Body.String())
+	androidETag := res.Header().Get(httputil.ETagHeader)
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP", "en")
+	req.Header.Set(httputil.AcceptHeader, "text/vnd.apple.strings")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("text/vnd.apple.strings; charset=utf-8", res.Header().Get("Content-Type"))
+	assert.Equal(`"OTHER_TEXT_KEY" = "en-other-val";
+"TEST_TEXT_KEY" = "en-text-val";
+`, res.Body.String())
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP?format=stringsdict", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Contains(res.Body.String(), "<key>FILE_COUNT</key>")
+	assert.Contains(res.Body.String(), "<string>%1$ld files</string>")
+	assert.NotContains(res.Body.String(), "TEST_TEXT_KEY")
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP?format=json", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("application/json; charset=utf-8", res.Header().Get("Content-Type"))
+	assert.NotEqual(androidETag, res.Header().Get(httputil.ETagHeader))
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP?format=android", "en")
+	req.Header.Set("If-None-Match", androidETag)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotModified, res.Code)
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP?format=docx", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	// Keys which map to the same resource name
+	body = models.TranslatedText{Key: "FILE-COUNT", Language: "en", Value: "Files"}
+	req = createTestBodyRequest(http.MethodPost, "/v1/admin/texts", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+	req = createTestBodyRequest(http.MethodPost, "/v1/groups/MOBILE_APP/members", "", groupMembersRequest{Keys: []string{"FILE-COUNT"}})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP?format=android", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusConflict, res.Code)
+	assert.Contains(res.Body.String(), "FILE-COUNT, FILE_COUNT")
+}
+
+func TestGetTextsFormats(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestRequest("/v1/texts/key/TEST_TEXT_KEY?format=properties", "sv")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("text/x-java-properties; charset=utf-8", res.Header().Get("Content-Type"))
+	assert.Equal("TEST_TEXT_KEY=sv-text-val\n", res.Body.String())
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP", "en")
+	req.Header.Set(httputil.AcceptHeader, "application/yaml")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("application/yaml; charset=utf-8", res.Header().Get("Content-Type"))
+	assert.Equal("\"OTHER_TEXT_KEY\": \"en-other-val\"\n\"TEST_TEXT_KEY\": \"en-text-val\"\n", res.Body.String())
+
+	body := models.TranslatedText{Key: "menu.file.open", Language: "en", Value: "Open"}
+	req = createTestBodyRequest(http.MethodPost, "/v1/admin/texts", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+	req = createTestBodyRequest(http.MethodPost, "/v1/groups/MOBILE_APP/members", "", groupMembersRequest{Keys: []string{"menu.file.open"}})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP", "en")
+	req.Header.Set(httputil.AcceptHeader, "application/vnd.i18next+json")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.JSONEq(`{
+		"OTHER_TEXT_KEY": "en-other-val",
+		"TEST_TEXT_KEY": "en-text-val",
+		"menu": {"file": {"open": "Open"}}
+	}`, res.Body.String())
+
+	e.textFormats = newTextFormats(config{i18nextSeparator: "_"})
+	req = createTestRequest("/v1/texts/group/MOBILE_APP?format=i18next", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.JSONEq(`{
+		"OTHER": {"TEXT": {"KEY": "en-other-val"}},
+		"TEST": {"TEXT": {"KEY": "en-text-val"}},
+		"menu.file.open": "Open"
+	}`, res.Body.String())
+}
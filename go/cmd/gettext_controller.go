@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/gettext"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	poMediaType = "text/x-gettext-translation"
+	moMediaType = "application/x-gettext-translation"
+)
+
+func (e *env) exportGettext(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("exportGettext", "ctx", ctx)
+
+	language := c.Param("language")
+	compiled := wantsFormat(c, "mo", moMediaType)
+	body, err := e.exportGettextCatalog(ctx, language, compiled)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if compiled {
+		c.Header(httputil.ContentDisposition, `attachment; filename="`+language+`.mo"`)
+		c.Data(http.StatusOK, moMediaType, body)
+		return
+	}
+
+	c.Header(httputil.ContentDisposition, `attachment; filename="`+language+`.po"`)
+	c.Data(http.StatusOK, poMediaType+"; charset=utf-8", body)
+}
+
+func (e *env) importGettext(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("importGettext", "ctx", ctx)
+
+	data, err := e.readImportBody(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	catalog, err := parseGettextCatalog(data)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	result, err := e.gettextConverter.Import(ctx, c.Param("language"), catalog)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// parseGettextCatalog parses a .mo file if the data starts with its magic number and a .po file otherwise.
+func parseGettextCatalog(data []byte) (gettext.File, error) {
+	parse := gettext.ParsePO
+	if gettext.IsMO(data) {
+		parse = gettext.ParseMO
+	}
+
+	catalog, err := parse(bytes.NewReader(data))
+	if err != nil {
+		return gettext.File{}, httputil.BadRequest("Invalid gettext catalog: " + err.Error())
+	}
+
+	return catalog, nil
+}
+
+// exportGettextCatalog exports the texts of a language as a .po file or, if compiled, as a .mo file.
+func (e *env) exportGettextCatalog(ctx *context.Context, language string, compiled bool) ([]byte, error) {
+	catalog, err := e.gettextConverter.Export(ctx, language)
+	if err != nil {
+		return nil, err
+	}
+
+	write := gettext.WritePO
+	if compiled {
+		write = gettext.WriteMO
+	}
+
+	var buf bytes.Buffer
+	err = write(&buf, catalog)
+	if err != nil {
+		controllerLog.Errorw("Failed to write gettext catalog", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	stdctx "context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/service"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/gettext"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/CzarSimon/text-service/go/pkg/utils/id"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSwedishPO = `msgid ""
+msgstr ""
+"Language: sv\n"
+"Content-Type: text/plain; charset=UTF-8\n"
+"Plural-Forms: nplurals=2; plural=(n != 1);\n"
+
+# Checked by the copy team.
+#. Title of the start page
+#: src/start.js:4
+msgctxt "start"
+msgid "title"
+msgstr "Välkommen"
+
+#, fuzzy
+msgid "Goodbye"
+msgstr "Hej då"
+
+msgid "Untranslated"
+msgstr ""
+
+msgid "One file"
+msgid_plural "%d files"
+msgstr[0] "En fil"
+msgstr[1] "%d filer, # st"
+
+msgid "TEST_TEXT_KEY"
+msgstr "sv-imported-val"
+`
+
+func TestImportGettext(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestRawRequest(http.MethodPost, "/v1/admin/gettext/sv", testSwedishPO)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var result models.GettextImport
+	err := json.NewDecoder(res.Body).Decode(&result)
+	assert.NoError(err)
+	assert.Equal(models.GettextImport{Language: "sv", Created: 2, Updated: 1, Skipped: 2}, result)
+
+	page := performTestListTexts(t, server.Handler, "/v1/texts?language=sv&prefix=start.")
+	assert.Len(page.Texts, 1)
+	assert.Equal("start.title", page.Texts[0].Key)
+	assert.Equal("Välkommen", page.Texts[0].Value)
+
+	page = performTestListTexts(t, server.Handler, "/v1/texts?language=sv&prefix=gettext.")
+	assert.Len(page.Texts, 1)
+	assert.Equal("{n, plural, one {En fil} other {%d filer, '#' st}}", page.Texts[0].Value)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Contains(res.Body.String(), "sv-imported-val")
+
+	for _, body := range []string{
+		"msgid \"a\"\nmsgstr \"{broken\"\n",
+		"msgid \"a\"\nmsgstr \"b\"\n\nmsgid \"a\"\nmsgstr \"c\"\n",
+		"msgid \"a\"\nmsgid_plural \"as\"\nmsgstr[0] \"b\"\n",
+		"msgid \"\"\nmsgstr \"Plural-Forms: nplurals=2; plural=n ?;\\n\"\n",
+		"msgid \"\"\nmsgstr \"Content-Type: text/plain; charset=ISO-8859-1\\n\"\n",
+		"msgstr \"no msgid\"\nmsgstr \"again\"\nnonsense\n",
+	} {
+		req = createTestRawRequest(http.MethodPost, "/v1/admin/gettext/sv", body)
+		res = performTestRequest(server.Handler, req)
+		assert.Equal(http.StatusBadRequest, res.Code, body)
+	}
+
+	req = createTestRawRequest(http.MethodPost, "/v1/admin/gettext/fi", testSwedishPO)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	e.cfg.maxImportSize = int64(len(testSwedishPO) - 1)
+	req = createTestRawRequest(http.MethodPost, "/v1/admin/gettext/sv", testSwedishPO)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusRequestEntityTooLarge, res.Code)
+}
+
+func TestImportGettextNotifiesAction(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+
+	recorder := &changeRecorder{}
+	converter := service.NewGettextConverter(
+		repository.NewLanguageRepository(e.db),
+		repository.NewTextRepository(e.db),
+		repository.NewGettextRepository(e.db),
+		recorder,
+	)
+
+	catalog, err := gettext.ParsePO(strings.NewReader(testSwedishPO))
+	assert.NoError(err)
+
+	ctx := context.New(stdctx.Background(), "TestImportGettextNotifiesAction", "")
+	_, err = converter.Import(ctx, "sv", catalog)
+	assert.NoError(err)
+
+	actions := make(map[string]string)
+	for _, change := range recorder.changes {
+		actions[change.Key] = change.Action
+	}
+	assert.Equal(3, len(actions))
+	assert.Equal(models.Created, actions["start.title"])
+	assert.Equal(models.Updated, actions["TEST_TEXT_KEY"])
+}
+
+func TestExportGettext(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestRawRequest(http.MethodPost, "/v1/admin/gettext/sv", testSwedishPO)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/admin/gettext/sv", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("text/x-gettext-translation; charset=utf-8", res.Header().Get("Content-Type"))
+	assert.Equal(`attachment; filename="sv.po"`, res.Header().Get(httputil.ContentDisposition))
+
+	catalog, err := gettext.ParsePO(res.Body)
+	assert.NoError(err)
+	assert.Equal("nplurals=2; plural=(n != 1);", catalog.HeaderField("Plural-Forms"))
+	messages := make(map[string]gettext.Message)
+	for _, msg := range catalog.Messages {
+		messages[msg.Key()] = msg
+	}
+	assert.Len(messages, 6)
+
+	title := messages["start\x04title"]
+	assert.Equal([]string{"Välkommen"}, title.Str)
+	assert.Equal([]string{"Checked by the copy team."}, title.TranslatorComments)
+	assert.Equal([]string{"Title of the start page"}, title.ExtractedComments)
+	assert.Equal([]string{"src/start.js:4"}, title.References)
+
+	files := messages["One file"]
+	assert.Equal("%d files", files.IDPlural)
+	assert.Equal([]string{"En fil", "%d filer, # st"}, files.Str)
+
+	assert.Equal([]string{"sv-imported-val"}, messages["TEST_TEXT_KEY"].Str)
+	assert.Equal([]string{"sv-only-val"}, messages["ONLY_SV_TEXT_KEY"].Str)
+	assert.Equal([]string{"sv-other-val"}, messages["OTHER_TEXT_KEY"].Str)
+
+	req = createTestRequest("/v1/admin/gettext/en", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	catalog, err = gettext.ParsePO(res.Body)
+	assert.NoError(err)
+	for _, msg := range catalog.Messages {
+		switch msg.Key() {
+		case "start\x04title":
+			assert.Equal([]string{""}, msg.Str)
+			assert.Empty(msg.TranslatorComments)
+			assert.Equal([]string{"Title of the start page"}, msg.ExtractedComments)
+		case "ONLY_SV_TEXT_KEY":
+			assert.Equal([]string{""}, msg.Str)
+		case "One file":
+			assert.Equal([]string{"", ""}, msg.Str)
+		}
+	}
+
+	req = createTestRequest("/v1/admin/gettext/sv?format=mo", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("application/x-gettext-translation", res.Header().Get("Content-Type"))
+	compiled := res.Body.Bytes()
+	catalog, err = gettext.ParseMO(bytes.NewReader(compiled))
+	assert.NoError(err)
+	assert.Len(catalog.Messages, 6)
+
+	req = createTestRawRequest(http.MethodPost, "/v1/admin/gettext/sv", string(compiled))
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Contains(res.Body.String(), `"updated":6`)
+
+	req = createTestRequest("/v1/admin/gettext/fi", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+}
+
+func createTestRawRequest(method, route, body string) *http.Request {
+	req, err := http.NewRequest(method, route, strings.NewReader(body))
+	if err != nil {
+		log.Fatal("Failed to create request")
+	}
+
+	req.Header.Set(httputil.RequestIDHeader, id.New())
+	return req
+}
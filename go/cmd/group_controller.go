@@ -0,0 +1,135 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+type groupRequest struct {
+	ID string `json:"id"`
+}
+
+type groupMembersRequest struct {
+	Keys []string `json:"keys"`
+}
+
+func (e *env) getGroups(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("getGroups", "ctx", ctx)
+
+	groups, err := e.groupManager.GetAll(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, groups)
+}
+
+func (e *env) getGroup(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("getGroup", "ctx", ctx)
+
+	group, err := e.groupManager.Get(ctx, c.Param("groupId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+func (e *env) createGroup(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("createGroup", "ctx", ctx)
+
+	var body groupRequest
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid request body"))
+		return
+	}
+
+	group, err := e.groupManager.Create(ctx, body.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, group)
+}
+
+func (e *env) renameGroup(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("renameGroup", "ctx", ctx)
+
+	var body groupRequest
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid request body"))
+		return
+	}
+
+	group, err := e.groupManager.Rename(ctx, c.Param("groupId"), body.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+func (e *env) deleteGroup(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("deleteGroup", "ctx", ctx)
+
+	err := e.groupManager.Delete(ctx, c.Param("groupId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	httputil.SendOK(c)
+}
+
+func (e *env) addGroupMembers(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("addGroupMembers", "ctx", ctx)
+
+	var body groupMembersRequest
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid request body"))
+		return
+	}
+
+	group, err := e.groupManager.AddMembers(ctx, c.Param("groupId"), body.Keys)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+func (e *env) removeGroupMembers(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("removeGroupMembers", "ctx", ctx)
+
+	var body groupMembersRequest
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid request body"))
+		return
+	}
+
+	group, err := e.groupManager.RemoveMembers(ctx, c.Param("groupId"), body.Keys)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
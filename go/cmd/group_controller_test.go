@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetGroups(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestRequest("/v1/groups", "")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var groups []models.TextGroup
+	err := json.NewDecoder(res.Body).Decode(&groups)
+	assert.NoError(err)
+	assert.Equal(1, len(groups))
+	assert.Equal("MOBILE_APP", groups[0].ID)
+	assert.Equal(3, groups[0].TextCount)
+
+	req = createTestRequest("/v1/groups/MOBILE_APP", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var group models.TextGroup
+	err = json.NewDecoder(res.Body).Decode(&group)
+	assert.NoError(err)
+	assert.Equal([]string{"ONLY_SV_TEXT_KEY", "OTHER_TEXT_KEY", "TEST_TEXT_KEY"}, group.Keys)
+
+	req = createTestRequest("/v1/groups/MISSING_GROUP", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+}
+
+func TestCreateAndDeleteGroup(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestBodyRequest(http.MethodPost, "/v1/groups", "", groupRequest{ID: "WEB_APP"})
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	req = createTestBodyRequest(http.MethodPost, "/v1/groups", "", groupRequest{ID: "WEB_APP"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusConflict, res.Code)
+
+	req = createTestBodyRequest(http.MethodPost, "/v1/groups", "", groupRequest{ID: "WEB APP"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/groups/MOBILE_APP", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/groups/MOBILE_APP", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+}
+
+func TestRenameGroup(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestBodyRequest(http.MethodPatch, "/v1/groups/MOBILE_APP", "", groupRequest{ID: "IOS_APP"})
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var group models.TextGroup
+	err := json.NewDecoder(res.Body).Decode(&group)
+	assert.NoError(err)
+	assert.Equal("IOS_APP", group.ID)
+	assert.Equal(3, group.TextCount)
+
+	req = createTestRequest("/v1/texts/group/IOS_APP", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestBodyRequest(http.MethodPatch, "/v1/groups/MOBILE_APP", "", groupRequest{ID: "ANDROID_APP"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+}
+
+func TestEditGroupMembers(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	path := "/v1/groups/MOBILE_APP/members"
+	body := groupMembersRequest{Keys: []string{"NOT_IN_GROUP", "TEST_TEXT_KEY"}}
+	req := createTestBodyRequest(http.MethodPost, path, "", body)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var group models.TextGroup
+	err := json.NewDecoder(res.Body).Decode(&group)
+	assert.NoError(err)
+	assert.Equal(4, group.TextCount)
+
+	body = groupMembersRequest{Keys: []string{"NOT_IN_GROUP", "ONLY_SV_TEXT_KEY"}}
+	req = createTestBodyRequest(http.MethodDelete, path, "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var texts models.Texts
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal(2, len(texts))
+	_, ok := texts["ONLY_SV_TEXT_KEY"]
+	assert.False(ok)
+
+	// No keys
+	req = createTestBodyRequest(http.MethodPost, path, "", groupMembersRequest{})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	// Missing group
+	req = createTestBodyRequest(http.MethodPost, "/v1/groups/MISSING_GROUP/members", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+}
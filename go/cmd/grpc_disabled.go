@@ -0,0 +1,13 @@
+//go:build !grpc
+// +build !grpc
+
+package main
+
+// startGRPCServer is a no-op when the service is built without gRPC support.
+func startGRPCServer(e *env) func() {
+	if e.cfg.grpcPort != "" {
+		log.Warnw("Not starting gRPC server, the service was built without the grpc build tag", "port", e.cfg.grpcPort)
+	}
+
+	return func() {}
+}
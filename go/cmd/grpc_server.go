@@ -0,0 +1,257 @@
+//go:build grpc
+// +build grpc
+
+package main
+
+import (
+	stdctx "context"
+	"net"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/api/textpb"
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/service"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/CzarSimon/text-service/go/pkg/utils/id"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// startGRPCServer serves the gRPC text service on the configured port
+// and returns a function which stops the server.
+func startGRPCServer(e *env) func() {
+	if e.cfg.grpcPort == "" {
+		return func() {}
+	}
+
+	lis, err := net.Listen("tcp", ":"+e.cfg.grpcPort)
+	if err != nil {
+		log.Panicw("Failed to listen for gRPC", "port", e.cfg.grpcPort, "error", err)
+	}
+
+	server := newGRPCServer(e)
+	go func() {
+		err := server.Serve(lis)
+		if err != nil {
+			log.Errorw("Unexpected error stopped gRPC server", "error", err)
+		}
+	}()
+
+	log.Info("Started gRPC text-service on port: " + e.cfg.grpcPort)
+	return func() {
+		stopped := make(chan struct{})
+		go func() {
+			server.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(shutdownTimeout):
+			server.Stop()
+		}
+	}
+}
+
+// newGRPCServer creates a gRPC server with the text service registered.
+func newGRPCServer(e *env) *grpc.Server {
+	server := grpc.NewServer()
+	textpb.RegisterTextServiceServer(server, &textServer{e: e})
+	return server
+}
+
+type textServer struct {
+	textpb.UnimplementedTextServiceServer
+	e *env
+}
+
+func (s *textServer) GetText(c stdctx.Context, req *textpb.GetTextRequest) (*textpb.Texts, error) {
+	ctx, err := s.createContext(c, req.GetProject())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	controllerLog.Debugw("textServer.GetText", "ctx", ctx)
+
+	texts, err := s.e.textGetter.Get(ctx, req.GetKey())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return toTextsMessage(ctx, texts), nil
+}
+
+func (s *textServer) GetGroup(c stdctx.Context, req *textpb.GetGroupRequest) (*textpb.Texts, error) {
+	ctx, err := s.createContext(c, req.GetProject())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	controllerLog.Debugw("textServer.GetGroup", "ctx", ctx)
+
+	texts, err := s.e.textGetter.GetGroup(ctx, req.GetGroupId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return toTextsMessage(ctx, texts), nil
+}
+
+// WatchGroup sends the texts of a group and sends them again whenever a change
+// in the project alters them, until the client cancels the stream.
+func (s *textServer) WatchGroup(req *textpb.GetGroupRequest, stream textpb.TextService_WatchGroupServer) error {
+	ctx, err := s.createContext(stream.Context(), req.GetProject())
+	if err != nil {
+		return grpcError(err)
+	}
+	controllerLog.Debugw("textServer.WatchGroup", "ctx", ctx)
+
+	changes, unsubscribe := s.e.changes.Subscribe()
+	defer unsubscribe()
+
+	var sent models.ResolvedTexts
+	send := func() error {
+		texts, err := s.e.textGetter.GetGroup(ctx, req.GetGroupId())
+		if err != nil {
+			return grpcError(err)
+		}
+
+		if sent.Texts != nil && reflect.DeepEqual(texts.Texts, sent.Texts) && reflect.DeepEqual(texts.Fallbacks, sent.Fallbacks) {
+			return nil
+		}
+
+		sent = texts
+		return stream.Send(toTextsMessage(ctx, texts))
+	}
+
+	err = send()
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case change, ok := <-changes:
+			if !ok {
+				return nil
+			}
+			if change.Project != ctx.Project && change.Type != models.LanguageChange {
+				continue
+			}
+
+			err = send()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// createContext creates a request context from the incoming metadata, propagating
+// the request id, language, preview flag and credentials as createContext does for HTTP.
+func (s *textServer) createContext(c stdctx.Context, project string) (*context.Context, error) {
+	md, _ := metadata.FromIncomingContext(c)
+	requestID := metadataValue(md, httputil.RequestIDHeader)
+	if requestID == "" {
+		requestID = id.New()
+	}
+
+	ctx := context.New(c, requestID, metadataValue(md, httputil.AcceptLanguage))
+	ctx.Preview, _ = strconv.ParseBool(metadataValue(md, httputil.PreviewHeader))
+
+	if s.e.cfg.authEnabled {
+		principal, err := s.authenticate(ctx, md)
+		if err != nil {
+			return nil, err
+		}
+
+		if !service.HasRole(principal, models.ReaderRole) {
+			return nil, httputil.Forbidden("Requires role: " + models.ReaderRole)
+		}
+		ctx.Principal = principal
+		ctx.User = principal.ID
+		ctx.Preview = ctx.Preview && service.HasRole(principal, models.TranslatorRole)
+	}
+
+	if project != "" {
+		_, err := s.e.projectManager.Get(ctx, project)
+		if err != nil {
+			return nil, err
+		}
+		ctx.Project = project
+	}
+
+	if ctx.Language == "" {
+		return nil, httputil.BadRequest("No language specified")
+	}
+
+	language, err := s.e.languageNegotiator.Negotiate(ctx, ctx.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.Language = language
+	return ctx, nil
+}
+
+func (s *textServer) authenticate(ctx *context.Context, md metadata.MD) (context.Principal, error) {
+	apiKey := metadataValue(md, httputil.APIKeyHeader)
+	if apiKey != "" {
+		return s.e.authenticator.AuthenticateAPIKey(ctx, apiKey)
+	}
+
+	authorization := metadataValue(md, httputil.AuthorizationHeader)
+	if strings.HasPrefix(authorization, bearerPrefix) {
+		return s.e.authenticator.AuthenticateToken(ctx, strings.TrimPrefix(authorization, bearerPrefix))
+	}
+
+	return context.Principal{}, httputil.Unauthorized("No credentials provided")
+}
+
+func metadataValue(md metadata.MD, header string) string {
+	values := md.Get(strings.ToLower(header))
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func toTextsMessage(ctx *context.Context, texts models.ResolvedTexts) *textpb.Texts {
+	return &textpb.Texts{
+		Language:  ctx.Language,
+		Texts:     texts.Texts,
+		Fallbacks: texts.Fallbacks,
+	}
+}
+
+// grpcError maps service errors to the gRPC status codes matching their http status.
+func grpcError(err error) error {
+	httpError, ok := err.(*httputil.Error)
+	if !ok {
+		return status.Error(codes.Internal, http.StatusText(http.StatusInternalServerError))
+	}
+
+	code := codes.Internal
+	switch httpError.StatusCode {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.FailedPrecondition
+	}
+
+	return status.Error(code, httpError.Message)
+}
@@ -0,0 +1,116 @@
+//go:build grpc
+// +build grpc
+
+package main
+
+import (
+	stdctx "context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/api/textpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGRPCGetText(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	client, stop := startTestGRPCServer(t, e)
+	defer stop()
+
+	ctx := grpcTestContext("en")
+	texts, err := client.GetText(ctx, &textpb.GetTextRequest{Key: "TEST_TEXT_KEY"})
+	assert.NoError(err)
+	assert.Equal("en", texts.GetLanguage())
+	assert.Equal(map[string]string{"TEST_TEXT_KEY": "en-text-val"}, texts.GetTexts())
+
+	_, err = client.GetText(ctx, &textpb.GetTextRequest{Key: "MISSING_KEY"})
+	assert.Equal(codes.NotFound, status.Code(err))
+
+	_, err = client.GetText(ctx, &textpb.GetTextRequest{Project: "no-such-project", Key: "TEST_TEXT_KEY"})
+	assert.Equal(codes.NotFound, status.Code(err))
+
+	_, err = client.GetText(stdctx.Background(), &textpb.GetTextRequest{Key: "TEST_TEXT_KEY"})
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func TestGRPCGetGroup(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	client, stop := startTestGRPCServer(t, e)
+	defer stop()
+
+	texts, err := client.GetGroup(grpcTestContext("sv"), &textpb.GetGroupRequest{GroupId: "MOBILE_APP"})
+	assert.NoError(err)
+	assert.Equal("sv", texts.GetLanguage())
+	assert.Equal(map[string]string{
+		"TEST_TEXT_KEY":    "sv-text-val",
+		"OTHER_TEXT_KEY":   "sv-other-val",
+		"ONLY_SV_TEXT_KEY": "sv-only-val",
+	}, texts.GetTexts())
+
+	_, err = client.GetGroup(grpcTestContext("sv"), &textpb.GetGroupRequest{GroupId: "NO_SUCH_ID"})
+	assert.Equal(codes.NotFound, status.Code(err))
+}
+
+func TestGRPCWatchGroup(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	client, stop := startTestGRPCServer(t, e)
+	defer stop()
+
+	ctx, cancel := stdctx.WithTimeout(grpcTestContext("sv"), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.WatchGroup(ctx, &textpb.GetGroupRequest{GroupId: "MOBILE_APP"})
+	assert.NoError(err)
+
+	texts, err := stream.Recv()
+	assert.NoError(err)
+	assert.Equal("sv-text-val", texts.GetTexts()["TEST_TEXT_KEY"])
+
+	server := newServer(e)
+	path := "/v1/admin/texts/key/TEST_TEXT_KEY/language/sv"
+	req := createTestBodyRequest(http.MethodPut, path, "", textValueRequest{Value: "sv-text-val-2"})
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	texts, err = stream.Recv()
+	assert.NoError(err)
+	assert.Equal("sv-text-val-2", texts.GetTexts()["TEST_TEXT_KEY"])
+}
+
+// startTestGRPCServer serves the gRPC text service over an in-memory connection
+// and returns a client connected to it along with a function which stops both.
+func startTestGRPCServer(t *testing.T, e *env) (textpb.TextServiceClient, func()) {
+	lis := bufconn.Listen(1024 * 1024)
+	server := newGRPCServer(e)
+	go server.Serve(lis)
+
+	dialer := func(stdctx.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return textpb.NewTextServiceClient(conn), func() {
+		conn.Close()
+		server.Stop()
+	}
+}
+
+func grpcTestContext(language string) stdctx.Context {
+	return metadata.AppendToOutgoingContext(stdctx.Background(), "accept-language", language)
+}
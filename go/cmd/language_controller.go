@@ -0,0 +1,88 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+type languageRequest struct {
+	ID          string `json:"id"`
+	EnglishName string `json:"englishName"`
+	NativeName  string `json:"nativeName"`
+	Direction   string `json:"direction"`
+	Fallback    string `json:"fallback"`
+	Enabled     *bool  `json:"enabled"`
+}
+
+func (r languageRequest) language() models.Language {
+	enabled := true
+	if r.Enabled != nil {
+		enabled = *r.Enabled
+	}
+
+	return models.Language{
+		ID:          r.ID,
+		EnglishName: r.EnglishName,
+		NativeName:  r.NativeName,
+		Direction:   r.Direction,
+		Fallback:    r.Fallback,
+		Enabled:     enabled,
+	}
+}
+
+func (e *env) getLanguages(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("getLanguages", "ctx", ctx)
+
+	// Disabled languages are listed as well unless ?enabled=true is given.
+	enabledOnly, err := strconv.ParseBool(c.DefaultQuery("enabled", "false"))
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid enabled parameter"))
+		return
+	}
+
+	languages, err := e.languageManager.GetAll(ctx, enabledOnly)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, languages)
+}
+
+func (e *env) createLanguage(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("createLanguage", "ctx", ctx)
+
+	var body languageRequest
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid request body"))
+		return
+	}
+
+	language, err := e.languageManager.Create(ctx, body.language())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, language)
+}
+
+func (e *env) deleteLanguage(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("deleteLanguage", "ctx", ctx)
+
+	err := e.languageManager.Delete(ctx, c.Param("languageId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	httputil.SendOK(c)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLanguages(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestRequest("/v1/languages", "")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var languages []models.Language
+	err := json.NewDecoder(res.Body).Decode(&languages)
+	assert.NoError(err)
+	assert.Equal(2, len(languages))
+	assert.Equal("en", languages[0].ID)
+	assert.Equal("English", languages[0].EnglishName)
+	assert.Equal(models.LeftToRight, languages[0].Direction)
+	assert.True(languages[0].Enabled)
+	assert.Equal("sv", languages[1].ID)
+	assert.Equal("Svenska", languages[1].NativeName)
+
+	disabled := false
+	body := languageRequest{ID: "fr", Enabled: &disabled}
+	req = createTestBodyRequest(http.MethodPost, "/v1/languages", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	// Disabled languages are listed unless only enabled ones are requested
+	req = createTestRequest("/v1/languages", "")
+	res = performTestRequest(server.Handler, req)
+	err = json.NewDecoder(res.Body).Decode(&languages)
+	assert.NoError(err)
+	assert.Equal(3, len(languages))
+	assert.False(languages[1].Enabled)
+
+	req = createTestRequest("/v1/languages?enabled=true", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	languages = nil
+	err = json.NewDecoder(res.Body).Decode(&languages)
+	assert.NoError(err)
+	assert.Equal(2, len(languages))
+	assert.Equal("en", languages[0].ID)
+	assert.Equal("sv", languages[1].ID)
+
+	req = createTestRequest("/v1/languages?enabled=maybe", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+}
+
+func TestCreateLanguage(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	body := languageRequest{
+		ID:          "ar",
+		EnglishName: "Arabic",
+		NativeName:  "العربية",
+		Direction:   models.RightToLeft,
+		Fallback:    "en",
+	}
+	req := createTestBodyRequest(http.MethodPost, "/v1/languages", "", body)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	var language models.Language
+	err := json.NewDecoder(res.Body).Decode(&language)
+	assert.NoError(err)
+	assert.Equal("ar", language.ID)
+	assert.Equal(models.RightToLeft, language.Direction)
+	assert.Equal("en", language.Fallback)
+	assert.True(language.Enabled)
+
+	// Already existing language
+	req = createTestBodyRequest(http.MethodPost, "/v1/languages", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusConflict, res.Code)
+
+	// Script and UN M.49 region subtags
+	for _, id := range []string{"zh-Hant", "sr-Latn-RS", "es-419", "pt-BR"} {
+		req = createTestBodyRequest(http.MethodPost, "/v1/languages", "", languageRequest{ID: id})
+		res = performTestRequest(server.Handler, req)
+		assert.Equal(http.StatusCreated, res.Code, id)
+	}
+
+	// Invalid language tags
+	for _, id := range []string{"s", "swedish", "zh-Han", "es-41", "es-4190", "sr-RS-Latn", "en_US", "en-US-"} {
+		req = createTestBodyRequest(http.MethodPost, "/v1/languages", "", languageRequest{ID: id})
+		res = performTestRequest(server.Handler, req)
+		assert.Equal(http.StatusBadRequest, res.Code, id)
+	}
+
+	// Invalid direction
+	body = languageRequest{ID: "fr", Direction: "up"}
+	req = createTestBodyRequest(http.MethodPost, "/v1/languages", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	// Unsupported fallback
+	body = languageRequest{ID: "fr", Fallback: "xy"}
+	req = createTestBodyRequest(http.MethodPost, "/v1/languages", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	// Disabled languages are not served
+	disabled := false
+	body = languageRequest{ID: "fr", Enabled: &disabled}
+	req = createTestBodyRequest(http.MethodPost, "/v1/languages", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP", "fr")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+}
+
+func TestDeleteLanguage(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	body := languageRequest{ID: "fr"}
+	req := createTestBodyRequest(http.MethodPost, "/v1/languages", "", body)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/languages/fr", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/languages/fr", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+
+	// Language with texts
+	req = createTestBodyRequest(http.MethodDelete, "/v1/languages/sv", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusConflict, res.Code)
+}
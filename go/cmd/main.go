@@ -1,36 +1,166 @@
 package main
 
 import (
+	stdctx "context"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
 	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
 	"github.com/CzarSimon/text-service/go/pkg/utils/logger"
+	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
 var log = logger.GetDefaultLogger("main").Sugar()
 
+const shutdownTimeout = 30 * time.Second
+
 func main() {
 	cfg := getConfig()
 	e := getEnv(cfg)
 	defer e.Close()
 
+	if len(os.Args) > 1 {
+		runCommand(e, os.Args[1:])
+		return
+	}
+
+	e.checkDefaultFallback(context.New(stdctx.Background(), "checkDefaultFallback", ""))
+	e.textStats.RefreshAll(context.New(stdctx.Background(), "refreshStats", ""))
 	server := newServer(e)
+	workerCtx, stopWorker := stdctx.WithCancel(stdctx.Background())
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		e.webhookWorker.Run(context.New(workerCtx, "webhookWorker", ""))
+	}()
+	go func() {
+		defer wg.Done()
+		e.textStats.Run(context.New(workerCtx, "textStats", ""))
+	}()
+
+	server.RegisterOnShutdown(e.changes.Close)
+	stopGRPC := startGRPCServer(e)
+	go awaitShutdown(server)
 	log.Info("Started text-service on port: " + cfg.port)
 	err := server.ListenAndServe()
-	if err != nil {
+	if err != nil && err != http.ErrServerClosed {
 		log.Error("Unexpected error stoped server.", zap.Error(err))
 	}
+
+	stopGRPC()
+	stopWorker()
+	wg.Wait()
+}
+
+// awaitShutdown gracefully shuts down the server on SIGINT or SIGTERM.
+func awaitShutdown(server *http.Server) {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	<-sig
+
+	log.Info("Shutting down text-service")
+	ctx, cancel := stdctx.WithTimeout(stdctx.Background(), shutdownTimeout)
+	defer cancel()
+
+	err := server.Shutdown(ctx)
+	if err != nil {
+		log.Error("Failed to gracefully shut down server.", zap.Error(err))
+	}
 }
 
 func newServer(e *env) *http.Server {
 	r := httputil.NewRouter(e.checkHealth)
+	reader := e.authorize(models.ReaderRole)
+	admin := e.authorize(models.AdminRole)
+
+	r.GET("/v1/languages", reader, e.getLanguages)
+	r.POST("/v1/languages", admin, e.createLanguage)
+	r.DELETE("/v1/languages/:languageId", admin, e.deleteLanguage)
+
+	r.GET("/v1/projects", reader, e.getProjects)
+	r.POST("/v1/projects", admin, e.createProject)
+	r.GET("/v1/projects/:project", reader, e.getProject)
+	r.DELETE("/v1/projects/:project", admin, e.deleteProject)
 
-	r.GET("/v1/texts/key/:key", e.getTextByKey)
-	r.GET("/v1/texts/group/:groupId", e.getTextGroup)
+	r.GET("/v1/admin/api-keys", admin, e.getAPIKeys)
+	r.POST("/v1/admin/api-keys", admin, e.createAPIKey)
+	r.DELETE("/v1/admin/api-keys/:keyId", admin, e.deleteAPIKey)
+
+	e.addProjectRoutes(r.Group("/v1"))
+	e.addProjectRoutes(r.Group("/v1/projects/:project", e.requireProject))
 
 	return &http.Server{
 		Addr:    ":" + e.cfg.port,
 		Handler: r,
 	}
 }
+
+// addProjectRoutes adds routes scoped to a project, either the default
+// project or the one named by the :project path parameter.
+// Readers may read texts, translators may stage drafts and admins may change everything.
+func (e *env) addProjectRoutes(r gin.IRoutes) {
+	reader := e.authorize(models.ReaderRole)
+	translator := e.authorize(models.TranslatorRole)
+	admin := e.authorize(models.AdminRole)
+
+	r.GET("/texts", reader, e.listTexts)
+	r.GET("/texts/key/:key", reader, e.getTextByKey)
+	r.GET("/texts/group/:groupId", reader, e.getTextGroup)
+	r.GET("/texts/group/:groupId/stream", reader, e.streamTextGroup)
+	r.POST("/texts/key/:key/render", reader, e.renderText)
+	r.GET("/texts/key/:key/history", reader, e.getTextHistory)
+
+	r.GET("/search", reader, e.searchTexts)
+
+	r.GET("/reports/missing", reader, e.getMissingReport)
+	r.GET("/stats", reader, e.getStats)
+
+	r.GET("/export/xliff", reader, e.exportXLIFF)
+	r.POST("/import/xliff", admin, e.importXLIFF)
+	r.GET("/export/spreadsheet", reader, e.exportSpreadsheet)
+	r.POST("/import/spreadsheet", admin, e.importSpreadsheet)
+
+	r.GET("/groups", reader, e.getGroups)
+	r.POST("/groups", admin, e.createGroup)
+	r.GET("/groups/:groupId", reader, e.getGroup)
+	r.PATCH("/groups/:groupId", admin, e.renameGroup)
+	r.DELETE("/groups/:groupId", admin, e.deleteGroup)
+	r.POST("/groups/:groupId/members", admin, e.addGroupMembers)
+	r.DELETE("/groups/:groupId/members", admin, e.removeGroupMembers)
+
+	r.GET("/releases", reader, e.getReleases)
+	r.POST("/releases", admin, e.createRelease)
+	r.GET("/releases/:releaseId", reader, e.getRelease)
+	r.GET("/releases/:releaseId/texts/key/:key", reader, e.getReleaseTextByKey)
+	r.GET("/releases/:releaseId/texts/group/:groupId", reader, e.getReleaseTextGroup)
+	r.GET("/current-release", reader, e.getCurrentRelease)
+	r.PUT("/current-release", admin, e.setCurrentRelease)
+	r.DELETE("/current-release", admin, e.clearCurrentRelease)
+
+	r.POST("/admin/texts", admin, e.createText)
+	r.PUT("/admin/texts/key/:key/language/:language", admin, e.putText)
+	r.PATCH("/admin/texts/key/:key/language/:language", admin, e.patchText)
+	r.DELETE("/admin/texts/key/:key/language/:language", admin, e.deleteText)
+	r.POST("/admin/texts/key/:key/history/:revisionId/restore", admin, e.restoreTextRevision)
+
+	r.GET("/admin/drafts", translator, e.getDrafts)
+	r.PUT("/admin/drafts/key/:key/language/:language", translator, e.putDraft)
+	r.DELETE("/admin/drafts/key/:key/language/:language", translator, e.discardDraft)
+	r.POST("/admin/drafts/publish", admin, e.publishDrafts)
+
+	r.GET("/admin/gettext/:language", admin, e.exportGettext)
+	r.POST("/admin/gettext/:language", admin, e.importGettext)
+
+	r.GET("/admin/webhooks", admin, e.getWebhooks)
+	r.POST("/admin/webhooks", admin, e.createWebhook)
+	r.DELETE("/admin/webhooks/:webhookId", admin, e.deleteWebhook)
+	r.GET("/admin/webhooks/:webhookId/deliveries", admin, e.getWebhookDeliveries)
+}
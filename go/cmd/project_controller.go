@@ -0,0 +1,91 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+type projectRequest struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
+func (e *env) getProjects(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("getProjects", "ctx", ctx)
+
+	projects, err := e.projectManager.GetAll(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, projects)
+}
+
+func (e *env) getProject(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("getProject", "ctx", ctx)
+
+	project, err := e.projectManager.Get(ctx, ctx.Project)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+func (e *env) createProject(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("createProject", "ctx", ctx)
+
+	var body projectRequest
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid request body"))
+		return
+	}
+
+	project := models.Project{
+		ID:          body.ID,
+		Description: body.Description,
+	}
+	created, err := e.projectManager.Create(ctx, project)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (e *env) deleteProject(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("deleteProject", "ctx", ctx)
+
+	err := e.projectManager.Delete(ctx, ctx.Project)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	httputil.SendOK(c)
+}
+
+// requireProject aborts requests to projects which do not exist.
+func (e *env) requireProject(c *gin.Context) {
+	ctx := createContext(c)
+
+	_, err := e.projectManager.Get(ctx, ctx.Project)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
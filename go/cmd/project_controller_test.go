@@ -0,0 +1,160 @@
+package main
+
+import (
+	stdctx "context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAndDeleteProject(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestBodyRequest(http.MethodPost, "/v1/projects", "", projectRequest{ID: "web", Description: "Web app"})
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	req = createTestBodyRequest(http.MethodPost, "/v1/projects", "", projectRequest{ID: "web"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusConflict, res.Code)
+
+	req = createTestBodyRequest(http.MethodPost, "/v1/projects", "", projectRequest{ID: "web app"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	req = createTestRequest("/v1/projects", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var projects []models.Project
+	err := json.NewDecoder(res.Body).Decode(&projects)
+	assert.NoError(err)
+	assert.Equal(2, len(projects))
+	assert.Equal("default", projects[0].ID)
+	assert.Equal("web", projects[1].ID)
+
+	req = createTestRequest("/v1/projects/web", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var project models.Project
+	err = json.NewDecoder(res.Body).Decode(&project)
+	assert.NoError(err)
+	assert.Equal("Web app", project.Description)
+
+	body := models.TranslatedText{Key: "WEB_TEXT_KEY", Language: "en", Value: "en-web-val"}
+	req = createTestBodyRequest(http.MethodPost, "/v1/projects/web/admin/texts", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/projects/web", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusConflict, res.Code)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/projects/web/admin/texts/key/WEB_TEXT_KEY/language/en", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/projects/web", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/projects/web", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/projects/default", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusConflict, res.Code)
+
+	// Gettext metadata is kept after its texts are removed
+	req = createTestBodyRequest(http.MethodPost, "/v1/projects", "", projectRequest{ID: "po"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	ctx := context.New(stdctx.Background(), "TestCreateAndDeleteProject", "")
+	ctx.Project = "po"
+	_, err = repository.NewGettextRepository(e.db).Save(ctx, "en", "Language: en\n", nil, nil)
+	assert.NoError(err)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/projects/po", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusConflict, res.Code)
+}
+
+func TestProjectsAreIsolated(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestBodyRequest(http.MethodPost, "/v1/projects", "", projectRequest{ID: "email"})
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	req = createTestRequest("/v1/projects/email/texts/key/TEST_TEXT_KEY", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+
+	body := models.TranslatedText{Key: "TEST_TEXT_KEY", Language: "sv", Value: "sv-email-val"}
+	req = createTestBodyRequest(http.MethodPost, "/v1/projects/email/admin/texts", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	req = createTestBodyRequest(http.MethodPost, "/v1/projects/email/groups", "", groupRequest{ID: "MOBILE_APP"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	members := groupMembersRequest{Keys: []string{"TEST_TEXT_KEY"}}
+	req = createTestBodyRequest(http.MethodPost, "/v1/projects/email/groups/MOBILE_APP/members", "", members)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/projects/email/texts/key/TEST_TEXT_KEY", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var texts models.Texts
+	err := json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("sv-email-val", texts["TEST_TEXT_KEY"])
+
+	req = createTestRequest("/v1/projects/email/texts/group/MOBILE_APP", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	texts = models.Texts{}
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal(1, len(texts))
+	assert.Equal("sv-email-val", texts["TEST_TEXT_KEY"])
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	texts = models.Texts{}
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("sv-text-val", texts["TEST_TEXT_KEY"])
+
+	req = createTestRequest("/v1/projects/default/texts/group/MOBILE_APP", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	texts = models.Texts{}
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal(3, len(texts))
+	assert.Equal("sv-text-val", texts["TEST_TEXT_KEY"])
+
+	req = createTestRequest("/v1/projects/missing/texts/key/TEST_TEXT_KEY", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+type releaseRequest struct {
+	ID          string   `json:"id"`
+	Description string   `json:"description"`
+	Groups      []string `json:"groups"`
+}
+
+type currentReleaseRequest struct {
+	ID string `json:"id"`
+}
+
+func (e *env) getReleases(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("getReleases", "ctx", ctx)
+
+	releases, err := e.releaseManager.GetAll(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, releases)
+}
+
+func (e *env) getRelease(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("getRelease", "ctx", ctx)
+
+	release, err := e.releaseManager.Get(ctx, c.Param("releaseId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, release)
+}
+
+func (e *env) createRelease(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("createRelease", "ctx", ctx)
+
+	var body releaseRequest
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid request body"))
+		return
+	}
+
+	release := models.Release{
+		ID:          body.ID,
+		Description: body.Description,
+	}
+	created, err := e.releaseManager.Create(ctx, release, body.Groups)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (e *env) getReleaseTextByKey(c *gin.Context) {
+	ctx := createContext(c)
+	ctx.Release = c.Param("releaseId")
+	controllerLog.Debugw("getReleaseTextByKey", "ctx", ctx)
+
+	err := e.negotiateLanguage(c, ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	texts, err := e.textGetter.Get(ctx, c.Param("key"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	e.sendCacheableTexts(c, ctx, texts)
+}
+
+func (e *env) getReleaseTextGroup(c *gin.Context) {
+	ctx := createContext(c)
+	ctx.Release = c.Param("releaseId")
+	controllerLog.Debugw("getReleaseTextGroup", "ctx", ctx)
+
+	err := e.negotiateLanguage(c, ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	texts, err := e.textGetter.GetGroup(ctx, c.Param("groupId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	e.sendCacheableTexts(c, ctx, texts)
+}
+
+func (e *env) getCurrentRelease(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("getCurrentRelease", "ctx", ctx)
+
+	release, err := e.releaseManager.GetCurrent(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, release)
+}
+
+func (e *env) setCurrentRelease(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("setCurrentRelease", "ctx", ctx)
+
+	var body currentReleaseRequest
+	err := c.ShouldBindJSON(&body)
+	if err != nil || body.ID == "" {
+		c.Error(httputil.BadRequest("Invalid request body"))
+		return
+	}
+
+	err = e.releaseManager.SetCurrent(ctx, body.ID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	httputil.SendOK(c)
+}
+
+func (e *env) clearCurrentRelease(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("clearCurrentRelease", "ctx", ctx)
+
+	err := e.releaseManager.SetCurrent(ctx, "")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	httputil.SendOK(c)
+}
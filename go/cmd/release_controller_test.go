@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRelease(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	body := releaseRequest{ID: "v1.0.0", Description: "First release"}
+	req := createTestBodyRequest(http.MethodPost, "/v1/releases", "", body)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	var release models.Release
+	err := json.NewDecoder(res.Body).Decode(&release)
+	assert.NoError(err)
+	assert.Equal("v1.0.0", release.ID)
+	assert.Equal("First release", release.Description)
+	assert.Equal(7, release.TextCount)
+	assert.False(release.Current)
+
+	req = createTestBodyRequest(http.MethodPost, "/v1/releases", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusConflict, res.Code)
+
+	body = releaseRequest{ID: "mobile-1", Groups: []string{"MOBILE_APP"}}
+	req = createTestBodyRequest(http.MethodPost, "/v1/releases", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	err = json.NewDecoder(res.Body).Decode(&release)
+	assert.NoError(err)
+	assert.Equal(5, release.TextCount)
+
+	body = releaseRequest{ID: "web-1", Groups: []string{"WEB_APP"}}
+	req = createTestBodyRequest(http.MethodPost, "/v1/releases", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+
+	body = releaseRequest{ID: "invalid id"}
+	req = createTestBodyRequest(http.MethodPost, "/v1/releases", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	req = createTestRequest("/v1/releases", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var releases []models.Release
+	err = json.NewDecoder(res.Body).Decode(&releases)
+	assert.NoError(err)
+	assert.Equal(2, len(releases))
+
+	req = createTestRequest("/v1/releases/missing", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+}
+
+func TestReleaseIsImmutable(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	body := releaseRequest{ID: "v1.0.0"}
+	req := createTestBodyRequest(http.MethodPost, "/v1/releases", "", body)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	update := textValueRequest{Value: "sv-updated-val"}
+	req = createTestBodyRequest(http.MethodPut, "/v1/admin/texts/key/TEST_TEXT_KEY/language/sv", "", update)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/groups/MOBILE_APP", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/releases/v1.0.0/texts/key/TEST_TEXT_KEY", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var texts models.Texts
+	err := json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("sv-text-val", texts["TEST_TEXT_KEY"])
+
+	req = createTestRequest("/v1/releases/v1.0.0/texts/group/MOBILE_APP", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	texts = models.Texts{}
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal(3, len(texts))
+	assert.Equal("sv-text-val", texts["TEST_TEXT_KEY"])
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	texts = models.Texts{}
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("sv-updated-val", texts["TEST_TEXT_KEY"])
+
+	req = createTestRequest("/v1/releases/missing/texts/key/TEST_TEXT_KEY", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+}
+
+func TestCurrentRelease(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestRequest("/v1/current-release", "")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+
+	req = createTestBodyRequest(http.MethodPost, "/v1/releases", "", releaseRequest{ID: "v1.0.0"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	req = createTestBodyRequest(http.MethodPut, "/v1/current-release", "", currentReleaseRequest{ID: "missing"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+
+	req = createTestBodyRequest(http.MethodPut, "/v1/current-release", "", currentReleaseRequest{ID: "v1.0.0"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/current-release", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var release models.Release
+	err := json.NewDecoder(res.Body).Decode(&release)
+	assert.NoError(err)
+	assert.Equal("v1.0.0", release.ID)
+	assert.True(release.Current)
+
+	update := textValueRequest{Value: "sv-updated-val"}
+	req = createTestBodyRequest(http.MethodPut, "/v1/admin/texts/key/TEST_TEXT_KEY/language/sv", "", update)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var texts models.Texts
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("sv-text-val", texts["TEST_TEXT_KEY"])
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/current-release", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	texts = models.Texts{}
+	err = json.NewDecoder(res.Body).Decode(&texts)
+	assert.NoError(err)
+	assert.Equal("sv-updated-val", texts["TEST_TEXT_KEY"])
+}
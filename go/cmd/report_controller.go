@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"net/http"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+const csvMediaType = "text/csv"
+
+func (e *env) getMissingReport(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("getMissingReport", "ctx", ctx)
+
+	report, err := e.reportGenerator.Missing(ctx, c.Query("group"), c.Query("language"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if !wantsFormat(c, "csv", csvMediaType) {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	body, err := missingReportCSV(report)
+	if err != nil {
+		controllerLog.Errorw("Failed to write missing report csv", "error", err, "ctx", ctx)
+		c.Error(httputil.ErrInternalServerError)
+		return
+	}
+
+	c.Header(httputil.ContentDisposition, `attachment; filename="missing-translations.csv"`)
+	c.Data(http.StatusOK, csvMediaType+"; charset=utf-8", body)
+}
+
+func (e *env) getStats(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("getStats", "ctx", ctx)
+
+	stats, err := e.textStats.Get(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// missingReportCSV writes one row per missing translation of a key.
+func missingReportCSV(report []models.MissingTranslation) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"key", "language"})
+	for _, text := range report {
+		for _, language := range text.Missing {
+			w.Write([]string{text.Key, language})
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
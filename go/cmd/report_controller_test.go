@@ -0,0 +1,118 @@
+package main
+
+import (
+	stdctx "context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMissingReport(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	body := models.TranslatedText{Key: "ONLY_EN_TEXT_KEY", Language: "en", Value: "en-only-val"}
+	req := createTestBodyRequest(http.MethodPost, "/v1/admin/texts", "", body)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	req = createTestRequest("/v1/reports/missing", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var report []models.MissingTranslation
+	err := json.NewDecoder(res.Body).Decode(&report)
+	assert.NoError(err)
+	assert.Equal(2, len(report))
+	assert.Equal("ONLY_EN_TEXT_KEY", report[0].Key)
+	assert.Equal([]string{"en"}, report[0].Translated)
+	assert.Equal([]string{"sv"}, report[0].Missing)
+	assert.Equal("ONLY_SV_TEXT_KEY", report[1].Key)
+	assert.Equal([]string{"en"}, report[1].Missing)
+
+	req = createTestRequest("/v1/reports/missing?group=MOBILE_APP", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	err = json.NewDecoder(res.Body).Decode(&report)
+	assert.NoError(err)
+	assert.Equal(1, len(report))
+	assert.Equal("ONLY_SV_TEXT_KEY", report[0].Key)
+
+	req = createTestRequest("/v1/reports/missing?language=sv", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	err = json.NewDecoder(res.Body).Decode(&report)
+	assert.NoError(err)
+	assert.Equal(1, len(report))
+	assert.Equal("ONLY_EN_TEXT_KEY", report[0].Key)
+
+	req = createTestRequest("/v1/reports/missing?format=csv", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("text/csv; charset=utf-8", res.Header().Get("Content-Type"))
+	assert.Equal("key,language\nONLY_EN_TEXT_KEY,sv\nONLY_SV_TEXT_KEY,en\n", res.Body.String())
+
+	req = createTestRequest("/v1/reports/missing?group=MOBILE_APP", "")
+	req.Header.Set("Accept", "text/csv")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("key,language\nONLY_SV_TEXT_KEY,en\n", res.Body.String())
+
+	req = createTestRequest("/v1/reports/missing?group=MISSING_GROUP", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+
+	req = createTestRequest("/v1/reports/missing?language=fi", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+}
+
+func TestGetStats(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestRequest("/v1/stats", "")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var stats models.Stats
+	err := json.NewDecoder(res.Body).Decode(&stats)
+	assert.NoError(err)
+	assert.Equal(4, stats.Keys)
+	assert.Equal(2, len(stats.Languages))
+	assert.Equal("en", stats.Languages[0].Language)
+	assert.Equal(3, stats.Languages[0].Translated)
+	assert.Equal(75.0, stats.Languages[0].PercentComplete)
+	assert.NotNil(stats.Languages[0].LastUpdated)
+	assert.Equal("sv", stats.Languages[1].Language)
+	assert.Equal(4, stats.Languages[1].Translated)
+	assert.Equal(100.0, stats.Languages[1].PercentComplete)
+
+	assert.Equal(1, len(stats.Groups))
+	group := stats.Groups[0]
+	assert.Equal("MOBILE_APP", group.Group)
+	assert.Equal(3, group.Keys)
+	assert.Equal(2, group.Languages[0].Translated)
+	assert.Equal(66.67, group.Languages[0].PercentComplete)
+	assert.Equal(100.0, group.Languages[1].PercentComplete)
+
+	body := models.TranslatedText{Key: "ONLY_SV_TEXT_KEY", Language: "en", Value: "en-only-val"}
+	req = createTestBodyRequest(http.MethodPost, "/v1/admin/texts", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+	e.textStats.RefreshPending(context.New(stdctx.Background(), "TestGetStats", ""))
+
+	req = createTestRequest("/metrics", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	metrics := res.Body.String()
+	assert.Contains(metrics, `translation_keys{group="MOBILE_APP",project="default"} 3`)
+	assert.Contains(metrics, `translation_percent_complete{group="MOBILE_APP",language="en",project="default"} 100`)
+	assert.Contains(metrics, `translation_translated{group="",language="en",project="default"} 4`)
+}
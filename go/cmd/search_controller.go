@@ -0,0 +1,35 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/CzarSimon/text-service/go/pkg/service"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+func (e *env) searchTexts(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("searchTexts", "ctx", ctx)
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid offset"))
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(service.DefaultSearchLimit)))
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid limit"))
+		return
+	}
+
+	result, err := e.textSearch.Search(ctx, c.Query("q"), c.Query("language"), c.Query("group"), offset, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
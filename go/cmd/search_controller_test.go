@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTexts(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	result := performTestSearch(t, server.Handler, "/v1/search?q=other")
+	assert.Equal(2, len(result.Texts))
+	assert.Equal("OTHER_TEXT_KEY", result.Texts[0].Key)
+	assert.Equal("en", result.Texts[0].Language)
+	assert.Equal("sv", result.Texts[1].Language)
+
+	result = performTestSearch(t, server.Handler, "/v1/search?q=val&language=en")
+	assert.Equal(3, len(result.Texts))
+
+	result = performTestSearch(t, server.Handler, "/v1/search?q=val&language=en&group=MOBILE_APP")
+	assert.Equal(2, len(result.Texts))
+	assert.Equal("OTHER_TEXT_KEY", result.Texts[0].Key)
+	assert.Equal("TEST_TEXT_KEY", result.Texts[1].Key)
+
+	result = performTestSearch(t, server.Handler, "/v1/search?q=non-gr")
+	assert.Equal(2, len(result.Texts))
+	assert.Equal("sv-non-group-val", result.Texts[1].Value)
+
+	result = performTestSearch(t, server.Handler, "/v1/search?q=_text_")
+	assert.Equal(5, len(result.Texts))
+
+	result = performTestSearch(t, server.Handler, "/v1/search?q=T_X")
+	assert.Equal(0, len(result.Texts))
+
+	result = performTestSearch(t, server.Handler, "/v1/search?q=%25")
+	assert.Equal(0, len(result.Texts))
+
+	result = performTestSearch(t, server.Handler, "/v1/search?q=val&limit=2")
+	assert.Equal(2, len(result.Texts))
+	assert.Equal(2, result.Limit)
+	assert.True(result.HasMore)
+
+	result = performTestSearch(t, server.Handler, "/v1/search?q=val&limit=2&offset=6")
+	assert.Equal(1, len(result.Texts))
+	assert.Equal(6, result.Offset)
+	assert.False(result.HasMore)
+
+	body := models.TranslatedText{Value: "Welcome back"}
+	req := createTestBodyRequest(http.MethodPut, "/v1/admin/texts/key/TEST_TEXT_KEY/language/en", "", body)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	result = performTestSearch(t, server.Handler, "/v1/search?q=welc")
+	assert.Equal(1, len(result.Texts))
+	assert.Equal("Welcome back", result.Texts[0].Value)
+
+	result = performTestSearch(t, server.Handler, "/v1/search?q=en-text")
+	assert.Equal(0, len(result.Texts))
+
+	for _, path := range []string{"/v1/search", "/v1/search?q=val&limit=0", "/v1/search?q=val&offset=-1", "/v1/search?q=val&limit=x"} {
+		req = createTestRequest(path, "")
+		res = performTestRequest(server.Handler, req)
+		assert.Equal(http.StatusBadRequest, res.Code, path)
+	}
+}
+
+func performTestSearch(t *testing.T, handler http.Handler, path string) models.SearchResult {
+	req := createTestRequest(path, "")
+	res := performTestRequest(handler, req)
+	assert.Equal(t, http.StatusOK, res.Code, path)
+
+	var result models.SearchResult
+	err := json.NewDecoder(res.Body).Decode(&result)
+	assert.NoError(t, err)
+	return result
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/CzarSimon/text-service/go/pkg/utils/xlsx"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	xlsxMediaType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	utf8BOM       = "\xef\xbb\xbf"
+	// formulaPrefixes leading characters which make spreadsheet applications evaluate a cell as a formula.
+	formulaPrefixes = "=+-@\t\r"
+)
+
+func (e *env) exportSpreadsheet(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("exportSpreadsheet", "ctx", ctx)
+
+	format := c.Query("format")
+	if format != "" && format != "csv" && format != "xlsx" {
+		c.Error(httputil.BadRequest("Unsupported spreadsheet format: " + format))
+		return
+	}
+
+	groupID := c.Query("group")
+	rows, err := e.spreadsheetConverter.Export(ctx, groupID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	name := "texts"
+	if groupID != "" {
+		name = groupID
+	}
+
+	escapeFormulas(rows)
+	var buf bytes.Buffer
+	mediaType := csvMediaType + "; charset=utf-8"
+	if wantsFormat(c, "xlsx", xlsxMediaType) {
+		mediaType = xlsxMediaType
+		name += ".xlsx"
+		err = xlsx.Write(&buf, "Texts", rows)
+	} else {
+		name += ".csv"
+		err = csv.NewWriter(&buf).WriteAll(rows)
+	}
+	if err != nil {
+		controllerLog.Errorw("Failed to write spreadsheet", "error", err, "ctx", ctx)
+		c.Error(httputil.ErrInternalServerError)
+		return
+	}
+
+	c.Header(httputil.ContentDisposition, `attachment; filename="`+name+`"`)
+	c.Data(http.StatusOK, mediaType, buf.Bytes())
+}
+
+func (e *env) importSpreadsheet(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("importSpreadsheet", "ctx", ctx)
+
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid dryRun, expected true or false"))
+		return
+	}
+
+	data, err := e.readImportBody(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	rows, err := parseSpreadsheet(data)
+	if err == xlsx.ErrEntryTooLarge {
+		c.Error(httputil.NewError("Spreadsheet is too large", http.StatusRequestEntityTooLarge))
+		return
+	}
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid spreadsheet: " + err.Error()))
+		return
+	}
+	unescapeFormulas(rows)
+
+	result, err := e.spreadsheetConverter.Import(ctx, rows, dryRun)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// parseSpreadsheet reads the rows of either an xlsx workbook or a CSV file, which may start with a byte order mark.
+func parseSpreadsheet(data []byte) ([][]string, error) {
+	if xlsx.IsXLSX(data) {
+		return xlsx.Read(bytes.NewReader(data), int64(len(data)))
+	}
+
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte(utf8BOM))))
+	r.FieldsPerRecord = -1
+	return r.ReadAll()
+}
+
+// escapeFormulas prefixes cells which would be evaluated as formulas, or which start with the
+// prefix itself, with a single quote so that spreadsheet applications treat them as text.
+func escapeFormulas(rows [][]string) {
+	for _, row := range rows {
+		for i, value := range row {
+			if value != "" && strings.ContainsAny(value[:1], formulaPrefixes+"'") {
+				row[i] = "'" + value
+			}
+		}
+	}
+}
+
+// unescapeFormulas removes the quotes added by escapeFormulas.
+func unescapeFormulas(rows [][]string) {
+	for _, row := range rows {
+		for i, value := range row {
+			if len(value) > 1 && value[0] == '\'' && strings.ContainsAny(value[1:2], formulaPrefixes+"'") {
+				row[i] = value[1:]
+			}
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"bytes"
+	stdctx "context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/service"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/CzarSimon/text-service/go/pkg/utils/xlsx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportSpreadsheet(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestRequest("/v1/export/spreadsheet", "")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("text/csv; charset=utf-8", res.Header().Get("Content-Type"))
+	assert.Equal(`attachment; filename="texts.csv"`, res.Header().Get("Content-Disposition"))
+
+	rows, err := csv.NewReader(res.Body).ReadAll()
+	assert.NoError(err)
+	assert.Len(rows, 5)
+	assert.Equal([]string{"key", "en", "sv", "updatedAt"}, rows[0])
+	values := spreadsheetValues(rows)
+	assert.Equal(map[string]string{"sv": "sv-non-group-val", "en": "en-non-group-val"}, values["NOT_IN_GROUP"])
+	assert.Equal(map[string]string{"sv": "sv-only-val", "en": ""}, values["ONLY_SV_TEXT_KEY"])
+	assert.NotEmpty(rows[1][3])
+
+	req = createTestRequest("/v1/export/spreadsheet?group=MOBILE_APP&format=xlsx", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal(xlsxMediaType, res.Header().Get("Content-Type"))
+	assert.Equal(`attachment; filename="MOBILE_APP.xlsx"`, res.Header().Get("Content-Disposition"))
+
+	body := res.Body.Bytes()
+	rows, err = xlsx.Read(bytes.NewReader(body), int64(len(body)))
+	assert.NoError(err)
+	assert.Len(rows, 4)
+	values = spreadsheetValues(rows)
+	assert.Equal("sv-text-val", values["TEST_TEXT_KEY"]["sv"])
+	assert.NotContains(values, "NOT_IN_GROUP")
+
+	req = createTestRequest("/v1/export/spreadsheet", "")
+	req.Header.Set("Accept", xlsxMediaType)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.True(xlsx.IsXLSX(res.Body.Bytes()))
+
+	for path, status := range map[string]int{
+		"/v1/export/spreadsheet?format=ods":       http.StatusBadRequest,
+		"/v1/export/spreadsheet?group=NO_SUCH_ID": http.StatusNotFound,
+	} {
+		req = createTestRequest(path, "")
+		res = performTestRequest(server.Handler, req)
+		assert.Equal(status, res.Code, path)
+	}
+}
+
+func TestImportSpreadsheet(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestRequest("/v1/export/spreadsheet", "")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	rows, err := csv.NewReader(res.Body).ReadAll()
+	assert.NoError(err)
+
+	setSpreadsheetValue(rows, "TEST_TEXT_KEY", "sv", "sv-text-val-2")
+	setSpreadsheetValue(rows, "ONLY_SV_TEXT_KEY", "en", "en-only-val")
+	setSpreadsheetValue(rows, "OTHER_TEXT_KEY", "en", "en-other-val-2")
+	setSpreadsheetValue(rows, "NOT_IN_GROUP", "sv", "")
+	rows = append(rows, []string{"NEW_TEXT_KEY", "new-val"})
+
+	// OTHER_TEXT_KEY is changed after the export, so the spreadsheet conflicts with it.
+	path := "/v1/admin/texts/key/OTHER_TEXT_KEY/language/en"
+	req = createTestBodyRequest(http.MethodPut, path, "", textValueRequest{Value: "en-other-val-new"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	res = performTestImportSpreadsheet(server.Handler, "?dryRun=true", spreadsheetCSV(rows))
+	assert.Equal(http.StatusOK, res.Code)
+	var result models.SpreadsheetImport
+	err = json.NewDecoder(res.Body).Decode(&result)
+	assert.NoError(err)
+	assert.True(result.DryRun)
+	assert.Equal([]models.SpreadsheetDiff{
+		{Key: "ONLY_SV_TEXT_KEY", Language: "en", Value: "en-only-val"},
+		{Key: "NEW_TEXT_KEY", Language: "en", Value: "new-val"},
+	}, result.Creates)
+	assert.Equal([]models.SpreadsheetDiff{
+		{Key: "TEST_TEXT_KEY", Language: "sv", Current: "sv-text-val", Value: "sv-text-val-2"},
+	}, result.Updates)
+	assert.Equal([]models.SpreadsheetDiff{
+		{Key: "OTHER_TEXT_KEY", Language: "en", Current: "en-other-val-new", Value: "en-other-val-2"},
+	}, result.Conflicts)
+	assert.Equal(4, result.Unchanged)
+
+	res = performTestImportSpreadsheet(server.Handler, "", spreadsheetCSV(rows))
+	assert.Equal(http.StatusConflict, res.Code)
+	assert.Contains(res.Body.String(), "OTHER_TEXT_KEY=en")
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Contains(res.Body.String(), `"sv-text-val"`)
+
+	setSpreadsheetValue(rows, "OTHER_TEXT_KEY", "en", "en-other-val-new")
+	var buf bytes.Buffer
+	err = xlsx.Write(&buf, "Texts", rows)
+	assert.NoError(err)
+	res = performTestImportSpreadsheet(server.Handler, "", buf.String())
+	assert.Equal(http.StatusOK, res.Code)
+	result = models.SpreadsheetImport{}
+	err = json.NewDecoder(res.Body).Decode(&result)
+	assert.NoError(err)
+	assert.False(result.DryRun)
+	assert.Len(result.Creates, 2)
+	assert.Len(result.Updates, 1)
+	assert.Empty(result.Conflicts)
+
+	req = createTestRequest("/v1/texts/key/TEST_TEXT_KEY", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Contains(res.Body.String(), `"sv-text-val-2"`)
+
+	req = createTestRequest("/v1/texts/key/ONLY_SV_TEXT_KEY", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Contains(res.Body.String(), `"en-only-val"`)
+
+	req = createTestRequest("/v1/texts/key/NOT_IN_GROUP", "sv")
+	res = performTestRequest(server.Handler, req)
+	assert.Contains(res.Body.String(), `"sv-non-group-val"`)
+
+	// A spreadsheet with a byte order mark and no updatedAt column conflicts with every existing text it changes.
+	body := "\xef\xbb\xbfkey,sv\nTEST_TEXT_KEY,sv-text-val-3\nNEWER_TEXT_KEY,newer-val\n"
+	res = performTestImportSpreadsheet(server.Handler, "?dryRun=true", body)
+	assert.Equal(http.StatusOK, res.Code)
+	result = models.SpreadsheetImport{}
+	err = json.NewDecoder(res.Body).Decode(&result)
+	assert.NoError(err)
+	assert.Len(result.Creates, 1)
+	assert.Len(result.Conflicts, 1)
+
+	for body, query := range map[string]string{
+		"id,sv\nTEST_TEXT_KEY,val\n":                "",
+		"key,fi\nTEST_TEXT_KEY,val\n":               "",
+		"key,sv,sv\nTEST_TEXT_KEY,val,val\n":        "",
+		"key,sv\nNEW_KEY,val\nNEW_KEY,val\n":        "",
+		"key,sv\nNEW_KEY,{broken\n":                 "",
+		"key,sv\ninvalid key,val\n":                 "",
+		"key,sv,updatedAt\nNEW_KEY,val,yesterday\n": "",
+		"key,sv\nNEW_KEY,\"val\n":                   "",
+		"key,sv\nNEW_KEY,val\n":                     "?dryRun=maybe",
+	} {
+		res = performTestImportSpreadsheet(server.Handler, query, body)
+		assert.Equal(http.StatusBadRequest, res.Code, body)
+	}
+}
+
+func TestImportSpreadsheetWithConcurrentUpdate(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+
+	textRepo := updatingTextRepo{TextRepository: repository.NewTextRepository(e.db)}
+	converter := service.NewSpreadsheetConverter(
+		repository.NewLanguageRepository(e.db),
+		textRepo,
+		repository.NewGroupRepository(e.db),
+		service.ChangeListeners{},
+	)
+
+	ctx := context.New(stdctx.Background(), "TestImportSpreadsheetWithConcurrentUpdate", "")
+	exported, err := textRepo.Find(ctx, "TEST_TEXT_KEY", "sv")
+	assert.NoError(err)
+
+	rows := [][]string{
+		{service.SpreadsheetKeyColumn, "sv", service.SpreadsheetUpdatedAtColumn},
+		{"TEST_TEXT_KEY", "sv-spreadsheet-val", exported.UpdatedAt.Format(time.RFC3339Nano)},
+	}
+	_, err = converter.Import(ctx, rows, false)
+	httpErr, ok := err.(*httputil.Error)
+	assert.True(ok)
+	assert.Equal(http.StatusConflict, httpErr.StatusCode)
+
+	text, err := textRepo.Find(ctx, "TEST_TEXT_KEY", "sv")
+	assert.NoError(err)
+	assert.Equal("sv-changed-val", text.Value)
+}
+
+func TestSpreadsheetFormulas(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	for value, language := range map[string]string{"=1+1": "sv", "'@quoted": "en"} {
+		path := "/v1/admin/texts/key/FORMULA_KEY/language/" + language
+		req := createTestBodyRequest(http.MethodPut, path, "", textValueRequest{Value: value})
+		res := performTestRequest(server.Handler, req)
+		assert.Equal(http.StatusOK, res.Code)
+	}
+
+	req := createTestRequest("/v1/export/spreadsheet", "")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	rows, err := csv.NewReader(res.Body).ReadAll()
+	assert.NoError(err)
+	values := spreadsheetValues(rows)
+	assert.Equal(map[string]string{"sv": "'=1+1", "en": "''@quoted"}, values["FORMULA_KEY"])
+
+	// Escaped values are imported as exported
+	res = performTestImportSpreadsheet(server.Handler, "?dryRun=true", spreadsheetCSV(rows))
+	assert.Equal(http.StatusOK, res.Code)
+	var result models.SpreadsheetImport
+	err = json.NewDecoder(res.Body).Decode(&result)
+	assert.NoError(err)
+	assert.Empty(result.Creates)
+	assert.Empty(result.Updates)
+	assert.Empty(result.Conflicts)
+
+	req = createTestRequest("/v1/export/spreadsheet?format=xlsx", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	body := res.Body.Bytes()
+	rows, err = xlsx.Read(bytes.NewReader(body), int64(len(body)))
+	assert.NoError(err)
+	assert.Equal("'=1+1", spreadsheetValues(rows)["FORMULA_KEY"]["sv"])
+}
+
+func TestImportSpreadsheetTooLarge(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	e.cfg.maxImportSize = 40
+	server := newServer(e)
+
+	res := performTestImportSpreadsheet(server.Handler, "?dryRun=true", "key,sv\nTEST_TEXT_KEY,sv-text-val\n")
+	assert.Equal(http.StatusOK, res.Code)
+
+	res = performTestImportSpreadsheet(server.Handler, "?dryRun=true", "key,sv\nTEST_TEXT_KEY,sv-text-val-which-is-too-long\n")
+	assert.Equal(http.StatusRequestEntityTooLarge, res.Code)
+}
+
+func performTestImportSpreadsheet(handler http.Handler, query, body string) *httptest.ResponseRecorder {
+	req := createTestRawRequest(http.MethodPost, "/v1/import/spreadsheet"+query, body)
+	return performTestRequest(handler, req)
+}
+
+func spreadsheetCSV(rows [][]string) string {
+	var b strings.Builder
+	csv.NewWriter(&b).WriteAll(rows)
+	return b.String()
+}
+
+func spreadsheetValues(rows [][]string) map[string]map[string]string {
+	values := make(map[string]map[string]string)
+	for _, row := range rows[1:] {
+		values[row[0]] = make(map[string]string)
+		for i, language := range rows[0][1 : len(rows[0])-1] {
+			value := ""
+			if i+1 < len(row) {
+				value = row[i+1]
+			}
+			values[row[0]][language] = value
+		}
+	}
+
+	return values
+}
+
+func setSpreadsheetValue(rows [][]string, key, language, value string) {
+	for _, row := range rows[1:] {
+		if row[0] != key {
+			continue
+		}
+
+		for i, column := range rows[0] {
+			if column == language {
+				row[i] = value
+			}
+		}
+	}
+}
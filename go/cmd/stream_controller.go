@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+// Server-Sent Event names.
+const (
+	bundleEvent = "bundle"
+	changeEvent = "change"
+)
+
+type textChangeEvent struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Action string `json:"action"`
+}
+
+// streamTextGroup sends the texts of a group as a bundle event and then a change
+// event for every text of the group which changes, until the client disconnects.
+func (e *env) streamTextGroup(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("streamTextGroup", "ctx", ctx)
+
+	err := e.negotiateLanguage(c, ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	changes, unsubscribe := e.changes.Subscribe()
+	defer unsubscribe()
+
+	groupID := c.Param("groupId")
+	texts, err := e.textGetter.GetGroup(ctx, groupID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header(httputil.CacheControlHeader, "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	err = writeEvent(c.Writer, bundleEvent, texts.Texts)
+	if err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(e.cfg.streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			controllerLog.Debugw("Stream client disconnected", "ctx", ctx)
+			return
+		case <-heartbeat.C:
+			_, err = io.WriteString(c.Writer, ": heartbeat\n\n")
+			if err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case change, ok := <-changes:
+			if !ok {
+				return
+			}
+			if change.Project != ctx.Project && change.Type != models.LanguageChange {
+				continue
+			}
+
+			texts, err = e.sendTextChanges(c, ctx, groupID, texts)
+			if err != nil {
+				controllerLog.Infow("Ending text stream", "error", err, "ctx", ctx)
+				return
+			}
+		}
+	}
+}
+
+// sendTextChanges fetches the texts of a group and sends a change event for each
+// text which differs from the previously sent texts.
+func (e *env) sendTextChanges(c *gin.Context, ctx *context.Context, groupID string, sent models.ResolvedTexts) (models.ResolvedTexts, error) {
+	texts, err := e.textGetter.GetGroup(ctx, groupID)
+	if err == httputil.ErrNotFound {
+		texts = models.ResolvedTexts{Texts: models.Texts{}}
+	} else if err != nil {
+		return sent, err
+	}
+
+	for _, event := range diffTexts(sent.Texts, texts.Texts) {
+		err = writeEvent(c.Writer, changeEvent, event)
+		if err != nil {
+			return sent, err
+		}
+	}
+
+	return texts, nil
+}
+
+func diffTexts(old, new models.Texts) []textChangeEvent {
+	events := make([]textChangeEvent, 0)
+	for key, value := range new {
+		oldValue, ok := old[key]
+		if !ok {
+			events = append(events, textChangeEvent{Key: key, Value: value, Action: models.Created})
+		} else if oldValue != value {
+			events = append(events, textChangeEvent{Key: key, Value: value, Action: models.Updated})
+		}
+	}
+
+	for key := range old {
+		if _, ok := new[key]; !ok {
+			events = append(events, textChangeEvent{Key: key, Action: models.Deleted})
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Key < events[j].Key
+	})
+	return events
+}
+
+func writeEvent(w gin.ResponseWriter, event string, data interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, body)
+	if err != nil {
+		return err
+	}
+
+	w.Flush()
+	return nil
+}
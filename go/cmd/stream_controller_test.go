@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamTextGroup(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	e.cfg.streamHeartbeat = 50 * time.Millisecond
+	server := newServer(e)
+	ts := httptest.NewServer(server.Handler)
+	defer ts.Close()
+
+	req, err := http.NewRequest(http.MethodGet, ts.URL+"/v1/texts/group/MOBILE_APP/stream", nil)
+	assert.NoError(err)
+	req.Header.Set(httputil.AcceptLanguage, "en")
+	res, err := http.DefaultClient.Do(req)
+	assert.NoError(err)
+	defer res.Body.Close()
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Equal("text/event-stream", res.Header.Get("Content-Type"))
+	assert.Equal("en", res.Header.Get(httputil.ContentLanguage))
+
+	stream := bufio.NewReader(res.Body)
+	event, data := readTestEvent(t, stream)
+	assert.Equal("bundle", event)
+	var texts models.Texts
+	err = json.Unmarshal([]byte(data), &texts)
+	assert.NoError(err)
+	assert.Equal(2, len(texts))
+	assert.Equal("en-text-val", texts["TEST_TEXT_KEY"])
+
+	body := models.TranslatedText{Value: "sv-updated-val"}
+	updateReq := createTestBodyRequest(http.MethodPut, "/v1/admin/texts/key/OTHER_TEXT_KEY/language/sv", "", body)
+	assert.Equal(http.StatusOK, performTestRequest(server.Handler, updateReq).Code)
+
+	body = models.TranslatedText{Value: "en-updated-val"}
+	updateReq = createTestBodyRequest(http.MethodPut, "/v1/admin/texts/key/OTHER_TEXT_KEY/language/en", "", body)
+	assert.Equal(http.StatusOK, performTestRequest(server.Handler, updateReq).Code)
+
+	event, data = readTestEvent(t, stream)
+	assert.Equal("change", event)
+	assert.JSONEq(`{"key":"OTHER_TEXT_KEY","value":"en-updated-val","action":"UPDATED"}`, data)
+
+	body = models.TranslatedText{Key: "NOT_IN_GROUP", Language: "en", Value: "en-new-val"}
+	updateReq = createTestBodyRequest(http.MethodPut, "/v1/admin/texts/key/NOT_IN_GROUP/language/en", "", body)
+	assert.Equal(http.StatusOK, performTestRequest(server.Handler, updateReq).Code)
+
+	body = models.TranslatedText{Key: "ONLY_SV_TEXT_KEY", Language: "en", Value: "en-only-val"}
+	updateReq = createTestBodyRequest(http.MethodPost, "/v1/admin/texts", "", body)
+	assert.Equal(http.StatusCreated, performTestRequest(server.Handler, updateReq).Code)
+
+	event, data = readTestEvent(t, stream)
+	assert.Equal("change", event)
+	assert.JSONEq(`{"key":"ONLY_SV_TEXT_KEY","value":"en-only-val","action":"CREATED"}`, data)
+
+	heartbeat, err := stream.ReadString('\n')
+	assert.NoError(err)
+	assert.Equal(": heartbeat\n", heartbeat)
+
+	e.changes.Close()
+	_, err = io.Copy(ioutil.Discard, stream)
+	assert.NoError(err)
+}
+
+func TestStreamTextGroupNotFound(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestRequest("/v1/texts/group/MISSING_GROUP/stream", "en")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+
+	req = createTestRequest("/v1/texts/group/MOBILE_APP/stream", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+}
+
+// readTestEvent reads the next event from a stream, skipping heartbeats.
+func readTestEvent(t *testing.T, stream *bufio.Reader) (string, string) {
+	var event, data string
+	for {
+		line, err := stream.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Failed to read event: %v", err)
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		case line == "" && event != "":
+			return event, data
+		}
+	}
+}
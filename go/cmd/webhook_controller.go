@@ -0,0 +1,79 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/gin-gonic/gin"
+)
+
+type webhookRequest struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Types  []string `json:"types"`
+}
+
+func (e *env) getWebhooks(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("getWebhooks", "ctx", ctx)
+
+	webhooks, err := e.webhookManager.GetAll(ctx)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+func (e *env) createWebhook(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("createWebhook", "ctx", ctx)
+
+	var body webhookRequest
+	err := c.ShouldBindJSON(&body)
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid request body"))
+		return
+	}
+
+	webhook := models.Webhook{
+		URL:    body.URL,
+		Secret: body.Secret,
+		Types:  body.Types,
+	}
+	created, err := e.webhookManager.Create(ctx, webhook)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (e *env) deleteWebhook(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("deleteWebhook", "ctx", ctx)
+
+	err := e.webhookManager.Delete(ctx, c.Param("webhookId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	httputil.SendOK(c)
+}
+
+func (e *env) getWebhookDeliveries(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("getWebhookDeliveries", "ctx", ctx)
+
+	deliveries, err := e.webhookManager.GetDeliveries(ctx, c.Param("webhookId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	stdctx "context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/service"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/stretchr/testify/assert"
+)
+
+type receivedWebhook struct {
+	event     string
+	signature string
+	body      []byte
+}
+
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	received []receivedWebhook
+}
+
+func (wr *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+	wr.received = append(wr.received, receivedWebhook{
+		event:     r.Header.Get(service.WebhookEventHeader),
+		signature: r.Header.Get(service.WebhookSignatureHeader),
+		body:      body,
+	})
+	w.WriteHeader(wr.status)
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	receiver := &webhookReceiver{status: http.StatusOK}
+	target := httptest.NewServer(receiver)
+	defer target.Close()
+
+	body := webhookRequest{URL: target.URL, Secret: "webhook-secret", Types: []string{models.TextChange}}
+	req := createTestBodyRequest(http.MethodPost, "/v1/admin/webhooks", "", body)
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	var webhook models.Webhook
+	err := json.NewDecoder(res.Body).Decode(&webhook)
+	assert.NoError(err)
+	assert.NotEmpty(webhook.ID)
+	assert.Equal("webhook-secret", webhook.Secret)
+
+	req = createTestRequest("/v1/admin/webhooks", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var webhooks []models.Webhook
+	err = json.NewDecoder(res.Body).Decode(&webhooks)
+	assert.NoError(err)
+	assert.Equal(1, len(webhooks))
+	assert.Equal("", webhooks[0].Secret)
+	assert.Equal([]string{models.TextChange}, webhooks[0].Types)
+
+	text := models.TranslatedText{Key: "TEST_TEXT_KEY", Language: "en", Value: "en-updated-val"}
+	req = createTestBodyRequest(http.MethodPut, "/v1/admin/texts/key/TEST_TEXT_KEY/language/en", "", text)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestBodyRequest(http.MethodPost, "/v1/groups", "", models.TextGroup{ID: "WEB_APP"})
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	// Deliveries are saved by the worker rather than in the request path
+	req = createTestRequest("/v1/admin/webhooks/"+webhook.ID+"/deliveries", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var deliveries []models.WebhookDelivery
+	err = json.NewDecoder(res.Body).Decode(&deliveries)
+	assert.NoError(err)
+	assert.Equal(0, len(deliveries))
+
+	ctx := context.New(stdctx.Background(), "TestWebhookDelivery", "")
+	assert.Equal(1, e.webhookWorker.DeliverDue(ctx))
+	assert.Equal(0, e.webhookWorker.DeliverDue(ctx))
+
+	assert.Equal(1, len(receiver.received))
+	received := receiver.received[0]
+	assert.Equal("TEXT.UPDATED", received.event)
+	assert.Equal(service.SignWebhookPayload("webhook-secret", received.body), received.signature)
+
+	var change models.Change
+	err = json.Unmarshal(received.body, &change)
+	assert.NoError(err)
+	assert.Equal("TEST_TEXT_KEY", change.Key)
+	assert.Equal("en", change.Language)
+	assert.Equal("default", change.Project)
+
+	req = createTestRequest("/v1/admin/webhooks/"+webhook.ID+"/deliveries", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	err = json.NewDecoder(res.Body).Decode(&deliveries)
+	assert.NoError(err)
+	assert.Equal(1, len(deliveries))
+	assert.Equal(models.DeliveryDelivered, deliveries[0].Status)
+	assert.Equal(1, deliveries[0].Attempts)
+	assert.Equal(http.StatusOK, deliveries[0].ResponseCode)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/admin/webhooks/"+webhook.ID, "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	req = createTestRequest("/v1/admin/webhooks/"+webhook.ID+"/deliveries", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+}
+
+func TestWebhookDeliveryRetries(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	e.cfg.webhook.MaxAttempts = 2
+	e.cfg.webhook.RetryBackoff = 0
+	e.webhookWorker = service.NewWebhookWorker(repository.NewWebhookRepository(e.db), e.webhookNotifier, e.cfg.webhook)
+	server := newServer(e)
+
+	receiver := &webhookReceiver{status: http.StatusInternalServerError}
+	target := httptest.NewServer(receiver)
+	defer target.Close()
+
+	req := createTestBodyRequest(http.MethodPost, "/v1/admin/webhooks", "", webhookRequest{URL: target.URL})
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	var webhook models.Webhook
+	err := json.NewDecoder(res.Body).Decode(&webhook)
+	assert.NoError(err)
+	assert.Len(webhook.Secret, 64)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/admin/texts/key/NOT_IN_GROUP/language/sv", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+
+	ctx := context.New(stdctx.Background(), "TestWebhookDeliveryRetries", "")
+	assert.Equal(1, e.webhookWorker.DeliverDue(ctx))
+
+	req = createTestRequest("/v1/admin/webhooks/"+webhook.ID+"/deliveries", "")
+	res = performTestRequest(server.Handler, req)
+	var deliveries []models.WebhookDelivery
+	err = json.NewDecoder(res.Body).Decode(&deliveries)
+	assert.NoError(err)
+	assert.Equal(1, len(deliveries))
+	assert.Equal(models.DeliveryPending, deliveries[0].Status)
+	assert.Equal(1, deliveries[0].Attempts)
+	assert.Equal(http.StatusInternalServerError, deliveries[0].ResponseCode)
+
+	assert.Equal(1, e.webhookWorker.DeliverDue(ctx))
+	assert.Equal(0, e.webhookWorker.DeliverDue(ctx))
+
+	req = createTestRequest("/v1/admin/webhooks/"+webhook.ID+"/deliveries", "")
+	res = performTestRequest(server.Handler, req)
+	err = json.NewDecoder(res.Body).Decode(&deliveries)
+	assert.NoError(err)
+	assert.Equal(models.DeliveryFailed, deliveries[0].Status)
+	assert.Equal(2, deliveries[0].Attempts)
+	assert.Equal(2, len(receiver.received))
+}
+
+func TestWebhookDeliveriesAreClaimed(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	receiver := &webhookReceiver{status: http.StatusOK}
+	target := httptest.NewServer(receiver)
+	defer target.Close()
+
+	req := createTestBodyRequest(http.MethodPost, "/v1/admin/webhooks", "", webhookRequest{URL: target.URL})
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusCreated, res.Code)
+
+	var webhook models.Webhook
+	err := json.NewDecoder(res.Body).Decode(&webhook)
+	assert.NoError(err)
+
+	ctx := context.New(stdctx.Background(), "TestWebhookDeliveriesAreClaimed", "")
+	webhookRepo := repository.NewWebhookRepository(e.db)
+	now := time.Now().UTC()
+	err = webhookRepo.SaveDeliveries(ctx, []models.WebhookDelivery{
+		{WebhookID: webhook.ID, Event: "TEXT.UPDATED", Payload: "{}", Status: models.DeliveryPending, NextAttemptAt: now, CreatedAt: now, UpdatedAt: now},
+	})
+	assert.NoError(err)
+
+	claimed, err := webhookRepo.ClaimDueDeliveries(ctx, time.Now().UTC(), time.Now().UTC().Add(time.Minute), 10)
+	assert.NoError(err)
+	assert.Equal(1, len(claimed))
+
+	claimed, err = webhookRepo.ClaimDueDeliveries(ctx, time.Now().UTC(), time.Now().UTC().Add(time.Minute), 10)
+	assert.NoError(err)
+	assert.Equal(0, len(claimed))
+
+	assert.Equal(0, e.webhookWorker.DeliverDue(ctx))
+	assert.Equal(0, len(receiver.received))
+}
+
+func TestCreateWebhookValidation(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestBodyRequest(http.MethodPost, "/v1/admin/webhooks", "", webhookRequest{URL: "ftp://example.com"})
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	body := webhookRequest{URL: "https://example.com/hook", Types: []string{"UNKNOWN"}}
+	req = createTestBodyRequest(http.MethodPost, "/v1/admin/webhooks", "", body)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	req = createTestBodyRequest(http.MethodDelete, "/v1/admin/webhooks/missing", "", nil)
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusNotFound, res.Code)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/CzarSimon/text-service/go/pkg/utils/xliff"
+	"github.com/gin-gonic/gin"
+)
+
+const xliffMediaType = "application/xliff+xml"
+
+func (e *env) exportXLIFF(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("exportXLIFF", "ctx", ctx)
+
+	source, target := c.Query("source"), c.Query("target")
+	version := c.DefaultQuery("version", xliff.Version12)
+	doc, err := e.xliffConverter.Export(ctx, version, source, target, c.Query("group"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	err = xliff.Write(&buf, doc)
+	if err != nil {
+		controllerLog.Errorw("Failed to write xliff document", "error", err, "ctx", ctx)
+		c.Error(httputil.ErrInternalServerError)
+		return
+	}
+
+	c.Header(httputil.ContentDisposition, `attachment; filename="`+source+"-"+target+`.xlf"`)
+	c.Data(http.StatusOK, xliffMediaType+"; charset=utf-8", buf.Bytes())
+}
+
+func (e *env) importXLIFF(c *gin.Context) {
+	ctx := createContext(c)
+	controllerLog.Debugw("importXLIFF", "ctx", ctx)
+
+	data, err := e.readImportBody(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	doc, err := xliff.Parse(bytes.NewReader(data))
+	if err != nil {
+		c.Error(httputil.BadRequest("Invalid XLIFF document: " + err.Error()))
+		return
+	}
+
+	result, err := e.xliffConverter.Import(ctx, doc)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	stdctx "context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/service"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/CzarSimon/text-service/go/pkg/utils/xliff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportXLIFF(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	req := createTestRequest("/v1/export/xliff?source=sv&target=en", "")
+	res := performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Equal("application/xliff+xml; charset=utf-8", res.Header().Get("Content-Type"))
+	assert.Contains(res.Body.String(), `xmlns="urn:oasis:names:tc:xliff:document:1.2"`)
+
+	doc, err := xliff.Parse(res.Body)
+	assert.NoError(err)
+	assert.Equal("sv", doc.SourceLanguage)
+	assert.Equal("en", doc.TargetLanguage)
+	assert.Equal([]xliff.Unit{
+		{ID: "NOT_IN_GROUP", Source: "sv-non-group-val", Target: "en-non-group-val", State: "translated"},
+		{ID: "ONLY_SV_TEXT_KEY", Source: "sv-only-val", State: "needs-translation"},
+		{ID: "OTHER_TEXT_KEY", Source: "sv-other-val", Target: "en-other-val", State: "translated"},
+		{ID: "TEST_TEXT_KEY", Source: "sv-text-val", Target: "en-text-val", State: "translated"},
+	}, doc.Units)
+
+	req = createTestRequest("/v1/export/xliff?source=en&target=sv&group=MOBILE_APP&version=2.0", "")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	doc, err = xliff.Parse(res.Body)
+	assert.NoError(err)
+	assert.Equal(xliff.Version20, doc.Version)
+	assert.Len(doc.Units, 2)
+	assert.Equal("OTHER_TEXT_KEY", doc.Units[0].ID)
+	assert.Equal("TEST_TEXT_KEY", doc.Units[1].ID)
+
+	for path, status := range map[string]int{
+		"/v1/export/xliff?source=sv":                            http.StatusBadRequest,
+		"/v1/export/xliff?source=sv&target=sv":                  http.StatusBadRequest,
+		"/v1/export/xliff?source=sv&target=fi":                  http.StatusBadRequest,
+		"/v1/export/xliff?source=sv&target=en&version=1.0":      http.StatusBadRequest,
+		"/v1/export/xliff?source=sv&target=en&group=NO_SUCH_ID": http.StatusNotFound,
+	} {
+		req = createTestRequest(path, "")
+		res = performTestRequest(server.Handler, req)
+		assert.Equal(status, res.Code, path)
+	}
+}
+
+func TestImportXLIFF(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+	server := newServer(e)
+
+	doc := xliff.Document{
+		Version:        xliff.Version20,
+		SourceLanguage: "sv",
+		TargetLanguage: "en",
+		Units: []xliff.Unit{
+			{ID: "NOT_IN_GROUP", Source: "sv-non-group-val", Target: "en-non-group-val"},
+			{ID: "ONLY_SV_TEXT_KEY", Source: "sv-only-val", Target: "en-only-val"},
+			{ID: "OTHER_TEXT_KEY", Source: "sv-other-val"},
+			{ID: "TEST_TEXT_KEY", Source: "sv-text-val", Target: "en-text-val-2"},
+		},
+	}
+	res := performTestImportXLIFF(server.Handler, doc)
+	assert.Equal(http.StatusOK, res.Code)
+
+	var result models.XLIFFImport
+	err := json.NewDecoder(res.Body).Decode(&result)
+	assert.NoError(err)
+	assert.Equal(models.XLIFFImport{
+		SourceLanguage: "sv",
+		TargetLanguage: "en",
+		Created:        []string{"ONLY_SV_TEXT_KEY"},
+		Updated:        []string{"TEST_TEXT_KEY"},
+		Untranslated:   []string{"OTHER_TEXT_KEY"},
+		Unchanged:      1,
+	}, result)
+
+	req := createTestRequest("/v1/texts/key/ONLY_SV_TEXT_KEY", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusOK, res.Code)
+	assert.Contains(res.Body.String(), "en-only-val")
+
+	req = createTestRequest("/v1/texts/key/OTHER_TEXT_KEY", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Contains(res.Body.String(), "en-other-val")
+
+	// The source changed since the export, nothing in the document may be imported.
+	doc.Units[0].Target = "en-non-group-val-2"
+	doc.Units[3].Source = "sv-outdated-val"
+	doc.Units = append(doc.Units, xliff.Unit{ID: "DELETED_KEY", Source: "sv-deleted-val", Target: "en-deleted-val"})
+	res = performTestImportXLIFF(server.Handler, doc)
+	assert.Equal(http.StatusConflict, res.Code)
+	assert.Contains(res.Body.String(), "TEST_TEXT_KEY,DELETED_KEY")
+
+	req = createTestRequest("/v1/texts/key/NOT_IN_GROUP", "en")
+	res = performTestRequest(server.Handler, req)
+	assert.Contains(res.Body.String(), `"en-non-group-val"`)
+
+	doc.Units = []xliff.Unit{{ID: "TEST_TEXT_KEY", Source: "sv-text-val", Target: "{broken"}}
+	res = performTestImportXLIFF(server.Handler, doc)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	doc.TargetLanguage = "fi"
+	res = performTestImportXLIFF(server.Handler, doc)
+	assert.Equal(http.StatusBadRequest, res.Code)
+
+	req = createTestRawRequest(http.MethodPost, "/v1/import/xliff", "<resources/>")
+	res = performTestRequest(server.Handler, req)
+	assert.Equal(http.StatusBadRequest, res.Code)
+	assert.Contains(res.Body.String(), "Invalid XLIFF document")
+
+	e.cfg.maxImportSize = 16
+	res = performTestImportXLIFF(server.Handler, doc)
+	assert.Equal(http.StatusRequestEntityTooLarge, res.Code)
+}
+
+func TestImportXLIFFWithConcurrentSourceChange(t *testing.T) {
+	assert := assert.New(t)
+	e := createTestEnv()
+
+	// The source is changed by another request after the texts were read.
+	textRepo := updatingTextRepo{TextRepository: repository.NewTextRepository(e.db)}
+	converter := service.NewXLIFFConverter(
+		repository.NewLanguageRepository(e.db),
+		textRepo,
+		repository.NewGroupRepository(e.db),
+		service.ChangeListeners{},
+	)
+
+	doc := xliff.Document{
+		Version:        xliff.Version20,
+		SourceLanguage: "sv",
+		TargetLanguage: "en",
+		Units:          []xliff.Unit{{ID: "TEST_TEXT_KEY", Source: "sv-text-val", Target: "en-text-val-2"}},
+	}
+	ctx := context.New(stdctx.Background(), "TestImportXLIFFWithConcurrentSourceChange", "")
+	_, err := converter.Import(ctx, doc)
+	httpErr, ok := err.(*httputil.Error)
+	assert.True(ok)
+	assert.Equal(http.StatusConflict, httpErr.StatusCode)
+
+	text, err := textRepo.Find(ctx, "TEST_TEXT_KEY", "en")
+	assert.NoError(err)
+	assert.Equal("en-text-val", text.Value)
+}
+
+// updatingTextRepo updates the swedish TEST_TEXT_KEY text after all texts are read, as another request would.
+type updatingTextRepo struct {
+	repository.TextRepository
+}
+
+func (r updatingTextRepo) FindAll(ctx *context.Context) ([]models.TranslatedText, error) {
+	texts, err := r.TextRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.TextRepository.Upsert(ctx, models.TranslatedText{Key: "TEST_TEXT_KEY", Language: "sv", Value: "sv-changed-val"})
+	return texts, err
+}
+
+func performTestImportXLIFF(handler http.Handler, doc xliff.Document) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	xliff.Write(&buf, doc)
+	req := createTestRawRequest(http.MethodPost, "/v1/import/xliff", buf.String())
+	return performTestRequest(handler, req)
+}
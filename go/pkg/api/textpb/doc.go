@@ -0,0 +1,10 @@
+// Package textpb holds the protobuf definition of the gRPC text service and
+// the code generated from it. Regenerate the code with protoc, protoc-gen-go
+// and protoc-gen-go-grpc after changing text.proto, e.g.:
+//
+//	go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.34.1
+//	go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.4.0
+//	go generate ./pkg/api/textpb
+package textpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative text.proto
@@ -5,24 +5,299 @@ import "time"
 // Texts text output format.
 type Texts map[string]string
 
+// ResolvedTexts texts resolved for a language, along with the language each
+// text was served in when it had to be taken from a fallback language, and
+// when the resolved texts last changed.
+type ResolvedTexts struct {
+	Texts        Texts
+	Fallbacks    map[string]string
+	LastModified time.Time
+}
+
+// Text directions.
+const (
+	LeftToRight = "ltr"
+	RightToLeft = "rtl"
+)
+
 // Language supported language.
 type Language struct {
-	ID        string
-	CreatedAt time.Time
+	ID          string    `json:"id"`
+	EnglishName string    `json:"englishName"`
+	NativeName  string    `json:"nativeName"`
+	Direction   string    `json:"direction"`
+	Fallback    string    `json:"fallback,omitempty"`
+	Enabled     bool      `json:"enabled"`
+	CreatedAt   time.Time `json:"createdAt"`
 }
 
 // TranslatedText localized text.
 type TranslatedText struct {
-	ID        int
-	Key       string
-	Language  string
-	Value     string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID        int       `json:"id"`
+	Key       string    `json:"key"`
+	Language  string    `json:"language"`
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TextCondition state a text must be in for other texts to be stored, checked in the same
+// transaction as they are stored. The text must not exist if Absent is set, otherwise it must
+// exist, have the Value unless it is empty and not have been updated after UpdatedBefore unless it is zero.
+type TextCondition struct {
+	Key           string
+	Language      string
+	Absent        bool
+	Value         string
+	UpdatedBefore time.Time
+}
+
+// TextFilter criteria for listing translated texts ordered by key and language.
+// Empty fields match any text, listing starts after the AfterKey and AfterLanguage pair.
+type TextFilter struct {
+	Language      string
+	Group         string
+	Prefix        string
+	UpdatedSince  time.Time
+	AfterKey      string
+	AfterLanguage string
+}
+
+// TextPage page of translated texts, the next page is fetched using the cursor.
+type TextPage struct {
+	Texts      []TranslatedText `json:"texts"`
+	NextCursor string           `json:"nextCursor,omitempty"`
+}
+
+// TextDraft staged value of a translated text which is not served until published.
+// PublishedValue holds the currently published value, if any.
+type TextDraft struct {
+	ID             int       `json:"id"`
+	Key            string    `json:"key"`
+	Language       string    `json:"language"`
+	Value          string    `json:"value"`
+	PublishedValue string    `json:"publishedValue,omitempty"`
+	ChangedBy      string    `json:"changedBy"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// Text returns the draft as a translated text.
+func (d TextDraft) Text() TranslatedText {
+	return TranslatedText{
+		ID:        d.ID,
+		Key:       d.Key,
+		Language:  d.Language,
+		Value:     d.Value,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
+
+// TextRevision recorded value of a translated text after a change.
+type TextRevision struct {
+	ID        int       `json:"id"`
+	Key       string    `json:"key"`
+	Language  string    `json:"language"`
+	Value     string    `json:"value"`
+	Action    string    `json:"action"`
+	ChangedBy string    `json:"changedBy"`
+	CreatedAt time.Time `json:"createdAt"`
 }
 
 // TextGroup group of texts.
 type TextGroup struct {
-	ID        string
-	CreatedAt time.Time
+	ID        string    `json:"id"`
+	TextCount int       `json:"textCount"`
+	Keys      []string  `json:"keys,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Project namespace of texts, groups and releases.
+type Project struct {
+	ID          string    `json:"id"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Release immutable snapshot of texts and group memberships.
+type Release struct {
+	ID          string    `json:"id"`
+	Description string    `json:"description"`
+	Current     bool      `json:"current"`
+	TextCount   int       `json:"textCount"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// Roles of API callers, each role is granted the access of the roles before it.
+const (
+	ReaderRole     = "reader"
+	TranslatorRole = "translator"
+	AdminRole      = "admin"
+)
+
+// APIKey static key used to authenticate API callers. Only the hash of the
+// key is stored, the key itself is returned once when the key is created.
+type APIKey struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	Key       string    `json:"key,omitempty"`
+	Hash      string    `json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// MissingTranslation text key which is not translated to all languages.
+type MissingTranslation struct {
+	Key        string   `json:"key"`
+	Translated []string `json:"translated"`
+	Missing    []string `json:"missing"`
+}
+
+// SearchResult page of texts matching a search.
+type SearchResult struct {
+	Texts   []TranslatedText `json:"texts"`
+	Offset  int              `json:"offset"`
+	Limit   int              `json:"limit"`
+	HasMore bool             `json:"hasMore"`
+}
+
+// LanguageStats translation completeness of a language.
+type LanguageStats struct {
+	Language        string     `json:"language"`
+	Keys            int        `json:"keys"`
+	Translated      int        `json:"translated"`
+	PercentComplete float64    `json:"percentComplete"`
+	LastUpdated     *time.Time `json:"lastUpdated,omitempty"`
+}
+
+// GroupStats translation completeness of the languages of a group.
+type GroupStats struct {
+	Group     string          `json:"group"`
+	Keys      int             `json:"keys"`
+	Languages []LanguageStats `json:"languages"`
+}
+
+// Stats translation completeness of a project, per language and per group.
+type Stats struct {
+	Keys      int             `json:"keys"`
+	Languages []LanguageStats `json:"languages"`
+	Groups    []GroupStats    `json:"groups"`
+}
+
+// Webhook subscription to changes, delivered as signed JSON payloads to the URL.
+// An empty list of types subscribes to all types of changes.
+type Webhook struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Types     []string  `json:"types"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Webhook delivery statuses.
+const (
+	DeliveryPending   = "PENDING"
+	DeliveryDelivered = "DELIVERED"
+	DeliveryFailed    = "FAILED"
+)
+
+// WebhookDelivery attempted delivery of a change to a webhook.
+type WebhookDelivery struct {
+	ID            int       `json:"id"`
+	WebhookID     string    `json:"webhookId"`
+	URL           string    `json:"url"`
+	Secret        string    `json:"-"`
+	Event         string    `json:"event"`
+	Payload       string    `json:"payload"`
+	Status        string    `json:"status"`
+	Attempts      int       `json:"attempts"`
+	ResponseCode  int       `json:"responseCode"`
+	Error         string    `json:"error,omitempty"`
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// GettextEntry gettext metadata of a translated text, kept so that the text
+// is exported with the msgctxt, msgid and comments it was imported with.
+type GettextEntry struct {
+	Key                string
+	Language           string
+	Context            string
+	ID                 string
+	IDPlural           string
+	TranslatorComments []string
+	ExtractedComments  []string
+	References         []string
+	Flags              []string
+}
+
+// GettextImport outcome of importing a gettext catalog, the number of texts created and updated.
+// Untranslated and fuzzy messages are skipped.
+type GettextImport struct {
+	Language string `json:"language"`
+	Created  int    `json:"created"`
+	Updated  int    `json:"updated"`
+	Skipped  int    `json:"skipped"`
+}
+
+// XLIFFImport outcome of importing an XLIFF document. Created and updated units had their
+// target text created or updated, untranslated units were left as is.
+type XLIFFImport struct {
+	SourceLanguage string   `json:"sourceLanguage"`
+	TargetLanguage string   `json:"targetLanguage"`
+	Created        []string `json:"created"`
+	Updated        []string `json:"updated"`
+	Untranslated   []string `json:"untranslated"`
+	Unchanged      int      `json:"unchanged"`
+}
+
+// SpreadsheetDiff difference between the value of a text in a spreadsheet and its current value.
+type SpreadsheetDiff struct {
+	Key      string `json:"key"`
+	Language string `json:"language"`
+	Current  string `json:"current,omitempty"`
+	Value    string `json:"value"`
+}
+
+// SpreadsheetImport outcome of importing a spreadsheet of texts, or of a dry run of the import.
+// Conflicts are values which differ from texts changed after the spreadsheet was exported.
+type SpreadsheetImport struct {
+	DryRun    bool              `json:"dryRun"`
+	Creates   []SpreadsheetDiff `json:"creates"`
+	Updates   []SpreadsheetDiff `json:"updates"`
+	Conflicts []SpreadsheetDiff `json:"conflicts"`
+	Unchanged int               `json:"unchanged"`
+}
+
+// Change types.
+const (
+	TextChange     = "TEXT"
+	GroupChange    = "GROUP"
+	LanguageChange = "LANGUAGE"
+	ReleaseChange  = "RELEASE"
+	DraftChange    = "DRAFT"
+	ProjectChange  = "PROJECT"
+)
+
+// Change actions.
+const (
+	Created   = "CREATED"
+	Updated   = "UPDATED"
+	Deleted   = "DELETED"
+	Published = "PUBLISHED"
+)
+
+// Change describes a change made to a text, group or language.
+type Change struct {
+	Type     string    `json:"type"`
+	Action   string    `json:"action"`
+	Key      string    `json:"key,omitempty"`
+	Language string    `json:"language,omitempty"`
+	GroupID  string    `json:"groupId,omitempty"`
+	Keys     []string  `json:"keys,omitempty"`
+	Release  string    `json:"release,omitempty"`
+	Project  string    `json:"project,omitempty"`
+	Time     time.Time `json:"time"`
 }
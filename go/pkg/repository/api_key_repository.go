@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/pkg/errors"
+)
+
+// APIKeyRepository storage interface for hashed API keys.
+type APIKeyRepository interface {
+	FindByHash(ctx *context.Context, hash string) (models.APIKey, error)
+	FindAll(ctx *context.Context) ([]models.APIKey, error)
+	Save(ctx *context.Context, key models.APIKey) error
+	Delete(ctx *context.Context, keyID string) error
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository using the default implementation.
+func NewAPIKeyRepository(db *sql.DB) APIKeyRepository {
+	return &apiKeyRepo{
+		db: db,
+	}
+}
+
+type apiKeyRepo struct {
+	db *sql.DB
+}
+
+const findAPIKeyByHashQuery = `SELECT id, name, role, key_hash, created_at FROM api_key WHERE key_hash = $1`
+
+func (r *apiKeyRepo) FindByHash(ctx *context.Context, hash string) (models.APIKey, error) {
+	log.Debugw("apiKeyRepo.FindByHash", "ctx", ctx)
+
+	var k models.APIKey
+	err := r.db.QueryRowContext(ctx, findAPIKeyByHashQuery, hash).Scan(&k.ID, &k.Name, &k.Role, &k.Hash, &k.CreatedAt)
+	if err == sql.ErrNoRows {
+		return models.APIKey{}, ErrNotFound
+	}
+
+	if err != nil {
+		return models.APIKey{}, errors.Wrap(err, "Failed to query api_key")
+	}
+
+	return k, nil
+}
+
+const findAllAPIKeysQuery = `SELECT id, name, role, key_hash, created_at FROM api_key ORDER BY created_at, id`
+
+func (r *apiKeyRepo) FindAll(ctx *context.Context) ([]models.APIKey, error) {
+	log.Debugw("apiKeyRepo.FindAll", "ctx", ctx)
+	rows, err := r.db.QueryContext(ctx, findAllAPIKeysQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query api keys")
+	}
+	defer rows.Close()
+
+	keys := make([]models.APIKey, 0)
+	var k models.APIKey
+	for rows.Next() {
+		err = rows.Scan(&k.ID, &k.Name, &k.Role, &k.Hash, &k.CreatedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to scan api key")
+		}
+
+		keys = append(keys, k)
+	}
+
+	return keys, nil
+}
+
+const saveAPIKeyQuery = `INSERT INTO api_key(id, name, role, key_hash, created_at) VALUES ($1, $2, $3, $4, $5)`
+
+func (r *apiKeyRepo) Save(ctx *context.Context, key models.APIKey) error {
+	log.Debugw("apiKeyRepo.Save", "keyId", key.ID, "ctx", ctx)
+	_, err := r.db.ExecContext(ctx, saveAPIKeyQuery, key.ID, key.Name, key.Role, key.Hash, key.CreatedAt)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to save api key. id=%s", key.ID)
+	}
+
+	return nil
+}
+
+const deleteAPIKeyQuery = `DELETE FROM api_key WHERE id = $1`
+
+func (r *apiKeyRepo) Delete(ctx *context.Context, keyID string) error {
+	log.Debugw("apiKeyRepo.Delete", "keyId", keyID, "ctx", ctx)
+	res, err := r.db.ExecContext(ctx, deleteAPIKeyQuery, keyID)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to delete api key. id=%s", keyID)
+	}
+
+	return assertRowsAffected(res)
+}
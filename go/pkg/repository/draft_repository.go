@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/pkg/errors"
+)
+
+// DraftRepository storage interface for draft values of translated texts.
+type DraftRepository interface {
+	Find(ctx *context.Context, key, language string) (models.TextDraft, error)
+	FindAll(ctx *context.Context, key, language, groupID string) ([]models.TextDraft, error)
+	Upsert(ctx *context.Context, text models.TranslatedText) error
+	Delete(ctx *context.Context, key, language string) error
+	Publish(ctx *context.Context, key, language, groupID string) ([]models.TextDraft, error)
+}
+
+// NewDraftRepository creates a new DraftRepository using the default implementation.
+func NewDraftRepository(db *sql.DB) DraftRepository {
+	return &draftRepo{
+		db: db,
+	}
+}
+
+type draftRepo struct {
+	db *sql.DB
+}
+
+const findDraftQuery = `
+	SELECT d.id, d.key, d.language, d.value, COALESCE(t.value, ''), d.changed_by, d.created_at, d.updated_at
+	FROM text_draft d LEFT JOIN translated_text t ON t.project = d.project AND t.key = d.key AND t.language = d.language
+	WHERE d.project = $1 AND d.key = $2 AND d.language = $3`
+
+func (r *draftRepo) Find(ctx *context.Context, key, language string) (models.TextDraft, error) {
+	log.Debugw("draftRepo.Find", "key", key, "language", language, "ctx", ctx)
+
+	var d models.TextDraft
+	err := r.db.QueryRowContext(ctx, findDraftQuery, ctx.Project, key, language).Scan(
+		&d.ID, &d.Key, &d.Language, &d.Value, &d.PublishedValue, &d.ChangedBy, &d.CreatedAt, &d.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return models.TextDraft{}, ErrNotFound
+	}
+
+	if err != nil {
+		return models.TextDraft{}, errors.Wrapf(err, "Failed to query text_draft. key=%s language=%s", key, language)
+	}
+
+	return d, nil
+}
+
+const findDraftsQuery = `
+	SELECT d.id, d.key, d.language, d.value, COALESCE(t.value, ''), d.changed_by, d.created_at, d.updated_at
+	FROM text_draft d LEFT JOIN translated_text t ON t.project = d.project AND t.key = d.key AND t.language = d.language
+	WHERE d.project = $1
+	AND ($2 = '' OR d.key = $2) 
+	AND ($3 = '' OR d.language = $3)
+	AND ($4 = '' OR d.key IN (SELECT tgm.text_key FROM text_group_membership tgm WHERE tgm.project = $1 AND tgm.group_id = $4))
+	ORDER BY d.key, d.language`
+
+// FindAll finds drafts matching the key, language and group, an empty filter value matches all drafts.
+func (r *draftRepo) FindAll(ctx *context.Context, key, language, groupID string) ([]models.TextDraft, error) {
+	log.Debugw("draftRepo.FindAll", "key", key, "language", language, "groupId", groupID, "ctx", ctx)
+	rows, err := r.db.QueryContext(ctx, findDraftsQuery, ctx.Project, key, language, groupID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to query text_draft. key=%s language=%s groupId=%s", key, language, groupID)
+	}
+
+	return scanDrafts(rows)
+}
+
+func scanDrafts(rows *sql.Rows) ([]models.TextDraft, error) {
+	defer rows.Close()
+
+	drafts := make([]models.TextDraft, 0)
+	var d models.TextDraft
+	for rows.Next() {
+		err := rows.Scan(&d.ID, &d.Key, &d.Language, &d.Value, &d.PublishedValue, &d.ChangedBy, &d.CreatedAt, &d.UpdatedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to scan text draft")
+		}
+
+		drafts = append(drafts, d)
+	}
+
+	return drafts, rows.Err()
+}
+
+const upsertDraftQuery = `
+	INSERT INTO text_draft(project, key, language, value, changed_by, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT(project, key, language) DO UPDATE SET value = excluded.value, changed_by = excluded.changed_by, updated_at = excluded.updated_at`
+
+func (r *draftRepo) Upsert(ctx *context.Context, text models.TranslatedText) error {
+	log.Debugw("draftRepo.Upsert", "key", text.Key, "language", text.Language, "ctx", ctx)
+
+	now := time.Now()
+	_, err := r.db.ExecContext(ctx, upsertDraftQuery, ctx.Project, text.Key, text.Language, text.Value, ctx.User, now, now)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to upsert text_draft. key=%s language=%s", text.Key, text.Language)
+	}
+
+	return nil
+}
+
+const deleteDraftQuery = `DELETE FROM text_draft WHERE project = $1 AND key = $2 AND language = $3`
+
+func (r *draftRepo) Delete(ctx *context.Context, key, language string) error {
+	log.Debugw("draftRepo.Delete", "key", key, "language", language, "ctx", ctx)
+
+	res, err := r.db.ExecContext(ctx, deleteDraftQuery, ctx.Project, key, language)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to delete text_draft. key=%s language=%s", key, language)
+	}
+
+	return assertRowsAffected(res)
+}
+
+const deletePublishedDraftQuery = `DELETE FROM text_draft WHERE project = $1 AND key = $2 AND language = $3 AND updated_at = $4`
+
+// Publish makes the drafts matching the key, language and group the published values of
+// their texts and removes the drafts, all in a single transaction. ErrConflict is returned
+// if a draft is changed or removed while it is being published.
+func (r *draftRepo) Publish(ctx *context.Context, key, language, groupID string) ([]models.TextDraft, error) {
+	log.Debugw("draftRepo.Publish", "key", key, "language", language, "groupId", groupID, "ctx", ctx)
+
+	var drafts []models.TextDraft
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, findDraftsQuery, ctx.Project, key, language, groupID)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to query text_draft. key=%s language=%s groupId=%s", key, language, groupID)
+		}
+
+		drafts, err = scanDrafts(rows)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, draft := range drafts {
+			res, err := tx.ExecContext(ctx, deletePublishedDraftQuery, ctx.Project, draft.Key, draft.Language, draft.UpdatedAt)
+			if err != nil {
+				return errors.Wrapf(err, "Failed to delete published text_draft. key=%s language=%s", draft.Key, draft.Language)
+			}
+
+			err = assertRowsAffected(res)
+			if err == ErrNotFound {
+				return ErrConflict
+			}
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.ExecContext(ctx, upsertTextQuery, ctx.Project, draft.Key, draft.Language, draft.Value, now, now)
+			if err != nil {
+				return errors.Wrapf(err, "Failed to publish text_draft. key=%s language=%s", draft.Key, draft.Language)
+			}
+
+			err = saveRevision(ctx, tx, draft.Text(), models.Published, now)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return drafts, nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/pkg/errors"
+)
+
+// GettextRepository storage interface for the gettext metadata of translated texts.
+type GettextRepository interface {
+	FindHeader(ctx *context.Context, language string) (string, error)
+	FindEntries(ctx *context.Context) ([]models.GettextEntry, error)
+	Save(ctx *context.Context, language, header string, entries []models.GettextEntry, texts []models.TranslatedText) ([]string, error)
+}
+
+// NewGettextRepository creates a new GettextRepository using the default implementation.
+func NewGettextRepository(db *sql.DB) GettextRepository {
+	return &gettextRepo{
+		db: db,
+	}
+}
+
+type gettextRepo struct {
+	db *sql.DB
+}
+
+const findGettextHeaderQuery = `SELECT header FROM gettext_header WHERE project = $1 AND language = $2`
+
+func (r *gettextRepo) FindHeader(ctx *context.Context, language string) (string, error) {
+	log.Debugw("gettextRepo.FindHeader", "language", language, "ctx", ctx)
+
+	var header string
+	err := r.db.QueryRowContext(ctx, findGettextHeaderQuery, ctx.Project, language).Scan(&header)
+	if err == sql.ErrNoRows {
+		return "", ErrNotFound
+	}
+
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to query gettext_header. language=%s", language)
+	}
+
+	return header, nil
+}
+
+const findGettextEntriesQuery = `
+	SELECT text_key, language, msgctxt, msgid, msgid_plural, translator_comments, extracted_comments, source_references, flags 
+	FROM gettext_entry WHERE project = $1 ORDER BY text_key, language`
+
+// FindEntries finds the gettext metadata of the project's texts in all languages.
+func (r *gettextRepo) FindEntries(ctx *context.Context) ([]models.GettextEntry, error) {
+	log.Debugw("gettextRepo.FindEntries", "ctx", ctx)
+	rows, err := r.db.QueryContext(ctx, findGettextEntriesQuery, ctx.Project)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query gettext entries")
+	}
+	defer rows.Close()
+
+	entries := make([]models.GettextEntry, 0)
+	for rows.Next() {
+		var e models.GettextEntry
+		var translatorComments, extractedComments, references, flags string
+		err = rows.Scan(&e.Key, &e.Language, &e.Context, &e.ID, &e.IDPlural, &translatorComments, &extractedComments, &references, &flags)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to scan gettext entry")
+		}
+
+		e.TranslatorComments = splitList(translatorComments, "\n")
+		e.ExtractedComments = splitList(extractedComments, "\n")
+		e.References = splitList(references, "\n")
+		e.Flags = splitList(flags, ",")
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+const upsertGettextHeaderQuery = `
+	INSERT INTO gettext_header(project, language, header, updated_at) VALUES ($1, $2, $3, $4)
+	ON CONFLICT(project, language) DO UPDATE SET header = excluded.header, updated_at = excluded.updated_at`
+
+const upsertGettextEntryQuery = `
+	INSERT INTO gettext_entry(project, text_key, language, msgctxt, msgid, msgid_plural, translator_comments, extracted_comments, source_references, flags, updated_at) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	ON CONFLICT(project, text_key, language) DO UPDATE SET 
+		msgctxt = excluded.msgctxt, msgid = excluded.msgid, msgid_plural = excluded.msgid_plural, 
+		translator_comments = excluded.translator_comments, extracted_comments = excluded.extracted_comments, 
+		source_references = excluded.source_references, flags = excluded.flags, updated_at = excluded.updated_at`
+
+// Save stores the header of a language's catalog along with the metadata of its entries and
+// upserts the imported texts, all in a single transaction. The action recorded for each text is returned.
+func (r *gettextRepo) Save(ctx *context.Context, language, header string, entries []models.GettextEntry, texts []models.TranslatedText) ([]string, error) {
+	log.Debugw("gettextRepo.Save", "language", language, "entries", len(entries), "texts", len(texts), "ctx", ctx)
+
+	actions := make([]string, 0, len(texts))
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+		for _, text := range texts {
+			action, err := upsertText(ctx, tx, text, now)
+			if err != nil {
+				return err
+			}
+			actions = append(actions, action)
+		}
+
+		_, err := tx.ExecContext(ctx, upsertGettextHeaderQuery, ctx.Project, language, header, now)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to upsert gettext_header. language=%s", language)
+		}
+
+		stmt, err := tx.PrepareContext(ctx, upsertGettextEntryQuery)
+		if err != nil {
+			return errors.Wrap(err, "Failed to prepare gettext_entry upsert")
+		}
+		defer stmt.Close()
+
+		for _, e := range entries {
+			_, err = stmt.ExecContext(ctx, ctx.Project, e.Key, language, e.Context, e.ID, e.IDPlural,
+				strings.Join(e.TranslatorComments, "\n"), strings.Join(e.ExtractedComments, "\n"),
+				strings.Join(e.References, "\n"), strings.Join(e.Flags, ","), now)
+			if err != nil {
+				return errors.Wrapf(err, "Failed to upsert gettext_entry. key=%s language=%s", e.Key, language)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
+
+func splitList(list, sep string) []string {
+	if list == "" {
+		return nil
+	}
+
+	return strings.Split(list, sep)
+}
@@ -11,9 +11,17 @@ import (
 
 // GroupRepository storage interface for translated texts.
 type GroupRepository interface {
+	Find(ctx *context.Context, groupID string) (models.TextGroup, error)
+	FindAll(ctx *context.Context) ([]models.TextGroup, error)
+	FindKeys(ctx *context.Context, groupID string) ([]string, error)
 	FindTexts(ctx *context.Context, groupID, language string) ([]models.TranslatedText, error)
 	Save(ctx *context.Context, group models.TextGroup) error
+	Rename(ctx *context.Context, groupID, newID string) error
+	Delete(ctx *context.Context, groupID string) error
 	AddTextToGroup(ctx *context.Context, textKey, groupID string) error
+	AddTextsToGroup(ctx *context.Context, groupID string, textKeys []string) error
+	RemoveTextsFromGroup(ctx *context.Context, groupID string, textKeys []string) error
+	LastRemoval(ctx *context.Context, groupID string) (time.Time, error)
 }
 
 // NewGroupRepository creates a new GroupRepository using the default implementation.
@@ -27,15 +35,85 @@ type groupRepo struct {
 	db *sql.DB
 }
 
+const findGroupQuery = `
+	SELECT g.id, g.created_at, (SELECT COUNT(DISTINCT tgm.text_key) FROM text_group_membership tgm WHERE tgm.project = g.project AND tgm.group_id = g.id)
+	FROM text_group g WHERE g.project = $1 AND g.id = $2`
+
+func (r *groupRepo) Find(ctx *context.Context, groupID string) (models.TextGroup, error) {
+	log.Debugw("groupRepo.Find", "groupId", groupID, "ctx", ctx)
+
+	var g models.TextGroup
+	err := r.db.QueryRowContext(ctx, findGroupQuery, ctx.Project, groupID).Scan(&g.ID, &g.CreatedAt, &g.TextCount)
+	if err == sql.ErrNoRows {
+		return models.TextGroup{}, ErrNotFound
+	}
+
+	if err != nil {
+		return models.TextGroup{}, errors.Wrapf(err, "Failed to query group. groupId=%s", groupID)
+	}
+
+	return g, nil
+}
+
+const findAllGroupsQuery = `
+	SELECT g.id, g.created_at, (SELECT COUNT(DISTINCT tgm.text_key) FROM text_group_membership tgm WHERE tgm.project = g.project AND tgm.group_id = g.id)
+	FROM text_group g WHERE g.project = $1 ORDER BY g.id`
+
+func (r *groupRepo) FindAll(ctx *context.Context) ([]models.TextGroup, error) {
+	log.Debugw("groupRepo.FindAll", "ctx", ctx)
+	rows, err := r.db.QueryContext(ctx, findAllGroupsQuery, ctx.Project)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query groups")
+	}
+	defer rows.Close()
+
+	groups := make([]models.TextGroup, 0)
+	var g models.TextGroup
+	for rows.Next() {
+		err = rows.Scan(&g.ID, &g.CreatedAt, &g.TextCount)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to scan group")
+		}
+
+		groups = append(groups, g)
+	}
+
+	return groups, nil
+}
+
+const findGroupKeysQuery = `SELECT DISTINCT text_key FROM text_group_membership WHERE project = $1 AND group_id = $2 ORDER BY text_key`
+
+func (r *groupRepo) FindKeys(ctx *context.Context, groupID string) ([]string, error) {
+	log.Debugw("groupRepo.FindKeys", "groupId", groupID, "ctx", ctx)
+	rows, err := r.db.QueryContext(ctx, findGroupKeysQuery, ctx.Project, groupID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to query group keys. groupId=%s", groupID)
+	}
+	defer rows.Close()
+
+	keys := make([]string, 0)
+	var key string
+	for rows.Next() {
+		err = rows.Scan(&key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to scan group key. groupId=%s", groupID)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
 const findGroupTextsQuery = `
 	SELECT t.id, t.key, t.language, t.value, t.created_at, t.updated_at 
 	FROM translated_text t
-	INNER JOIN text_group_membership tgm ON t.key = tgm.text_key
-	WHERE tgm.group_id = $1 AND t.language = $2`
+	INNER JOIN text_group_membership tgm ON t.project = tgm.project AND t.key = tgm.text_key
+	WHERE tgm.project = $1 AND tgm.group_id = $2 AND t.language = $3`
 
 func (r *groupRepo) FindTexts(ctx *context.Context, groupID, language string) ([]models.TranslatedText, error) {
 	log.Debugw("groupRepo.FindTexts", "groupId", groupID, "language", language, "ctx", ctx)
-	rows, err := r.db.QueryContext(ctx, findGroupTextsQuery, groupID, language)
+	rows, err := r.db.QueryContext(ctx, findGroupTextsQuery, ctx.Project, groupID, language)
 	if err != nil {
 		return nil, errors.Wrapf(err, "Failed to query group texts. groupId=%s language=%s", groupID, language)
 	}
@@ -55,11 +133,11 @@ func (r *groupRepo) FindTexts(ctx *context.Context, groupID, language string) ([
 	return texts, nil
 }
 
-const saveGroupQuery = `INSERT INTO text_group(id, created_at) VALUES ($1, $2)`
+const saveGroupQuery = `INSERT INTO text_group(project, id, created_at) VALUES ($1, $2, $3)`
 
 func (r *groupRepo) Save(ctx *context.Context, group models.TextGroup) error {
 	log.Debugw("groupRepo.Save", "groupId", group.ID, "ctx", ctx)
-	_, err := r.db.ExecContext(ctx, saveGroupQuery, group.ID, time.Now())
+	_, err := r.db.ExecContext(ctx, saveGroupQuery, ctx.Project, group.ID, time.Now())
 	if err != nil {
 		return errors.Wrapf(err, "Failed to save group. id=%s", group.ID)
 	}
@@ -67,14 +145,155 @@ func (r *groupRepo) Save(ctx *context.Context, group models.TextGroup) error {
 	return nil
 }
 
-const addTextToGroupQuery = `INSERT INTO text_group_membership(text_key, group_id, created_at) VALUES ($1, $2, $3)`
+const addTextToGroupQuery = `INSERT INTO text_group_membership(project, text_key, group_id, created_at) VALUES ($1, $2, $3, $4)`
 
 func (r *groupRepo) AddTextToGroup(ctx *context.Context, textKey, groupID string) error {
 	log.Debugw("groupRepo.AddTextToGroup", "textKey", textKey, "groupId", groupID, "ctx", ctx)
-	_, err := r.db.ExecContext(ctx, addTextToGroupQuery, textKey, groupID, time.Now())
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+		_, err := tx.ExecContext(ctx, addTextToGroupQuery, ctx.Project, textKey, groupID, now)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to add text to group. textKey=%s groupId=%s", textKey, groupID)
+		}
+
+		return membersChanged(ctx, tx, groupID, now)
+	})
+}
+
+const membersChangedQuery = `UPDATE text_group SET members_changed_at = $1 WHERE project = $2 AND id = $3`
+
+// membersChanged records when the members of a group last changed.
+func membersChanged(ctx *context.Context, tx *sql.Tx, groupID string, now time.Time) error {
+	_, err := tx.ExecContext(ctx, membersChangedQuery, now, ctx.Project, groupID)
 	if err != nil {
-		return errors.Wrapf(err, "Failed to add text to group. textKey=%s groupId=%s", textKey, groupID)
+		return errors.Wrapf(err, "Failed to record group member change. groupId=%s", groupID)
 	}
 
 	return nil
 }
+
+const moveGroupMembersQuery = `UPDATE text_group_membership SET group_id = $1 WHERE project = $2 AND group_id = $3`
+const deleteGroupMembersQuery = `DELETE FROM text_group_membership WHERE project = $1 AND group_id = $2`
+const deleteGroupQuery = `DELETE FROM text_group WHERE project = $1 AND id = $2`
+
+func (r *groupRepo) Rename(ctx *context.Context, groupID, newID string) error {
+	log.Debugw("groupRepo.Rename", "groupId", groupID, "newId", newID, "ctx", ctx)
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var createdAt time.Time
+		err := tx.QueryRowContext(ctx, `SELECT created_at FROM text_group WHERE project = $1 AND id = $2`, ctx.Project, groupID).Scan(&createdAt)
+		if err == sql.ErrNoRows {
+			return ErrNotFound
+		}
+		if err != nil {
+			return errors.Wrapf(err, "Failed to query group. groupId=%s", groupID)
+		}
+
+		_, err = tx.ExecContext(ctx, saveGroupQuery, ctx.Project, newID, createdAt)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to save group. id=%s", newID)
+		}
+
+		_, err = tx.ExecContext(ctx, moveGroupMembersQuery, newID, ctx.Project, groupID)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to move group members. groupId=%s newId=%s", groupID, newID)
+		}
+
+		_, err = tx.ExecContext(ctx, deleteGroupQuery, ctx.Project, groupID)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to delete group. groupId=%s", groupID)
+		}
+
+		return membersChanged(ctx, tx, newID, time.Now())
+	})
+}
+
+func (r *groupRepo) Delete(ctx *context.Context, groupID string) error {
+	log.Debugw("groupRepo.Delete", "groupId", groupID, "ctx", ctx)
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, deleteGroupMembersQuery, ctx.Project, groupID)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to delete group members. groupId=%s", groupID)
+		}
+
+		res, err := tx.ExecContext(ctx, deleteGroupQuery, ctx.Project, groupID)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to delete group. groupId=%s", groupID)
+		}
+
+		return assertRowsAffected(res)
+	})
+}
+
+const addMissingTextToGroupQuery = `
+	INSERT INTO text_group_membership(project, text_key, group_id, created_at) 
+	SELECT $1, $2, $3, $4 WHERE NOT EXISTS (
+		SELECT 1 FROM text_group_membership WHERE project = $1 AND text_key = $2 AND group_id = $3
+	)`
+
+func (r *groupRepo) AddTextsToGroup(ctx *context.Context, groupID string, textKeys []string) error {
+	log.Debugw("groupRepo.AddTextsToGroup", "groupId", groupID, "textKeys", textKeys, "ctx", ctx)
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+		for _, key := range textKeys {
+			_, err := tx.ExecContext(ctx, addMissingTextToGroupQuery, ctx.Project, key, groupID, now)
+			if err != nil {
+				return errors.Wrapf(err, "Failed to add text to group. textKey=%s groupId=%s", key, groupID)
+			}
+		}
+
+		return membersChanged(ctx, tx, groupID, now)
+	})
+}
+
+const removeTextFromGroupQuery = `DELETE FROM text_group_membership WHERE project = $1 AND text_key = $2 AND group_id = $3`
+
+func (r *groupRepo) RemoveTextsFromGroup(ctx *context.Context, groupID string, textKeys []string) error {
+	log.Debugw("groupRepo.RemoveTextsFromGroup", "groupId", groupID, "textKeys", textKeys, "ctx", ctx)
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		for _, key := range textKeys {
+			_, err := tx.ExecContext(ctx, removeTextFromGroupQuery, ctx.Project, key, groupID)
+			if err != nil {
+				return errors.Wrapf(err, "Failed to remove text from group. textKey=%s groupId=%s", key, groupID)
+			}
+		}
+
+		return membersChanged(ctx, tx, groupID, time.Now())
+	})
+}
+
+const findMembersChangedQuery = `SELECT members_changed_at FROM text_group WHERE project = $1 AND id = $2`
+
+const findLastDeletedMemberQuery = `
+	SELECT h.created_at FROM text_history h
+	WHERE h.project = $1 AND h.action = $2
+	AND h.key IN (SELECT m.text_key FROM text_group_membership m WHERE m.project = $1 AND m.group_id = $3)
+	ORDER BY h.created_at DESC LIMIT 1`
+
+// LastRemoval finds when a text last stopped being part of a group, either by a change of the group's
+// members or by the deletion of a member text. The zero time is returned if neither has happened.
+func (r *groupRepo) LastRemoval(ctx *context.Context, groupID string) (time.Time, error) {
+	log.Debugw("groupRepo.LastRemoval", "groupId", groupID, "ctx", ctx)
+
+	var changedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, findMembersChangedQuery, ctx.Project, groupID).Scan(&changedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return time.Time{}, errors.Wrapf(err, "Failed to query group member change. groupId=%s", groupID)
+	}
+
+	var deletedAt time.Time
+	err = r.db.QueryRowContext(ctx, findLastDeletedMemberQuery, ctx.Project, models.Deleted, groupID).Scan(&deletedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return time.Time{}, errors.Wrapf(err, "Failed to query deleted group texts. groupId=%s", groupID)
+	}
+
+	if changedAt.Time.After(deletedAt) {
+		return changedAt.Time, nil
+	}
+
+	return deletedAt, nil
+}
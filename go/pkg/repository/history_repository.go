@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/pkg/errors"
+)
+
+// HistoryRepository storage interface for the revision history of translated texts.
+// Revisions are recorded by the TextRepository as texts are written.
+type HistoryRepository interface {
+	Find(ctx *context.Context, revisionID int) (models.TextRevision, error)
+	FindAll(ctx *context.Context, key, language string) ([]models.TextRevision, error)
+}
+
+// NewHistoryRepository creates a new HistoryRepository using the default implementation.
+func NewHistoryRepository(db *sql.DB) HistoryRepository {
+	return &historyRepo{
+		db: db,
+	}
+}
+
+type historyRepo struct {
+	db *sql.DB
+}
+
+const findRevisionQuery = `
+	SELECT id, key, language, value, action, changed_by, created_at 
+	FROM text_history WHERE project = $1 AND id = $2`
+
+func (r *historyRepo) Find(ctx *context.Context, revisionID int) (models.TextRevision, error) {
+	log.Debugw("historyRepo.Find", "revisionId", revisionID, "ctx", ctx)
+
+	var rev models.TextRevision
+	err := r.db.QueryRowContext(ctx, findRevisionQuery, ctx.Project, revisionID).Scan(
+		&rev.ID, &rev.Key, &rev.Language, &rev.Value, &rev.Action, &rev.ChangedBy, &rev.CreatedAt)
+	if err == sql.ErrNoRows {
+		return models.TextRevision{}, ErrNotFound
+	}
+
+	if err != nil {
+		return models.TextRevision{}, errors.Wrapf(err, "Failed to query text_history. id=%d", revisionID)
+	}
+
+	return rev, nil
+}
+
+const findRevisionsQuery = `
+	SELECT id, key, language, value, action, changed_by, created_at 
+	FROM text_history WHERE project = $1 AND key = $2 AND ($3 = '' OR language = $3)
+	ORDER BY id DESC`
+
+func (r *historyRepo) FindAll(ctx *context.Context, key, language string) ([]models.TextRevision, error) {
+	log.Debugw("historyRepo.FindAll", "key", key, "language", language, "ctx", ctx)
+	rows, err := r.db.QueryContext(ctx, findRevisionsQuery, ctx.Project, key, language)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to query text_history. key=%s language=%s", key, language)
+	}
+	defer rows.Close()
+
+	revisions := make([]models.TextRevision, 0)
+	var rev models.TextRevision
+	for rows.Next() {
+		err = rows.Scan(&rev.ID, &rev.Key, &rev.Language, &rev.Value, &rev.Action, &rev.ChangedBy, &rev.CreatedAt)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to scan text revision. key=%s language=%s", key, language)
+		}
+
+		revisions = append(revisions, rev)
+	}
+
+	return revisions, nil
+}
+
+const saveRevisionQuery = `
+	INSERT INTO text_history(project, key, language, value, action, changed_by, created_at) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+func saveRevision(ctx *context.Context, tx *sql.Tx, text models.TranslatedText, action string, changedAt time.Time) error {
+	_, err := tx.ExecContext(ctx, saveRevisionQuery, ctx.Project, text.Key, text.Language, text.Value, action, ctx.User, changedAt)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to insert text_history. key=%s language=%s", text.Key, text.Language)
+	}
+
+	return nil
+}
@@ -14,13 +14,18 @@ var log = logger.GetDefaultLogger("pkg/repository").Sugar()
 
 // Common errors
 var (
-	ErrNotFound = errors.New("not found")
+	ErrNotFound      = errors.New("not found")
+	ErrInUse         = errors.New("in use")
+	ErrAlreadyExists = errors.New("already exists")
+	ErrConflict      = errors.New("conflict")
 )
 
 // LanguageRepository storage interface for languages.
 type LanguageRepository interface {
 	Find(ctx *context.Context, language string) (models.Language, error)
-	Save(ctx *context.Context, language string) error
+	FindAll(ctx *context.Context) ([]models.Language, error)
+	Save(ctx *context.Context, language models.Language) error
+	Delete(ctx *context.Context, language string) error
 }
 
 // NewLanguageRepository creates a new LanguageRepository using the default implementation.
@@ -34,13 +39,14 @@ type languageRepo struct {
 	db *sql.DB
 }
 
-const findLanguagesQuery = `SELECT id, created_at FROM language WHERE id = $1`
+const findLanguagesQuery = `
+	SELECT id, english_name, native_name, direction, fallback, enabled, created_at 
+	FROM language WHERE id = $1`
 
 func (r *languageRepo) Find(ctx *context.Context, language string) (models.Language, error) {
 	log.Debugw("languageRepo.Find", "language", language, "ctx", ctx)
 
-	var lang models.Language
-	err := r.db.QueryRowContext(ctx, findLanguagesQuery, language).Scan(&lang.ID, &lang.CreatedAt)
+	lang, err := scanLanguage(r.db.QueryRowContext(ctx, findLanguagesQuery, language))
 	if err == sql.ErrNoRows {
 		return models.Language{}, ErrNotFound
 	}
@@ -52,14 +58,95 @@ func (r *languageRepo) Find(ctx *context.Context, language string) (models.Langu
 	return lang, nil
 }
 
-const saveLanguageQuery = `INSERT INTO language(id, created_at) VALUES ($1, $2)`
+const findAllLanguagesQuery = `
+	SELECT id, english_name, native_name, direction, fallback, enabled, created_at 
+	FROM language ORDER BY id`
+
+func (r *languageRepo) FindAll(ctx *context.Context) ([]models.Language, error) {
+	log.Debugw("languageRepo.FindAll", "ctx", ctx)
+	rows, err := r.db.QueryContext(ctx, findAllLanguagesQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query languages")
+	}
+	defer rows.Close()
+
+	languages := make([]models.Language, 0)
+	for rows.Next() {
+		lang, err := scanLanguage(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to scan language")
+		}
+
+		languages = append(languages, lang)
+	}
+
+	return languages, nil
+}
+
+const saveLanguageQuery = `
+	INSERT INTO language(id, english_name, native_name, direction, fallback, enabled, created_at) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+func (r *languageRepo) Save(ctx *context.Context, language models.Language) error {
+	log.Debugw("languageRepo.Save", "language", language.ID, "ctx", ctx)
 
-func (r *languageRepo) Save(ctx *context.Context, language string) error {
-	log.Debugw("languageRepo.Save", "language", language, "ctx", ctx)
-	_, err := r.db.ExecContext(ctx, saveLanguageQuery, language, time.Now())
+	direction := language.Direction
+	if direction == "" {
+		direction = models.LeftToRight
+	}
+
+	fallback := sql.NullString{String: language.Fallback, Valid: language.Fallback != ""}
+	_, err := r.db.ExecContext(ctx, saveLanguageQuery, language.ID, language.EnglishName,
+		language.NativeName, direction, fallback, language.Enabled, time.Now())
 	if err != nil {
-		return errors.Wrapf(err, "Failed to insert language. language=%s", language)
+		return errors.Wrapf(err, "Failed to insert language. language=%s", language.ID)
 	}
 
 	return nil
 }
+
+const countLanguageUsageQuery = `
+	SELECT 
+		(SELECT COUNT(*) FROM translated_text WHERE language = $1) + 
+		(SELECT COUNT(*) FROM text_draft WHERE language = $1) + 
+		(SELECT COUNT(*) FROM language WHERE fallback = $1)`
+
+const deleteLanguageQuery = `DELETE FROM language WHERE id = $1`
+
+func (r *languageRepo) Delete(ctx *context.Context, language string) error {
+	log.Debugw("languageRepo.Delete", "language", language, "ctx", ctx)
+
+	// The usage check and the delete share a transaction so that a text, draft or fallback
+	// added in between cannot be left pointing at a deleted language.
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var usages int
+		err := tx.QueryRowContext(ctx, countLanguageUsageQuery, language).Scan(&usages)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to count language usages. language=%s", language)
+		}
+
+		if usages > 0 {
+			return ErrInUse
+		}
+
+		res, err := tx.ExecContext(ctx, deleteLanguageQuery, language)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to delete language. language=%s", language)
+		}
+
+		return assertRowsAffected(res)
+	})
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanLanguage(row scanner) (models.Language, error) {
+	var lang models.Language
+	var fallback sql.NullString
+	err := row.Scan(&lang.ID, &lang.EnglishName, &lang.NativeName, &lang.Direction, &fallback, &lang.Enabled, &lang.CreatedAt)
+	lang.Fallback = fallback.String
+
+	return lang, err
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/pkg/errors"
+)
+
+// ProjectRepository storage interface for projects.
+type ProjectRepository interface {
+	Find(ctx *context.Context, projectID string) (models.Project, error)
+	FindAll(ctx *context.Context) ([]models.Project, error)
+	Save(ctx *context.Context, project models.Project) error
+	Delete(ctx *context.Context, projectID string) error
+}
+
+// NewProjectRepository creates a new ProjectRepository using the default implementation.
+func NewProjectRepository(db *sql.DB) ProjectRepository {
+	return &projectRepo{
+		db: db,
+	}
+}
+
+type projectRepo struct {
+	db *sql.DB
+}
+
+const findProjectQuery = `SELECT id, description, created_at FROM project WHERE id = $1`
+
+func (r *projectRepo) Find(ctx *context.Context, projectID string) (models.Project, error) {
+	log.Debugw("projectRepo.Find", "projectId", projectID, "ctx", ctx)
+
+	var p models.Project
+	err := r.db.QueryRowContext(ctx, findProjectQuery, projectID).Scan(&p.ID, &p.Description, &p.CreatedAt)
+	if err == sql.ErrNoRows {
+		return models.Project{}, ErrNotFound
+	}
+
+	if err != nil {
+		return models.Project{}, errors.Wrapf(err, "Failed to query project. projectId=%s", projectID)
+	}
+
+	return p, nil
+}
+
+const findAllProjectsQuery = `SELECT id, description, created_at FROM project ORDER BY id`
+
+func (r *projectRepo) FindAll(ctx *context.Context) ([]models.Project, error) {
+	log.Debugw("projectRepo.FindAll", "ctx", ctx)
+	rows, err := r.db.QueryContext(ctx, findAllProjectsQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query projects")
+	}
+	defer rows.Close()
+
+	projects := make([]models.Project, 0)
+	var p models.Project
+	for rows.Next() {
+		err = rows.Scan(&p.ID, &p.Description, &p.CreatedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to scan project")
+		}
+
+		projects = append(projects, p)
+	}
+
+	return projects, nil
+}
+
+const saveProjectQuery = `INSERT INTO project(id, description, created_at) VALUES ($1, $2, $3)`
+
+func (r *projectRepo) Save(ctx *context.Context, project models.Project) error {
+	log.Debugw("projectRepo.Save", "projectId", project.ID, "ctx", ctx)
+	_, err := r.db.ExecContext(ctx, saveProjectQuery, project.ID, project.Description, time.Now())
+	if err != nil {
+		return errors.Wrapf(err, "Failed to save project. id=%s", project.ID)
+	}
+
+	return nil
+}
+
+const countProjectUsageQuery = `
+	SELECT 
+		(SELECT COUNT(*) FROM translated_text WHERE project = $1) + 
+		(SELECT COUNT(*) FROM text_draft WHERE project = $1) + 
+		(SELECT COUNT(*) FROM text_group WHERE project = $1) + 
+		(SELECT COUNT(*) FROM text_group_membership WHERE project = $1) + 
+		(SELECT COUNT(*) FROM text_release WHERE project = $1) + 
+		(SELECT COUNT(*) FROM release_text WHERE project = $1) + 
+		(SELECT COUNT(*) FROM release_group_membership WHERE project = $1) + 
+		(SELECT COUNT(*) FROM gettext_entry WHERE project = $1) + 
+		(SELECT COUNT(*) FROM gettext_header WHERE project = $1) + 
+		(SELECT COUNT(*) FROM webhook WHERE project = $1)`
+
+const deleteProjectHistoryQuery = `DELETE FROM text_history WHERE project = $1`
+
+const deleteProjectQuery = `DELETE FROM project WHERE id = $1`
+
+// Delete deletes a project along with the history of its removed texts, returns ErrInUse
+// if the project still holds texts, groups, releases, gettext entries or webhooks.
+func (r *projectRepo) Delete(ctx *context.Context, projectID string) error {
+	log.Debugw("projectRepo.Delete", "projectId", projectID, "ctx", ctx)
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var usage int
+		err := tx.QueryRowContext(ctx, countProjectUsageQuery, projectID).Scan(&usage)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to count project usage. projectId=%s", projectID)
+		}
+
+		if usage > 0 {
+			return ErrInUse
+		}
+
+		_, err = tx.ExecContext(ctx, deleteProjectHistoryQuery, projectID)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to delete project history. projectId=%s", projectID)
+		}
+
+		res, err := tx.ExecContext(ctx, deleteProjectQuery, projectID)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to delete project. projectId=%s", projectID)
+		}
+
+		return assertRowsAffected(res)
+	})
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/pkg/errors"
+)
+
+// ReleaseRepository storage interface for immutable releases of texts.
+type ReleaseRepository interface {
+	Find(ctx *context.Context, releaseID string) (models.Release, error)
+	FindAll(ctx *context.Context) ([]models.Release, error)
+	FindCurrent(ctx *context.Context) (models.Release, error)
+	FindText(ctx *context.Context, releaseID, key, language string) (models.TranslatedText, error)
+	FindGroupTexts(ctx *context.Context, releaseID, groupID, language string) ([]models.TranslatedText, error)
+	Save(ctx *context.Context, release models.Release, texts []models.TranslatedText, groups map[string][]string) error
+	SetCurrent(ctx *context.Context, releaseID string) error
+	LastCurrentChange(ctx *context.Context) (time.Time, error)
+}
+
+// NewReleaseRepository creates a new ReleaseRepository using the default implementation.
+func NewReleaseRepository(db *sql.DB) ReleaseRepository {
+	return &releaseRepo{
+		db: db,
+	}
+}
+
+type releaseRepo struct {
+	db *sql.DB
+}
+
+const findReleaseQuery = `
+	SELECT r.id, r.description, r.is_current, r.created_at, (SELECT COUNT(*) FROM release_text rt WHERE rt.project = r.project AND rt.release_id = r.id)
+	FROM text_release r WHERE r.project = $1 AND r.id = $2`
+
+func (r *releaseRepo) Find(ctx *context.Context, releaseID string) (models.Release, error) {
+	log.Debugw("releaseRepo.Find", "releaseId", releaseID, "ctx", ctx)
+	return r.findOne(ctx, findReleaseQuery, releaseID)
+}
+
+const findCurrentReleaseQuery = `
+	SELECT r.id, r.description, r.is_current, r.created_at, (SELECT COUNT(*) FROM release_text rt WHERE rt.project = r.project AND rt.release_id = r.id)
+	FROM text_release r WHERE r.project = $1 AND r.is_current = $2`
+
+func (r *releaseRepo) FindCurrent(ctx *context.Context) (models.Release, error) {
+	log.Debugw("releaseRepo.FindCurrent", "ctx", ctx)
+	return r.findOne(ctx, findCurrentReleaseQuery, true)
+}
+
+func (r *releaseRepo) findOne(ctx *context.Context, query string, arg interface{}) (models.Release, error) {
+	var rel models.Release
+	err := r.db.QueryRowContext(ctx, query, ctx.Project, arg).Scan(&rel.ID, &rel.Description, &rel.Current, &rel.CreatedAt, &rel.TextCount)
+	if err == sql.ErrNoRows {
+		return models.Release{}, ErrNotFound
+	}
+
+	if err != nil {
+		return models.Release{}, errors.Wrapf(err, "Failed to query release. arg=%v", arg)
+	}
+
+	return rel, nil
+}
+
+const findAllReleasesQuery = `
+	SELECT r.id, r.description, r.is_current, r.created_at, (SELECT COUNT(*) FROM release_text rt WHERE rt.project = r.project AND rt.release_id = r.id)
+	FROM text_release r WHERE r.project = $1 ORDER BY r.created_at DESC, r.id`
+
+func (r *releaseRepo) FindAll(ctx *context.Context) ([]models.Release, error) {
+	log.Debugw("releaseRepo.FindAll", "ctx", ctx)
+	rows, err := r.db.QueryContext(ctx, findAllReleasesQuery, ctx.Project)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query releases")
+	}
+	defer rows.Close()
+
+	releases := make([]models.Release, 0)
+	var rel models.Release
+	for rows.Next() {
+		err = rows.Scan(&rel.ID, &rel.Description, &rel.Current, &rel.CreatedAt, &rel.TextCount)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to scan release")
+		}
+
+		releases = append(releases, rel)
+	}
+
+	return releases, nil
+}
+
+const findReleaseTextQuery = `
+	SELECT id, key, language, value, created_at, updated_at 
+	FROM release_text WHERE project = $1 AND release_id = $2 AND key = $3 AND language = $4`
+
+func (r *releaseRepo) FindText(ctx *context.Context, releaseID, key, language string) (models.TranslatedText, error) {
+	log.Debugw("releaseRepo.FindText", "releaseId", releaseID, "key", key, "language", language, "ctx", ctx)
+
+	var t models.TranslatedText
+	err := r.db.QueryRowContext(ctx, findReleaseTextQuery, ctx.Project, releaseID, key, language).Scan(
+		&t.ID, &t.Key, &t.Language, &t.Value, &t.CreatedAt, &t.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return models.TranslatedText{}, ErrNotFound
+	}
+
+	if err != nil {
+		return models.TranslatedText{}, errors.Wrapf(err, "Failed to query release_text. releaseId=%s key=%s", releaseID, key)
+	}
+
+	return t, nil
+}
+
+const findReleaseGroupTextsQuery = `
+	SELECT t.id, t.key, t.language, t.value, t.created_at, t.updated_at 
+	FROM release_text t
+	INNER JOIN release_group_membership rgm ON t.project = rgm.project AND t.release_id = rgm.release_id AND t.key = rgm.text_key
+	WHERE t.project = $1 AND t.release_id = $2 AND rgm.group_id = $3 AND t.language = $4`
+
+func (r *releaseRepo) FindGroupTexts(ctx *context.Context, releaseID, groupID, language string) ([]models.TranslatedText, error) {
+	log.Debugw("releaseRepo.FindGroupTexts", "releaseId", releaseID, "groupId", groupID, "language", language, "ctx", ctx)
+	rows, err := r.db.QueryContext(ctx, findReleaseGroupTextsQuery, ctx.Project, releaseID, groupID, language)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to query release group texts. releaseId=%s groupId=%s", releaseID, groupID)
+	}
+	defer rows.Close()
+
+	return scanTexts(rows)
+}
+
+const saveReleaseQuery = `INSERT INTO text_release(project, id, description, is_current, created_at) VALUES ($1, $2, $3, $4, $5)`
+
+const saveReleaseTextQuery = `
+	INSERT INTO release_text(project, release_id, key, language, value, created_at, updated_at) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+const saveReleaseMembershipQuery = `
+	INSERT INTO release_group_membership(project, release_id, group_id, text_key) VALUES ($1, $2, $3, $4)`
+
+func (r *releaseRepo) Save(ctx *context.Context, release models.Release, texts []models.TranslatedText, groups map[string][]string) error {
+	log.Debugw("releaseRepo.Save", "releaseId", release.ID, "texts", len(texts), "groups", len(groups), "ctx", ctx)
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, saveReleaseQuery, ctx.Project, release.ID, release.Description, false, time.Now())
+		if err != nil {
+			return errors.Wrapf(err, "Failed to insert release. id=%s", release.ID)
+		}
+
+		for _, t := range texts {
+			_, err = tx.ExecContext(ctx, saveReleaseTextQuery, ctx.Project, release.ID, t.Key, t.Language, t.Value, t.CreatedAt, t.UpdatedAt)
+			if err != nil {
+				return errors.Wrapf(err, "Failed to insert release_text. releaseId=%s key=%s", release.ID, t.Key)
+			}
+		}
+
+		for groupID, keys := range groups {
+			for _, key := range keys {
+				_, err = tx.ExecContext(ctx, saveReleaseMembershipQuery, ctx.Project, release.ID, groupID, key)
+				if err != nil {
+					return errors.Wrapf(err, "Failed to insert release_group_membership. releaseId=%s groupId=%s", release.ID, groupID)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+const clearCurrentReleaseQuery = `UPDATE text_release SET is_current = $1, current_changed_at = $2 WHERE project = $3 AND is_current = $4`
+const setCurrentReleaseQuery = `UPDATE text_release SET is_current = $1, current_changed_at = $2 WHERE project = $3 AND id = $4`
+
+// SetCurrent marks a release as current, an empty release id means no release is current.
+// The time is recorded on both the previous and the new current release.
+func (r *releaseRepo) SetCurrent(ctx *context.Context, releaseID string) error {
+	log.Debugw("releaseRepo.SetCurrent", "releaseId", releaseID, "ctx", ctx)
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+		_, err := tx.ExecContext(ctx, clearCurrentReleaseQuery, false, now, ctx.Project, true)
+		if err != nil {
+			return errors.Wrap(err, "Failed to clear current release")
+		}
+
+		if releaseID == "" {
+			return nil
+		}
+
+		res, err := tx.ExecContext(ctx, setCurrentReleaseQuery, true, now, ctx.Project, releaseID)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to set current release. releaseId=%s", releaseID)
+		}
+
+		return assertRowsAffected(res)
+	})
+}
+
+const findLastCurrentChangeQuery = `
+	SELECT current_changed_at FROM text_release
+	WHERE project = $1 AND current_changed_at IS NOT NULL
+	ORDER BY current_changed_at DESC LIMIT 1`
+
+// LastCurrentChange finds when a release last became or stopped being current, or the zero time if none has.
+func (r *releaseRepo) LastCurrentChange(ctx *context.Context) (time.Time, error) {
+	log.Debugw("releaseRepo.LastCurrentChange", "ctx", ctx)
+
+	var changedAt time.Time
+	err := r.db.QueryRowContext(ctx, findLastCurrentChangeQuery, ctx.Project).Scan(&changedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return time.Time{}, errors.Wrap(err, "Failed to query current release change")
+	}
+
+	return changedAt, nil
+}
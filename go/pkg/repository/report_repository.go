@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/pkg/errors"
+)
+
+// ReportRepository storage interface for reports on the state of translations.
+type ReportRepository interface {
+	FindTextLanguages(ctx *context.Context, groupID string) (map[string][]string, error)
+	CountKeys(ctx *context.Context) (map[string]int, error)
+	CountTranslated(ctx *context.Context) (map[string][]models.LanguageStats, error)
+}
+
+// NewReportRepository creates a new ReportRepository using the default implementation.
+func NewReportRepository(db *sql.DB) ReportRepository {
+	return &reportRepo{
+		db: db,
+	}
+}
+
+type reportRepo struct {
+	db *sql.DB
+}
+
+const findTextLanguagesQuery = `
+	SELECT t.key, t.language FROM translated_text t
+	WHERE t.project = $1
+	AND ($2 = '' OR t.key IN (SELECT m.text_key FROM text_group_membership m WHERE m.project = $1 AND m.group_id = $2))
+	ORDER BY t.key, t.language`
+
+// FindTextLanguages finds the languages each text key is translated to,
+// restricted to the members of a group unless the group id is empty.
+func (r *reportRepo) FindTextLanguages(ctx *context.Context, groupID string) (map[string][]string, error) {
+	log.Debugw("reportRepo.FindTextLanguages", "groupId", groupID, "ctx", ctx)
+	rows, err := r.db.QueryContext(ctx, findTextLanguagesQuery, ctx.Project, groupID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to query text languages. groupId=%s", groupID)
+	}
+	defer rows.Close()
+
+	languages := make(map[string][]string)
+	var key, language string
+	for rows.Next() {
+		err = rows.Scan(&key, &language)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to scan text language")
+		}
+
+		languages[key] = append(languages[key], language)
+	}
+
+	return languages, nil
+}
+
+const countKeysQuery = `
+	SELECT '', COUNT(DISTINCT t.key) FROM translated_text t WHERE t.project = $1
+	UNION ALL
+	SELECT g.id, COUNT(m.text_key) FROM text_group g
+	LEFT JOIN text_group_membership m ON m.project = g.project AND m.group_id = g.id
+	WHERE g.project = $1
+	GROUP BY g.id`
+
+// CountKeys counts the text keys of each group, the keys of all texts are counted under an empty group id.
+func (r *reportRepo) CountKeys(ctx *context.Context) (map[string]int, error) {
+	log.Debugw("reportRepo.CountKeys", "ctx", ctx)
+	rows, err := r.db.QueryContext(ctx, countKeysQuery, ctx.Project)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to count text keys")
+	}
+	defer rows.Close()
+
+	keys := make(map[string]int)
+	var groupID string
+	var count int
+	for rows.Next() {
+		err = rows.Scan(&groupID, &count)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to scan text key count")
+		}
+
+		keys[groupID] = count
+	}
+
+	return keys, nil
+}
+
+const countTranslatedQuery = `
+	SELECT '', t.language, COUNT(*), MAX(t.updated_at) FROM translated_text t
+	WHERE t.project = $1
+	GROUP BY t.language
+	UNION ALL
+	SELECT m.group_id, t.language, COUNT(*), MAX(t.updated_at) FROM text_group_membership m
+	INNER JOIN translated_text t ON t.project = m.project AND t.key = m.text_key
+	WHERE m.project = $1
+	GROUP BY m.group_id, t.language`
+
+// CountTranslated counts the texts of each group translated to each language along with when a
+// text in the language was last updated, all texts are counted under an empty group id.
+// Only the Language, Translated and LastUpdated fields of the returned stats are set.
+func (r *reportRepo) CountTranslated(ctx *context.Context) (map[string][]models.LanguageStats, error) {
+	log.Debugw("reportRepo.CountTranslated", "ctx", ctx)
+	rows, err := r.db.QueryContext(ctx, countTranslatedQuery, ctx.Project)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to count translated texts")
+	}
+	defer rows.Close()
+
+	translated := make(map[string][]models.LanguageStats)
+	var groupID string
+	for rows.Next() {
+		var ls models.LanguageStats
+		var lastUpdated aggregatedTime
+		err = rows.Scan(&groupID, &ls.Language, &ls.Translated, &lastUpdated)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to scan translated text count")
+		}
+
+		if lastUpdated.Valid {
+			ls.LastUpdated = &lastUpdated.Time
+		}
+		translated[groupID] = append(translated[groupID], ls)
+	}
+
+	return translated, nil
+}
+
+// aggregatedTimeFormats formats of times returned as text by aggregate functions.
+var aggregatedTimeFormats = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+}
+
+// aggregatedTime scans the result of an aggregate function over a time column, which sqlite
+// and mysql return as text rather than as a time since the column type is not kept.
+type aggregatedTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+func (t *aggregatedTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		t.Time, t.Valid = time.Time{}, false
+		return nil
+	case time.Time:
+		t.Time, t.Valid = v, true
+		return nil
+	case []byte:
+		return t.parse(string(v))
+	case string:
+		return t.parse(v)
+	default:
+		return fmt.Errorf("Unsupported time value: %T", value)
+	}
+}
+
+func (t *aggregatedTime) parse(value string) error {
+	value = strings.TrimSuffix(value, "Z")
+	for _, format := range aggregatedTimeFormats {
+		parsed, err := time.Parse(format, value)
+		if err == nil {
+			t.Time, t.Valid = parsed, true
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Unsupported time format: %s", value)
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+	"unicode"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/pkg/errors"
+)
+
+// SearchRepository storage interface for searching translated texts.
+// Keys are matched by substring and values by the prefixes of their words.
+type SearchRepository interface {
+	Search(ctx *context.Context, query, language, groupID string, offset, limit int) ([]models.TranslatedText, error)
+}
+
+// NewSearchRepository creates a new SearchRepository using the full-text search of the database driver.
+func NewSearchRepository(db *sql.DB, driver string) SearchRepository {
+	switch driver {
+	case "postgres":
+		return &searchRepo{db: db, query: postgresSearchQuery, matchTerms: postgresMatchTerms}
+	case "mysql":
+		return &searchRepo{db: db, query: mysqlSearchQuery, matchTerms: mysqlMatchTerms}
+	default:
+		return &searchRepo{db: db, query: sqliteSearchQuery, matchTerms: sqliteMatchTerms}
+	}
+}
+
+type searchRepo struct {
+	db         *sql.DB
+	query      string
+	matchTerms func(terms []string) string
+}
+
+const sqliteSearchQuery = `
+	SELECT t.id, t.key, t.language, t.value, t.created_at, t.updated_at FROM translated_text t
+	WHERE t.project = $1
+	AND ($2 = '' OR t.language = $2)
+	AND ($3 = '' OR t.key IN (SELECT m.text_key FROM text_group_membership m WHERE m.project = $1 AND m.group_id = $3))
+	AND (t.key LIKE $4 ESCAPE '\' OR t.id IN (SELECT docid FROM text_search WHERE text_search MATCH $5))
+	ORDER BY t.key, t.language
+	LIMIT $6 OFFSET $7`
+
+const postgresSearchQuery = `
+	SELECT t.id, t.key, t.language, t.value, t.created_at, t.updated_at FROM translated_text t
+	WHERE t.project = $1
+	AND ($2 = '' OR t.language = $2)
+	AND ($3 = '' OR t.key IN (SELECT m.text_key FROM text_group_membership m WHERE m.project = $1 AND m.group_id = $3))
+	AND (t.key ILIKE $4 ESCAPE '\' OR to_tsvector('simple', t.value) @@ to_tsquery('simple', $5))
+	ORDER BY t.key, t.language
+	LIMIT $6 OFFSET $7`
+
+const mysqlSearchQuery = `
+	SELECT t.id, t.key, t.language, t.value, t.created_at, t.updated_at FROM translated_text t
+	WHERE t.project = $1
+	AND ($2 = '' OR t.language = $2)
+	AND ($3 = '' OR t.key IN (SELECT m.text_key FROM text_group_membership m WHERE m.project = $1 AND m.group_id = $3))
+	AND (t.key LIKE $4 ESCAPE '\\' OR MATCH(t.value) AGAINST ($5 IN BOOLEAN MODE))
+	ORDER BY t.key, t.language
+	LIMIT $6 OFFSET $7`
+
+func (r *searchRepo) Search(ctx *context.Context, query, language, groupID string, offset, limit int) ([]models.TranslatedText, error) {
+	log.Debugw("searchRepo.Search", "query", query, "language", language, "groupId", groupID, "ctx", ctx)
+	keyPattern := "%" + escapeLike(query) + "%"
+	match := r.matchTerms(searchTerms(query))
+
+	rows, err := r.db.QueryContext(ctx, r.query, ctx.Project, language, groupID, keyPattern, match, limit, offset)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to search translated_text. query=%s", query)
+	}
+	defer rows.Close()
+
+	return scanTexts(rows)
+}
+
+// searchTerms splits a query into words of letters and digits,
+// which leaves no operators of the full-text query syntax.
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func sqliteMatchTerms(terms []string) string {
+	return joinTerms(terms, "", "*", " ")
+}
+
+func postgresMatchTerms(terms []string) string {
+	return joinTerms(terms, "", ":*", " & ")
+}
+
+func mysqlMatchTerms(terms []string) string {
+	return joinTerms(terms, "+", "*", " ")
+}
+
+func joinTerms(terms []string, prefix, suffix, sep string) string {
+	matches := make([]string, len(terms))
+	for i, term := range terms {
+		matches[i] = prefix + term + suffix
+	}
+
+	return strings.Join(matches, sep)
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -12,7 +12,14 @@ import (
 // TextRepository storage interface for translated texts.
 type TextRepository interface {
 	Find(ctx *context.Context, key, language string) (models.TranslatedText, error)
+	FindAll(ctx *context.Context) ([]models.TranslatedText, error)
+	FindPage(ctx *context.Context, filter models.TextFilter, limit int) ([]models.TranslatedText, error)
 	Save(ctx *context.Context, text models.TranslatedText) error
+	Update(ctx *context.Context, text models.TranslatedText) error
+	Upsert(ctx *context.Context, text models.TranslatedText) (string, error)
+	UpsertAll(ctx *context.Context, texts []models.TranslatedText, conditions []models.TextCondition) ([]string, error)
+	Delete(ctx *context.Context, key, language string) error
+	LastDeletion(ctx *context.Context, key string) (time.Time, error)
 }
 
 // NewTextRepository creates a new TextRepository using the default implementation.
@@ -26,13 +33,13 @@ type textRepo struct {
 	db *sql.DB
 }
 
-const findTextQuery = `SELECT id, key, language, value, created_at, updated_at FROM translated_text WHERE key = $1 AND language = $2`
+const findTextQuery = `SELECT id, key, language, value, created_at, updated_at FROM translated_text WHERE project = $1 AND key = $2 AND language = $3`
 
 func (r *textRepo) Find(ctx *context.Context, key, language string) (models.TranslatedText, error) {
 	log.Debugw("textRepo.Find", "key", key, "language", language, "ctx", ctx)
 
 	var t models.TranslatedText
-	err := r.db.QueryRowContext(ctx, findTextQuery, key, language).Scan(&t.ID, &t.Key, &t.Language, &t.Value, &t.CreatedAt, &t.UpdatedAt)
+	err := r.db.QueryRowContext(ctx, findTextQuery, ctx.Project, key, language).Scan(&t.ID, &t.Key, &t.Language, &t.Value, &t.CreatedAt, &t.UpdatedAt)
 	if err == sql.ErrNoRows {
 		return models.TranslatedText{}, ErrNotFound
 	}
@@ -44,16 +51,245 @@ func (r *textRepo) Find(ctx *context.Context, key, language string) (models.Tran
 	return t, nil
 }
 
-const saveTextQuery = `INSERT INTO translated_text(key, language, value, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)`
+const findAllTextsQuery = `SELECT id, key, language, value, created_at, updated_at FROM translated_text WHERE project = $1 ORDER BY key, language`
+
+func (r *textRepo) FindAll(ctx *context.Context) ([]models.TranslatedText, error) {
+	log.Debugw("textRepo.FindAll", "ctx", ctx)
+	rows, err := r.db.QueryContext(ctx, findAllTextsQuery, ctx.Project)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query translated_text")
+	}
+	defer rows.Close()
+
+	return scanTexts(rows)
+}
+
+const findTextPageQuery = `
+	SELECT t.id, t.key, t.language, t.value, t.created_at, t.updated_at FROM translated_text t
+	WHERE t.project = $1
+	AND ($2 = '' OR t.language = $2)
+	AND ($3 = '' OR t.key IN (SELECT m.text_key FROM text_group_membership m WHERE m.project = $1 AND m.group_id = $3))
+	AND t.key LIKE $4 ESCAPE '\'
+	AND t.updated_at >= $5
+	AND ($6 = '' OR t.key > $6 OR (t.key = $6 AND t.language > $7))
+	ORDER BY t.key, t.language
+	LIMIT $8`
+
+// FindPage finds up to limit texts matching a filter, ordered by key and language.
+func (r *textRepo) FindPage(ctx *context.Context, filter models.TextFilter, limit int) ([]models.TranslatedText, error) {
+	log.Debugw("textRepo.FindPage", "filter", filter, "limit", limit, "ctx", ctx)
+	prefix := escapeLike(filter.Prefix) + "%"
+	// Timestamps are stored in local time, which keeps textual comparisons in sqlite consistent.
+	updatedSince := filter.UpdatedSince.Local()
+
+	rows, err := r.db.QueryContext(ctx, findTextPageQuery, ctx.Project, filter.Language, filter.Group, prefix,
+		updatedSince, filter.AfterKey, filter.AfterLanguage, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query page of translated_text")
+	}
+	defer rows.Close()
+
+	return scanTexts(rows)
+}
+
+func scanTexts(rows *sql.Rows) ([]models.TranslatedText, error) {
+	texts := make([]models.TranslatedText, 0)
+	var t models.TranslatedText
+	for rows.Next() {
+		err := rows.Scan(&t.ID, &t.Key, &t.Language, &t.Value, &t.CreatedAt, &t.UpdatedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to scan text")
+		}
+
+		texts = append(texts, t)
+	}
+
+	return texts, nil
+}
+
+const saveTextQuery = `INSERT INTO translated_text(project, key, language, value, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)`
 
 func (r *textRepo) Save(ctx *context.Context, text models.TranslatedText) error {
 	log.Debugw("textRepo.Save", "key", text.Key, "language", text.Language, "ctx", ctx)
 
-	now := time.Now()
-	_, err := r.db.ExecContext(ctx, saveTextQuery, text.Key, text.Language, text.Value, now, now)
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+		_, err := tx.ExecContext(ctx, saveTextQuery, ctx.Project, text.Key, text.Language, text.Value, now, now)
+		if err != nil && isUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+		if err != nil {
+			return errors.Wrapf(err, "Failed to insert translated_text. key=%s", text.Key)
+		}
+
+		return saveRevision(ctx, tx, text, models.Created, now)
+	})
+}
+
+const updateTextQuery = `UPDATE translated_text SET value = $1, updated_at = $2 WHERE project = $3 AND key = $4 AND language = $5`
+
+func (r *textRepo) Update(ctx *context.Context, text models.TranslatedText) error {
+	log.Debugw("textRepo.Update", "key", text.Key, "language", text.Language, "ctx", ctx)
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+		res, err := tx.ExecContext(ctx, updateTextQuery, text.Value, now, ctx.Project, text.Key, text.Language)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to update translated_text. key=%s language=%s", text.Key, text.Language)
+		}
+
+		err = assertRowsAffected(res)
+		if err != nil {
+			return err
+		}
+
+		return saveRevision(ctx, tx, text, models.Updated, now)
+	})
+}
+
+const upsertTextQuery = `
+	INSERT INTO translated_text(project, key, language, value, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT(project, key, language) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at`
+
+// Upsert creates or updates a text and returns whether it was created or updated.
+func (r *textRepo) Upsert(ctx *context.Context, text models.TranslatedText) (string, error) {
+	log.Debugw("textRepo.Upsert", "key", text.Key, "language", text.Language, "ctx", ctx)
+
+	var action string
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var err error
+		action, err = upsertText(ctx, tx, text, time.Now())
+		return err
+	})
+
+	return action, err
+}
+
+// UpsertAll upserts all texts in a single transaction, so either all or none of them are stored.
+// No text is stored and ErrConflict is returned unless all conditions hold. The action recorded
+// for each text is returned.
+func (r *textRepo) UpsertAll(ctx *context.Context, texts []models.TranslatedText, conditions []models.TextCondition) ([]string, error) {
+	log.Debugw("textRepo.UpsertAll", "texts", len(texts), "conditions", len(conditions), "ctx", ctx)
+
+	actions := make([]string, 0, len(texts))
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		for _, cond := range conditions {
+			err := checkCondition(ctx, tx, cond)
+			if err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		for _, text := range texts {
+			action, err := upsertText(ctx, tx, text, now)
+			if err != nil {
+				return err
+			}
+			actions = append(actions, action)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
+
+// lockTextQuery updates a text without changing it, which locks it until the transaction ends.
+const lockTextQuery = `UPDATE translated_text SET value = value WHERE project = $1 AND key = $2 AND language = $3`
+
+const findTextValueQuery = `SELECT value, updated_at FROM translated_text WHERE project = $1 AND key = $2 AND language = $3`
+
+// checkCondition returns ErrConflict unless a text is in the state of the condition. The text is
+// locked before it is checked so that it cannot be changed by others before the transaction ends.
+func checkCondition(ctx *context.Context, tx *sql.Tx, cond models.TextCondition) error {
+	_, err := tx.ExecContext(ctx, lockTextQuery, ctx.Project, cond.Key, cond.Language)
 	if err != nil {
-		return errors.Wrapf(err, "Failed to insert translated_text. key=%s", text.Key)
+		return errors.Wrapf(err, "Failed to lock translated_text. key=%s language=%s", cond.Key, cond.Language)
+	}
+
+	var value string
+	var updatedAt time.Time
+	err = tx.QueryRowContext(ctx, findTextValueQuery, ctx.Project, cond.Key, cond.Language).Scan(&value, &updatedAt)
+	if err == sql.ErrNoRows {
+		if cond.Absent {
+			return nil
+		}
+		return ErrConflict
+	}
+	if err != nil {
+		return errors.Wrapf(err, "Failed to query translated_text. key=%s language=%s", cond.Key, cond.Language)
+	}
+
+	if cond.Absent || (cond.Value != "" && value != cond.Value) {
+		return ErrConflict
+	}
+	if !cond.UpdatedBefore.IsZero() && updatedAt.After(cond.UpdatedBefore) {
+		return ErrConflict
 	}
 
 	return nil
 }
+
+const countTextQuery = `SELECT COUNT(*) FROM translated_text WHERE project = $1 AND key = $2 AND language = $3`
+
+// upsertText upserts a text and records the revision as created if no text existed before, and as updated otherwise.
+// The recorded action is returned.
+func upsertText(ctx *context.Context, tx *sql.Tx, text models.TranslatedText, now time.Time) (string, error) {
+	var count int
+	err := tx.QueryRowContext(ctx, countTextQuery, ctx.Project, text.Key, text.Language).Scan(&count)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to count translated_text. key=%s language=%s", text.Key, text.Language)
+	}
+
+	_, err = tx.ExecContext(ctx, upsertTextQuery, ctx.Project, text.Key, text.Language, text.Value, now, now)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to upsert translated_text. key=%s language=%s", text.Key, text.Language)
+	}
+
+	action := models.Updated
+	if count == 0 {
+		action = models.Created
+	}
+
+	return action, saveRevision(ctx, tx, text, action, now)
+}
+
+const deleteTextQuery = `DELETE FROM translated_text WHERE project = $1 AND key = $2 AND language = $3`
+
+func (r *textRepo) Delete(ctx *context.Context, key, language string) error {
+	log.Debugw("textRepo.Delete", "key", key, "language", language, "ctx", ctx)
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, deleteTextQuery, ctx.Project, key, language)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to delete translated_text. key=%s language=%s", key, language)
+		}
+
+		err = assertRowsAffected(res)
+		if err != nil {
+			return err
+		}
+
+		deleted := models.TranslatedText{Key: key, Language: language}
+		return saveRevision(ctx, tx, deleted, models.Deleted, time.Now())
+	})
+}
+
+const findLastDeletedTextQuery = `SELECT created_at FROM text_history WHERE project = $1 AND key = $2 AND action = $3 ORDER BY created_at DESC LIMIT 1`
+
+// LastDeletion finds when a text of the key was last deleted in any language, or the zero time if none has been.
+func (r *textRepo) LastDeletion(ctx *context.Context, key string) (time.Time, error) {
+	log.Debugw("textRepo.LastDeletion", "key", key, "ctx", ctx)
+
+	var deletedAt time.Time
+	err := r.db.QueryRowContext(ctx, findLastDeletedTextQuery, ctx.Project, key, models.Deleted).Scan(&deletedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return time.Time{}, errors.Wrapf(err, "Failed to query deleted texts. key=%s", key)
+	}
+
+	return deletedAt, nil
+}
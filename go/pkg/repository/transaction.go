@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/dbutil"
+	"github.com/pkg/errors"
+)
+
+// withTx runs fn in a transaction which is committed if fn succeeds and rolled back otherwise.
+func withTx(ctx *context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "Failed to start transaction")
+	}
+
+	err = fn(tx)
+	if err != nil {
+		dbutil.Rollback(tx)
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return errors.Wrap(err, "Failed to commit transaction")
+	}
+
+	return nil
+}
+
+// assertRowsAffected returns ErrNotFound if a statement did not affect any rows.
+func assertRowsAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get number of affected rows")
+	}
+
+	if n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// isUniqueViolation checks if an error was caused by a unique constraint, as reported by the
+// sqlite, postgres and mysql drivers, without depending on the driver in use.
+func isUniqueViolation(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "UNIQUE constraint failed") ||
+		strings.Contains(msg, "duplicate key value violates unique constraint") ||
+		strings.Contains(msg, "Error 1062")
+}
@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/pkg/errors"
+)
+
+// WebhookRepository storage interface for webhook subscriptions and their deliveries.
+type WebhookRepository interface {
+	Find(ctx *context.Context, webhookID string) (models.Webhook, error)
+	FindAll(ctx *context.Context) ([]models.Webhook, error)
+	FindSubscribers(ctx *context.Context, project string) ([]models.Webhook, error)
+	Save(ctx *context.Context, webhook models.Webhook) error
+	Delete(ctx *context.Context, webhookID string) error
+	FindDeliveries(ctx *context.Context, webhookID string) ([]models.WebhookDelivery, error)
+	ClaimDueDeliveries(ctx *context.Context, now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error)
+	SaveDeliveries(ctx *context.Context, deliveries []models.WebhookDelivery) error
+	UpdateDelivery(ctx *context.Context, delivery models.WebhookDelivery) error
+}
+
+// NewWebhookRepository creates a new WebhookRepository using the default implementation.
+func NewWebhookRepository(db *sql.DB) WebhookRepository {
+	return &webhookRepo{
+		db: db,
+	}
+}
+
+type webhookRepo struct {
+	db *sql.DB
+}
+
+const findWebhookQuery = `SELECT id, url, secret, types, created_at FROM webhook WHERE project = $1 AND id = $2`
+
+func (r *webhookRepo) Find(ctx *context.Context, webhookID string) (models.Webhook, error) {
+	log.Debugw("webhookRepo.Find", "webhookId", webhookID, "ctx", ctx)
+
+	var w models.Webhook
+	var types string
+	err := r.db.QueryRowContext(ctx, findWebhookQuery, ctx.Project, webhookID).Scan(&w.ID, &w.URL, &w.Secret, &types, &w.CreatedAt)
+	if err == sql.ErrNoRows {
+		return models.Webhook{}, ErrNotFound
+	}
+
+	if err != nil {
+		return models.Webhook{}, errors.Wrapf(err, "Failed to query webhook. id=%s", webhookID)
+	}
+
+	w.Types = splitTypes(types)
+	return w, nil
+}
+
+const findWebhooksQuery = `SELECT id, url, secret, types, created_at FROM webhook WHERE project = $1 ORDER BY created_at, id`
+
+func (r *webhookRepo) FindAll(ctx *context.Context) ([]models.Webhook, error) {
+	log.Debugw("webhookRepo.FindAll", "ctx", ctx)
+	return r.findWebhooks(ctx, findWebhooksQuery, ctx.Project)
+}
+
+const findSubscribersQuery = `SELECT id, url, secret, types, created_at FROM webhook WHERE ($1 = '' OR project = $1)`
+
+// FindSubscribers finds the webhooks of a project, an empty project finds the webhooks of all projects.
+func (r *webhookRepo) FindSubscribers(ctx *context.Context, project string) ([]models.Webhook, error) {
+	log.Debugw("webhookRepo.FindSubscribers", "project", project, "ctx", ctx)
+	return r.findWebhooks(ctx, findSubscribersQuery, project)
+}
+
+func (r *webhookRepo) findWebhooks(ctx *context.Context, query, project string) ([]models.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, query, project)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to query webhooks. project=%s", project)
+	}
+	defer rows.Close()
+
+	webhooks := make([]models.Webhook, 0)
+	for rows.Next() {
+		var w models.Webhook
+		var types string
+		err = rows.Scan(&w.ID, &w.URL, &w.Secret, &types, &w.CreatedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to scan webhook")
+		}
+
+		w.Types = splitTypes(types)
+		webhooks = append(webhooks, w)
+	}
+
+	return webhooks, nil
+}
+
+const saveWebhookQuery = `INSERT INTO webhook(id, project, url, secret, types, created_at) VALUES ($1, $2, $3, $4, $5, $6)`
+
+func (r *webhookRepo) Save(ctx *context.Context, webhook models.Webhook) error {
+	log.Debugw("webhookRepo.Save", "webhookId", webhook.ID, "ctx", ctx)
+	types := strings.Join(webhook.Types, ",")
+	_, err := r.db.ExecContext(ctx, saveWebhookQuery, webhook.ID, ctx.Project, webhook.URL, webhook.Secret, types, webhook.CreatedAt)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to save webhook. id=%s", webhook.ID)
+	}
+
+	return nil
+}
+
+const deleteWebhookDeliveriesQuery = `DELETE FROM webhook_delivery WHERE webhook_id IN (SELECT id FROM webhook WHERE project = $1 AND id = $2)`
+const deleteWebhookQuery = `DELETE FROM webhook WHERE project = $1 AND id = $2`
+
+// Delete deletes a webhook along with its delivery log.
+func (r *webhookRepo) Delete(ctx *context.Context, webhookID string) error {
+	log.Debugw("webhookRepo.Delete", "webhookId", webhookID, "ctx", ctx)
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, deleteWebhookDeliveriesQuery, ctx.Project, webhookID)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to delete webhook deliveries. id=%s", webhookID)
+		}
+
+		res, err := tx.ExecContext(ctx, deleteWebhookQuery, ctx.Project, webhookID)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to delete webhook. id=%s", webhookID)
+		}
+
+		return assertRowsAffected(res)
+	})
+}
+
+const findDeliveriesQuery = `
+	SELECT d.id, d.webhook_id, w.url, w.secret, d.event, d.payload, d.status, d.attempts, d.response_code, d.error, d.next_attempt_at, d.created_at, d.updated_at
+	FROM webhook_delivery d INNER JOIN webhook w ON w.id = d.webhook_id
+	WHERE w.project = $1 AND d.webhook_id = $2
+	ORDER BY d.id DESC`
+
+func (r *webhookRepo) FindDeliveries(ctx *context.Context, webhookID string) ([]models.WebhookDelivery, error) {
+	log.Debugw("webhookRepo.FindDeliveries", "webhookId", webhookID, "ctx", ctx)
+	rows, err := r.db.QueryContext(ctx, findDeliveriesQuery, ctx.Project, webhookID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to query webhook deliveries. webhookId=%s", webhookID)
+	}
+	defer rows.Close()
+
+	return scanDeliveries(rows)
+}
+
+const findDueDeliveriesQuery = `
+	SELECT d.id, d.webhook_id, w.url, w.secret, d.event, d.payload, d.status, d.attempts, d.response_code, d.error, d.next_attempt_at, d.created_at, d.updated_at
+	FROM webhook_delivery d INNER JOIN webhook w ON w.id = d.webhook_id
+	WHERE d.status = $1 AND d.next_attempt_at <= $2
+	ORDER BY d.next_attempt_at, d.id
+	LIMIT $3`
+
+const claimDeliveryQuery = `
+	UPDATE webhook_delivery SET next_attempt_at = $1
+	WHERE id = $2 AND status = $3 AND next_attempt_at <= $4`
+
+// ClaimDueDeliveries finds pending deliveries which are due to be attempted at the given time
+// and leases them by moving their next attempt to leaseUntil. A delivery which is claimed by
+// someone else in the meantime is left out, as is one which was updated since it was found.
+func (r *webhookRepo) ClaimDueDeliveries(ctx *context.Context, now, leaseUntil time.Time, limit int) ([]models.WebhookDelivery, error) {
+	log.Debugw("webhookRepo.ClaimDueDeliveries", "ctx", ctx)
+
+	claimed := make([]models.WebhookDelivery, 0)
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, findDueDeliveriesQuery, models.DeliveryPending, now, limit)
+		if err != nil {
+			return errors.Wrap(err, "Failed to query due webhook deliveries")
+		}
+
+		deliveries, err := scanDeliveries(rows)
+		rows.Close()
+		if err != nil {
+			return err
+		}
+
+		for _, d := range deliveries {
+			res, err := tx.ExecContext(ctx, claimDeliveryQuery, leaseUntil, d.ID, models.DeliveryPending, now)
+			if err != nil {
+				return errors.Wrapf(err, "Failed to claim webhook delivery. id=%d", d.ID)
+			}
+
+			err = assertRowsAffected(res)
+			if err == ErrNotFound {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			d.NextAttemptAt = leaseUntil
+			claimed = append(claimed, d)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+func scanDeliveries(rows *sql.Rows) ([]models.WebhookDelivery, error) {
+	deliveries := make([]models.WebhookDelivery, 0)
+	var d models.WebhookDelivery
+	for rows.Next() {
+		err := rows.Scan(&d.ID, &d.WebhookID, &d.URL, &d.Secret, &d.Event, &d.Payload, &d.Status,
+			&d.Attempts, &d.ResponseCode, &d.Error, &d.NextAttemptAt, &d.CreatedAt, &d.UpdatedAt)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to scan webhook delivery")
+		}
+
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, nil
+}
+
+const saveDeliveryQuery = `
+	INSERT INTO webhook_delivery(webhook_id, event, payload, status, attempts, response_code, error, next_attempt_at, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+// SaveDeliveries saves the deliveries in a single transaction.
+func (r *webhookRepo) SaveDeliveries(ctx *context.Context, deliveries []models.WebhookDelivery) error {
+	log.Debugw("webhookRepo.SaveDeliveries", "count", len(deliveries), "ctx", ctx)
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		for _, d := range deliveries {
+			_, err := tx.ExecContext(ctx, saveDeliveryQuery, d.WebhookID, d.Event, d.Payload, d.Status,
+				d.Attempts, d.ResponseCode, d.Error, d.NextAttemptAt, d.CreatedAt, d.UpdatedAt)
+			if err != nil {
+				return errors.Wrapf(err, "Failed to save webhook delivery. webhookId=%s", d.WebhookID)
+			}
+		}
+
+		return nil
+	})
+}
+
+const updateDeliveryQuery = `
+	UPDATE webhook_delivery SET status = $1, attempts = $2, response_code = $3, error = $4, next_attempt_at = $5, updated_at = $6
+	WHERE id = $7`
+
+func (r *webhookRepo) UpdateDelivery(ctx *context.Context, d models.WebhookDelivery) error {
+	log.Debugw("webhookRepo.UpdateDelivery", "deliveryId", d.ID, "status", d.Status, "ctx", ctx)
+	res, err := r.db.ExecContext(ctx, updateDeliveryQuery, d.Status, d.Attempts, d.ResponseCode, d.Error, d.NextAttemptAt, d.UpdatedAt, d.ID)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to update webhook delivery. id=%d", d.ID)
+	}
+
+	return assertRowsAffected(res)
+}
+
+func splitTypes(types string) []string {
+	if types == "" {
+		return []string{}
+	}
+
+	return strings.Split(types, ",")
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/CzarSimon/text-service/go/pkg/utils/id"
+)
+
+const apiKeyBytes = 32
+
+// APIKeyManager interface for issuing and revoking API keys.
+type APIKeyManager interface {
+	GetAll(ctx *context.Context) ([]models.APIKey, error)
+	Create(ctx *context.Context, name, role string) (models.APIKey, error)
+	Delete(ctx *context.Context, keyID string) error
+}
+
+// NewAPIKeyManager creates a new APIKeyManager using the default implementation.
+func NewAPIKeyManager(apiKeyRepo repository.APIKeyRepository) APIKeyManager {
+	return &apiKeyManager{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+type apiKeyManager struct {
+	apiKeyRepo repository.APIKeyRepository
+}
+
+func (m *apiKeyManager) GetAll(ctx *context.Context) ([]models.APIKey, error) {
+	log.Debugw("apiKeyManager.GetAll", "ctx", ctx)
+	keys, err := m.apiKeyRepo.FindAll(ctx)
+	if err != nil {
+		log.Errorw("Failed to find api keys", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	return keys, nil
+}
+
+// Create issues a new API key, the returned key is the only time the plain key is available.
+func (m *apiKeyManager) Create(ctx *context.Context, name, role string) (models.APIKey, error) {
+	log.Debugw("apiKeyManager.Create", "name", name, "role", role, "ctx", ctx)
+	if name == "" {
+		return models.APIKey{}, httputil.BadRequest("No api key name specified")
+	}
+
+	if !ValidRole(role) {
+		errorMsg := fmt.Sprintf("Invalid role: %s", role)
+		return models.APIKey{}, httputil.BadRequest(errorMsg)
+	}
+
+	secret := make([]byte, apiKeyBytes)
+	_, err := rand.Read(secret)
+	if err != nil {
+		log.Errorw("Failed to generate api key", "error", err, "ctx", ctx)
+		return models.APIKey{}, httputil.ErrInternalServerError
+	}
+
+	key := models.APIKey{
+		ID:        id.New(),
+		Name:      name,
+		Role:      role,
+		Key:       hex.EncodeToString(secret),
+		CreatedAt: time.Now(),
+	}
+	key.Hash = hashAPIKey(key.Key)
+
+	err = m.apiKeyRepo.Save(ctx, key)
+	if err != nil {
+		log.Errorw("Failed to save api key", "error", err, "ctx", ctx)
+		return models.APIKey{}, httputil.ErrInternalServerError
+	}
+
+	return key, nil
+}
+
+func (m *apiKeyManager) Delete(ctx *context.Context, keyID string) error {
+	log.Debugw("apiKeyManager.Delete", "keyId", keyID, "ctx", ctx)
+	err := m.apiKeyRepo.Delete(ctx, keyID)
+	if err == repository.ErrNotFound {
+		return httputil.ErrNotFound
+	}
+	if err != nil {
+		log.Errorw("Failed to delete api key", "error", err, "ctx", ctx)
+		return httputil.ErrInternalServerError
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/CzarSimon/text-service/go/pkg/utils/jwt"
+)
+
+var roleLevels = map[string]int{
+	models.ReaderRole:     1,
+	models.TranslatorRole: 2,
+	models.AdminRole:      3,
+}
+
+// Authenticator interface for resolving the principal behind API keys and tokens.
+type Authenticator interface {
+	AuthenticateAPIKey(ctx *context.Context, key string) (context.Principal, error)
+	AuthenticateToken(ctx *context.Context, token string) (context.Principal, error)
+}
+
+// NewAuthenticator creates a new Authenticator using the default implementation.
+// API keys are looked up by their hash and tokens are validated by the verifier,
+// if the verifier is nil all tokens are rejected.
+func NewAuthenticator(apiKeyRepo repository.APIKeyRepository, verifier *jwt.Verifier) Authenticator {
+	return &authenticator{
+		apiKeyRepo: apiKeyRepo,
+		verifier:   verifier,
+	}
+}
+
+type authenticator struct {
+	apiKeyRepo repository.APIKeyRepository
+	verifier   *jwt.Verifier
+}
+
+func (a *authenticator) AuthenticateAPIKey(ctx *context.Context, key string) (context.Principal, error) {
+	log.Debugw("authenticator.AuthenticateAPIKey", "ctx", ctx)
+	apiKey, err := a.apiKeyRepo.FindByHash(ctx, hashAPIKey(key))
+	if err == repository.ErrNotFound {
+		return context.Principal{}, httputil.Unauthorized("Invalid API key")
+	}
+	if err != nil {
+		log.Errorw("Failed to find api key", "error", err, "ctx", ctx)
+		return context.Principal{}, httputil.ErrInternalServerError
+	}
+
+	return context.Principal{ID: apiKey.ID, Role: apiKey.Role}, nil
+}
+
+func (a *authenticator) AuthenticateToken(ctx *context.Context, token string) (context.Principal, error) {
+	log.Debugw("authenticator.AuthenticateToken", "ctx", ctx)
+	if a.verifier == nil {
+		return context.Principal{}, httputil.Unauthorized("Token authentication is not enabled")
+	}
+
+	claims, err := a.verifier.Verify(token)
+	if err != nil {
+		log.Infow("Invalid token", "error", err, "ctx", ctx)
+		return context.Principal{}, httputil.Unauthorized("Invalid token")
+	}
+
+	if claims.Subject == "" || !ValidRole(claims.Role) {
+		return context.Principal{}, httputil.Unauthorized("Token lacks a subject or valid role")
+	}
+
+	return context.Principal{ID: claims.Subject, Role: claims.Role}, nil
+}
+
+// ValidRole checks if a role is known.
+func ValidRole(role string) bool {
+	_, ok := roleLevels[role]
+	return ok
+}
+
+// HasRole checks if the principal is granted the access of the required role.
+func HasRole(principal context.Principal, required string) bool {
+	level, ok := roleLevels[principal.Role]
+	return ok && level >= roleLevels[required]
+}
+
+func hashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
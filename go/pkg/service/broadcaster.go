@@ -0,0 +1,80 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+)
+
+// ChangeBroadcaster fans out changes to subscribers which watch for changes, e.g. streaming clients.
+type ChangeBroadcaster interface {
+	ChangeListener
+	Subscribe() (<-chan models.Change, func())
+	Close()
+}
+
+// NewChangeBroadcaster creates a new ChangeBroadcaster where each subscriber
+// buffers up to bufferSize changes. Changes to a subscriber with a full buffer are dropped.
+func NewChangeBroadcaster(bufferSize int) ChangeBroadcaster {
+	return &broadcaster{
+		bufferSize:  bufferSize,
+		subscribers: make(map[chan models.Change]bool),
+	}
+}
+
+type broadcaster struct {
+	bufferSize  int
+	mu          sync.RWMutex
+	subscribers map[chan models.Change]bool
+	closed      bool
+}
+
+// Subscribe returns a channel of changes along with a function which cancels the subscription.
+// The channel is closed when the subscription is cancelled or the broadcaster is closed.
+func (b *broadcaster) Subscribe() (<-chan models.Change, func()) {
+	ch := make(chan models.Change, b.bufferSize)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	b.subscribers[ch] = true
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if b.subscribers[ch] {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+// Close ends all subscriptions, e.g. to let streaming clients go on shutdown.
+func (b *broadcaster) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+func (b *broadcaster) OnChange(ctx *context.Context, change models.Change) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- change:
+		default:
+			log.Warnw("Dropped change to slow subscriber", "type", change.Type, "action", change.Action, "ctx", ctx)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+)
+
+// ChangeListener interface for reacting to changes of texts, groups and languages.
+type ChangeListener interface {
+	OnChange(ctx *context.Context, change models.Change)
+}
+
+// ChangeListeners notifies every listener in the list about changes.
+type ChangeListeners []ChangeListener
+
+// OnChange notifies all listeners of a change.
+func (l ChangeListeners) OnChange(ctx *context.Context, change models.Change) {
+	for _, listener := range l {
+		listener.OnChange(ctx, change)
+	}
+}
+
+func notify(ctx *context.Context, listener ChangeListener, change models.Change) {
+	change.Time = time.Now()
+	if change.Project == "" {
+		change.Project = ctx.Project
+	}
+	log.Debugw("Notifying change", "type", change.Type, "action", change.Action, "ctx", ctx)
+	listener.OnChange(ctx, change)
+}
+
+func textChange(action string, text models.TranslatedText) models.Change {
+	return models.Change{
+		Type:     models.TextChange,
+		Action:   action,
+		Key:      text.Key,
+		Language: text.Language,
+	}
+}
+
+func groupChange(action, groupID string, keys []string) models.Change {
+	return models.Change{
+		Type:    models.GroupChange,
+		Action:  action,
+		GroupID: groupID,
+		Keys:    keys,
+	}
+}
+
+func languageChange(action, language string) models.Change {
+	return models.Change{
+		Type:     models.LanguageChange,
+		Action:   action,
+		Language: language,
+	}
+}
+
+func releaseChange(action, releaseID string) models.Change {
+	return models.Change{
+		Type:    models.ReleaseChange,
+		Action:  action,
+		Release: releaseID,
+	}
+}
+
+func draftChange(action string, text models.TranslatedText) models.Change {
+	return models.Change{
+		Type:     models.DraftChange,
+		Action:   action,
+		Key:      text.Key,
+		Language: text.Language,
+	}
+}
+
+func projectChange(action, projectID string) models.Change {
+	return models.Change{
+		Type:    models.ProjectChange,
+		Action:  action,
+		Project: projectID,
+	}
+}
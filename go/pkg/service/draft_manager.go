@@ -0,0 +1,108 @@
+package service
+
+import (
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+)
+
+// DraftManager interface for staging draft values of texts and publishing them.
+type DraftManager interface {
+	GetAll(ctx *context.Context, key, language, groupID string) ([]models.TextDraft, error)
+	Save(ctx *context.Context, text models.TranslatedText) (models.TextDraft, error)
+	Discard(ctx *context.Context, key, language string) error
+	Publish(ctx *context.Context, key, language, groupID string) ([]models.TextDraft, error)
+}
+
+// NewDraftManager creates a new DraftManager using the default implementation.
+// Changes are reported to the listener.
+func NewDraftManager(
+	languageRepo repository.LanguageRepository,
+	draftRepo repository.DraftRepository,
+	listener ChangeListener) DraftManager {
+	return &draftManager{
+		languageRepo: languageRepo,
+		draftRepo:    draftRepo,
+		listener:     listener,
+	}
+}
+
+type draftManager struct {
+	languageRepo repository.LanguageRepository
+	draftRepo    repository.DraftRepository
+	listener     ChangeListener
+}
+
+func (m *draftManager) GetAll(ctx *context.Context, key, language, groupID string) ([]models.TextDraft, error) {
+	log.Debugw("draftManager.GetAll", "key", key, "language", language, "groupId", groupID, "ctx", ctx)
+	drafts, err := m.draftRepo.FindAll(ctx, key, language, groupID)
+	if err != nil {
+		log.Errorw("Failed to find drafts", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	return drafts, nil
+}
+
+func (m *draftManager) Save(ctx *context.Context, text models.TranslatedText) (models.TextDraft, error) {
+	log.Debugw("draftManager.Save", "key", text.Key, "language", text.Language, "ctx", ctx)
+	err := validateText(ctx, m.languageRepo, text)
+	if err != nil {
+		return models.TextDraft{}, err
+	}
+
+	err = m.draftRepo.Upsert(ctx, text)
+	if err != nil {
+		log.Errorw("Failed to save draft", "error", err, "ctx", ctx)
+		return models.TextDraft{}, httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, draftChange(models.Updated, text))
+	draft, err := m.draftRepo.Find(ctx, text.Key, text.Language)
+	if err != nil {
+		log.Errorw("Failed to find stored draft", "error", err, "ctx", ctx)
+		return models.TextDraft{}, httputil.ErrInternalServerError
+	}
+
+	return draft, nil
+}
+
+func (m *draftManager) Discard(ctx *context.Context, key, language string) error {
+	log.Debugw("draftManager.Discard", "key", key, "language", language, "ctx", ctx)
+	err := m.draftRepo.Delete(ctx, key, language)
+	if err == repository.ErrNotFound {
+		return httputil.ErrNotFound
+	}
+	if err != nil {
+		log.Errorw("Failed to delete draft", "error", err, "ctx", ctx)
+		return httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, draftChange(models.Deleted, models.TranslatedText{Key: key, Language: language}))
+	return nil
+}
+
+// Publish publishes the drafts matching the key, language and group. At least one
+// of them must be specified so that all drafts are not published by mistake.
+func (m *draftManager) Publish(ctx *context.Context, key, language, groupID string) ([]models.TextDraft, error) {
+	log.Debugw("draftManager.Publish", "key", key, "language", language, "groupId", groupID, "ctx", ctx)
+	if key == "" && language == "" && groupID == "" {
+		return nil, httputil.BadRequest("No key, language or group specified")
+	}
+
+	drafts, err := m.draftRepo.Publish(ctx, key, language, groupID)
+	if err == repository.ErrConflict {
+		return nil, httputil.Conflict("Drafts were changed while being published")
+	}
+	if err != nil {
+		log.Errorw("Failed to publish drafts", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	for _, draft := range drafts {
+		notify(ctx, m.listener, textChange(models.Published, draft.Text()))
+	}
+
+	return drafts, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+)
+
+// fallbackChain resolves the ordered list of languages to look texts up in for
+// the context language. The chain follows each language's configured fallback,
+// or its parent language (sv-FI -> sv) if none is configured, and ends with the
+// default fallback language. Disabled and unknown languages, including the
+// default fallback language, are skipped.
+func (g *getter) fallbackChain(ctx *context.Context) ([]string, error) {
+	err := g.assertLanguageExists(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := []string{ctx.Language}
+	visited := map[string]bool{ctx.Language: true}
+	current := ctx.Language
+	for {
+		next, err := g.nextFallback(ctx, current)
+		if err != nil {
+			return nil, err
+		}
+
+		if next == "" || visited[next] {
+			break
+		}
+
+		visited[next] = true
+		current = next
+		enabled, err := g.isEnabled(ctx, next)
+		if err != nil {
+			return nil, err
+		}
+
+		if enabled {
+			chain = append(chain, next)
+		}
+	}
+
+	if g.defaultFallback == "" || visited[g.defaultFallback] {
+		return chain, nil
+	}
+
+	enabled, err := g.isEnabled(ctx, g.defaultFallback)
+	if err != nil {
+		return nil, err
+	}
+
+	if enabled {
+		chain = append(chain, g.defaultFallback)
+	}
+
+	return chain, nil
+}
+
+// isEnabled checks if a fallback language exists and is enabled.
+func (g *getter) isEnabled(ctx *context.Context, language string) (bool, error) {
+	lang, err := g.languageRepo.Find(ctx, language)
+	if err == repository.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		log.Errorw("Failed to find fallback language", "error", err, "ctx", ctx)
+		return false, httputil.ErrInternalServerError
+	}
+
+	return lang.Enabled, nil
+}
+
+func (g *getter) nextFallback(ctx *context.Context, language string) (string, error) {
+	lang, err := g.languageRepo.Find(ctx, language)
+	if err != nil && err != repository.ErrNotFound {
+		log.Errorw("Failed to find language", "error", err, "ctx", ctx)
+		return "", httputil.ErrInternalServerError
+	}
+
+	if lang.Fallback != "" {
+		return lang.Fallback, nil
+	}
+
+	return parentLanguage(language), nil
+}
+
+// parentLanguage returns the language tag with its last subtag removed.
+func parentLanguage(language string) string {
+	i := strings.LastIndex(language, "-")
+	if i < 1 {
+		return ""
+	}
+
+	return language[:i]
+}
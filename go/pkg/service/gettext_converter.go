@@ -0,0 +1,370 @@
+package service
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/gettext"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/CzarSimon/text-service/go/pkg/utils/messageformat"
+)
+
+// pluralArgument name of the argument of messages imported from gettext plural forms.
+const pluralArgument = "n"
+
+// maxPluralSample largest number tried when matching gettext plural forms to CLDR plural categories.
+const maxPluralSample = 1000
+
+var pluralCategories = []string{
+	messageformat.Zero,
+	messageformat.One,
+	messageformat.Two,
+	messageformat.Few,
+	messageformat.Many,
+	messageformat.Other,
+}
+
+// GettextConverter interface for importing and exporting the texts of a language as gettext catalogs.
+type GettextConverter interface {
+	Import(ctx *context.Context, language string, catalog gettext.File) (models.GettextImport, error)
+	Export(ctx *context.Context, language string) (gettext.File, error)
+}
+
+// NewGettextConverter creates a new GettextConverter using the default implementation.
+// Changes are reported to the listener.
+func NewGettextConverter(
+	languageRepo repository.LanguageRepository,
+	textRepo repository.TextRepository,
+	gettextRepo repository.GettextRepository,
+	listener ChangeListener) GettextConverter {
+	return &gettextConverter{
+		languageRepo: languageRepo,
+		textRepo:     textRepo,
+		gettextRepo:  gettextRepo,
+		listener:     listener,
+	}
+}
+
+type gettextConverter struct {
+	languageRepo repository.LanguageRepository
+	textRepo     repository.TextRepository
+	gettextRepo  repository.GettextRepository
+	listener     ChangeListener
+}
+
+// Import stores the translated messages of a catalog as texts of the language, keyed
+// by msgctxt and msgid. Plural forms are stored as an ICU plural message of the argument n.
+// The catalog is validated in full and then stored along with its gettext metadata in a single transaction.
+func (g *gettextConverter) Import(ctx *context.Context, language string, catalog gettext.File) (models.GettextImport, error) {
+	log.Debugw("gettextConverter.Import", "language", language, "messages", len(catalog.Messages), "ctx", ctx)
+	err := assertLanguageSupported(ctx, g.languageRepo, language)
+	if err != nil {
+		return models.GettextImport{}, err
+	}
+
+	contentType := strings.ToLower(catalog.HeaderField("Content-Type"))
+	if strings.Contains(contentType, "charset=") && !strings.Contains(contentType, "charset=utf-8") {
+		errorMsg := fmt.Sprintf("Unsupported content type: %s, catalogs must be UTF-8 encoded", contentType)
+		return models.GettextImport{}, httputil.BadRequest(errorMsg)
+	}
+
+	forms, err := pluralForms(catalog)
+	if err != nil {
+		return models.GettextImport{}, err
+	}
+
+	cases := pluralCases(language, forms)
+	result := models.GettextImport{Language: language}
+	texts := make([]models.TranslatedText, 0, len(catalog.Messages))
+	entries := make([]models.GettextEntry, 0, len(catalog.Messages))
+	keys := make(map[string]bool)
+	for _, msg := range catalog.Messages {
+		if !msg.Translated() || msg.HasFlag("fuzzy") {
+			result.Skipped++
+			continue
+		}
+
+		key := gettextKey(msg)
+		if keys[key] {
+			errorMsg := fmt.Sprintf("Duplicate message. msgctxt=%q msgid=%q", msg.Context, msg.ID)
+			return models.GettextImport{}, httputil.BadRequest(errorMsg)
+		}
+		keys[key] = true
+
+		value, err := gettextValue(cases, msg)
+		if err != nil {
+			return models.GettextImport{}, err
+		}
+
+		texts = append(texts, models.TranslatedText{Key: key, Language: language, Value: value})
+		entries = append(entries, models.GettextEntry{
+			Key:                key,
+			Language:           language,
+			Context:            msg.Context,
+			ID:                 msg.ID,
+			IDPlural:           msg.IDPlural,
+			TranslatorComments: msg.TranslatorComments,
+			ExtractedComments:  msg.ExtractedComments,
+			References:         msg.References,
+			Flags:              msg.Flags,
+		})
+	}
+
+	actions, err := g.gettextRepo.Save(ctx, language, catalog.Header, entries, texts)
+	if err != nil {
+		log.Errorw("Failed to save imported texts and gettext entries", "error", err, "ctx", ctx)
+		return models.GettextImport{}, httputil.ErrInternalServerError
+	}
+
+	for i, text := range texts {
+		if actions[i] == models.Created {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+		notify(ctx, g.listener, textChange(actions[i], text))
+	}
+
+	return result, nil
+}
+
+// Export creates a catalog with every text key of the project, keys which are not
+// translated to the language are exported with an empty translation. Texts which were
+// imported from gettext are exported with their original msgctxt, msgid and comments.
+func (g *gettextConverter) Export(ctx *context.Context, language string) (gettext.File, error) {
+	log.Debugw("gettextConverter.Export", "language", language, "ctx", ctx)
+	err := assertLanguageSupported(ctx, g.languageRepo, language)
+	if err != nil {
+		return gettext.File{}, err
+	}
+
+	texts, err := g.textRepo.FindAll(ctx)
+	if err != nil {
+		log.Errorw("Failed to find texts", "error", err, "ctx", ctx)
+		return gettext.File{}, httputil.ErrInternalServerError
+	}
+
+	entries, err := g.findEntries(ctx, language)
+	if err != nil {
+		return gettext.File{}, err
+	}
+
+	catalog := gettext.File{Header: defaultGettextHeader(ctx, language)}
+	header, err := g.gettextRepo.FindHeader(ctx, language)
+	if err == nil {
+		catalog.Header = header
+	} else if err != repository.ErrNotFound {
+		log.Errorw("Failed to find gettext header", "error", err, "ctx", ctx)
+		return gettext.File{}, httputil.ErrInternalServerError
+	}
+
+	forms, err := pluralForms(catalog)
+	if err != nil {
+		log.Warnw("Invalid stored plural forms, using the default", "error", err, "ctx", ctx)
+		forms = gettext.DefaultPluralForms
+	}
+
+	categories := formCategories(language, forms)
+	values := make(map[string]string)
+	keys := make([]string, 0)
+	for _, text := range texts {
+		if _, ok := values[text.Key]; !ok {
+			keys = append(keys, text.Key)
+			values[text.Key] = ""
+		}
+		if text.Language == language {
+			values[text.Key] = text.Value
+		}
+	}
+	sort.Strings(keys)
+
+	catalog.Messages = make([]gettext.Message, 0, len(keys))
+	for _, key := range keys {
+		msg := gettext.Message{ID: key}
+		if entry, ok := entries[key]; ok {
+			msg = gettext.Message{
+				Context:           entry.Context,
+				ID:                entry.ID,
+				IDPlural:          entry.IDPlural,
+				ExtractedComments: entry.ExtractedComments,
+				References:        entry.References,
+			}
+			if entry.Language == language {
+				msg.TranslatorComments = entry.TranslatorComments
+				msg.Flags = entry.Flags
+			}
+		}
+
+		if msg.IDPlural == "" {
+			msg.Str = []string{values[key]}
+		} else {
+			msg.Str = gettextPluralForms(categories, values[key])
+		}
+		catalog.Messages = append(catalog.Messages, msg)
+	}
+
+	return catalog, nil
+}
+
+// findEntries finds the gettext metadata of each key, preferring the metadata of the
+// language and otherwise using the metadata imported with any other language.
+func (g *gettextConverter) findEntries(ctx *context.Context, language string) (map[string]models.GettextEntry, error) {
+	entries, err := g.gettextRepo.FindEntries(ctx)
+	if err != nil {
+		log.Errorw("Failed to find gettext entries", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	byKey := make(map[string]models.GettextEntry)
+	for _, entry := range entries {
+		if _, ok := byKey[entry.Key]; !ok || entry.Language == language {
+			byKey[entry.Key] = entry
+		}
+	}
+
+	return byKey, nil
+}
+
+func defaultGettextHeader(ctx *context.Context, language string) string {
+	return fmt.Sprintf("Project-Id-Version: %s\n"+
+		"Language: %s\n"+
+		"MIME-Version: 1.0\n"+
+		"Content-Type: text/plain; charset=UTF-8\n"+
+		"Content-Transfer-Encoding: 8bit\n"+
+		"Plural-Forms: nplurals=2; plural=(n != 1);\n", ctx.Project, language)
+}
+
+func pluralForms(catalog gettext.File) (gettext.PluralForms, error) {
+	header := catalog.HeaderField("Plural-Forms")
+	if header == "" {
+		return gettext.DefaultPluralForms, nil
+	}
+
+	forms, err := gettext.ParsePluralForms(header)
+	if err != nil {
+		errorMsg := fmt.Sprintf("Invalid Plural-Forms header: %s", err)
+		return gettext.PluralForms{}, httputil.BadRequest(errorMsg)
+	}
+
+	return forms, nil
+}
+
+// gettextKey maps a message to a text key. The msgid, prefixed by the msgctxt if there is one,
+// is used as key if it is a valid key, otherwise the key is derived from a hash of the two.
+func gettextKey(msg gettext.Message) string {
+	key := msg.ID
+	if msg.Context != "" {
+		key = msg.Context + "." + msg.ID
+	}
+
+	if validateKey(key) == nil {
+		return key
+	}
+
+	hash := sha1.Sum([]byte(msg.Key()))
+	return "gettext." + hex.EncodeToString(hash[:8])
+}
+
+// pluralCase CLDR plural category of a language and the gettext plural form used for the category.
+type pluralCase struct {
+	category string
+	form     int
+}
+
+// pluralCases matches the gettext plural forms to the CLDR plural categories of a language,
+// using the smallest number of each category to select its form.
+func pluralCases(language string, forms gettext.PluralForms) []pluralCase {
+	samples := make(map[string]int)
+	for n := maxPluralSample; n >= 0; n-- {
+		samples[messageformat.PluralCategory(language, strconv.Itoa(n), false)] = n
+	}
+
+	cases := make([]pluralCase, 0, len(samples))
+	for _, category := range pluralCategories {
+		if n, ok := samples[category]; ok {
+			cases = append(cases, pluralCase{category: category, form: forms.Index(n)})
+		}
+	}
+
+	return cases
+}
+
+// formCategories finds the CLDR category of the smallest number selecting each gettext plural form.
+func formCategories(language string, forms gettext.PluralForms) []string {
+	categories := make([]string, forms.N)
+	for n := maxPluralSample; n >= 0; n-- {
+		categories[forms.Index(n)] = messageformat.PluralCategory(language, strconv.Itoa(n), false)
+	}
+
+	return categories
+}
+
+// gettextValue converts the translation of a message to a text value, plural forms
+// are converted to a plural message with a case for each CLDR plural category of the language.
+func gettextValue(cases []pluralCase, msg gettext.Message) (string, error) {
+	value := msg.Str[0]
+	if msg.IDPlural != "" {
+		var b strings.Builder
+		b.WriteString("{" + pluralArgument + ", plural,")
+		for _, c := range cases {
+			if c.form >= len(msg.Str) || msg.Str[c.form] == "" {
+				errorMsg := fmt.Sprintf("Missing plural form %d. msgctxt=%q msgid=%q", c.form, msg.Context, msg.ID)
+				return "", httputil.BadRequest(errorMsg)
+			}
+
+			b.WriteString(" " + c.category + " {" + strings.Replace(msg.Str[c.form], "#", "'#'", -1) + "}")
+		}
+		b.WriteString("}")
+		value = b.String()
+	}
+
+	if value == "" {
+		errorMsg := fmt.Sprintf("Missing translation. msgctxt=%q msgid=%q", msg.Context, msg.ID)
+		return "", httputil.BadRequest(errorMsg)
+	}
+
+	_, err := messageformat.Parse(value)
+	if err != nil {
+		errorMsg := fmt.Sprintf("Invalid message format: %s. msgctxt=%q msgid=%q", err, msg.Context, msg.ID)
+		return "", httputil.BadRequest(errorMsg)
+	}
+
+	return value, nil
+}
+
+// gettextPluralForms converts a text value to gettext plural forms, each form is taken from the
+// plural case of the form's category. Values which are not plural messages are used for every form.
+func gettextPluralForms(categories []string, value string) []string {
+	str := make([]string, len(categories))
+	if value == "" {
+		return str
+	}
+
+	var cases map[string]*messageformat.Message
+	msg, err := messageformat.Parse(value)
+	if err == nil {
+		_, cases, _ = msg.Plural()
+	}
+
+	for i, category := range categories {
+		if cases == nil {
+			str[i] = value
+			continue
+		}
+
+		form, ok := cases[category]
+		if !ok {
+			form = cases[messageformat.Other]
+		}
+		str[i] = strings.Replace(form.Source(), "'#'", "#", -1)
+	}
+
+	return str
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+)
+
+// GroupManager interface for listing, creating, editing and deleting text groups.
+type GroupManager interface {
+	GetAll(ctx *context.Context) ([]models.TextGroup, error)
+	Get(ctx *context.Context, groupID string) (models.TextGroup, error)
+	Create(ctx *context.Context, groupID string) (models.TextGroup, error)
+	Rename(ctx *context.Context, groupID, newID string) (models.TextGroup, error)
+	Delete(ctx *context.Context, groupID string) error
+	AddMembers(ctx *context.Context, groupID string, keys []string) (models.TextGroup, error)
+	RemoveMembers(ctx *context.Context, groupID string, keys []string) (models.TextGroup, error)
+}
+
+// NewGroupManager creates a new GroupManager using the default implementation.
+// Changes are reported to the listener.
+func NewGroupManager(groupRepo repository.GroupRepository, listener ChangeListener) GroupManager {
+	return &groupManager{
+		groupRepo: groupRepo,
+		listener:  listener,
+	}
+}
+
+type groupManager struct {
+	groupRepo repository.GroupRepository
+	listener  ChangeListener
+}
+
+func (m *groupManager) GetAll(ctx *context.Context) ([]models.TextGroup, error) {
+	log.Debugw("groupManager.GetAll", "ctx", ctx)
+	groups, err := m.groupRepo.FindAll(ctx)
+	if err != nil {
+		log.Errorw("Failed to find groups", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	return groups, nil
+}
+
+func (m *groupManager) Get(ctx *context.Context, groupID string) (models.TextGroup, error) {
+	log.Debugw("groupManager.Get", "groupId", groupID, "ctx", ctx)
+	group, err := m.findGroup(ctx, groupID)
+	if err != nil {
+		return models.TextGroup{}, err
+	}
+
+	keys, err := m.groupRepo.FindKeys(ctx, groupID)
+	if err != nil {
+		log.Errorw("Failed to find group keys", "error", err, "ctx", ctx)
+		return models.TextGroup{}, httputil.ErrInternalServerError
+	}
+
+	group.Keys = keys
+	return group, nil
+}
+
+func (m *groupManager) Create(ctx *context.Context, groupID string) (models.TextGroup, error) {
+	log.Debugw("groupManager.Create", "groupId", groupID, "ctx", ctx)
+	err := m.assertGroupMissing(ctx, groupID)
+	if err != nil {
+		return models.TextGroup{}, err
+	}
+
+	err = m.groupRepo.Save(ctx, models.TextGroup{ID: groupID})
+	if err != nil {
+		log.Errorw("Failed to save group", "error", err, "ctx", ctx)
+		return models.TextGroup{}, httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, groupChange(models.Created, groupID, nil))
+	return m.Get(ctx, groupID)
+}
+
+func (m *groupManager) Rename(ctx *context.Context, groupID, newID string) (models.TextGroup, error) {
+	log.Debugw("groupManager.Rename", "groupId", groupID, "newId", newID, "ctx", ctx)
+	err := m.assertGroupMissing(ctx, newID)
+	if err != nil {
+		return models.TextGroup{}, err
+	}
+
+	err = m.groupRepo.Rename(ctx, groupID, newID)
+	if err == repository.ErrNotFound {
+		return models.TextGroup{}, httputil.ErrNotFound
+	}
+	if err != nil {
+		log.Errorw("Failed to rename group", "error", err, "ctx", ctx)
+		return models.TextGroup{}, httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, groupChange(models.Deleted, groupID, nil))
+	notify(ctx, m.listener, groupChange(models.Created, newID, nil))
+	return m.Get(ctx, newID)
+}
+
+func (m *groupManager) Delete(ctx *context.Context, groupID string) error {
+	log.Debugw("groupManager.Delete", "groupId", groupID, "ctx", ctx)
+	err := m.groupRepo.Delete(ctx, groupID)
+	if err == repository.ErrNotFound {
+		return httputil.ErrNotFound
+	}
+	if err != nil {
+		log.Errorw("Failed to delete group", "error", err, "ctx", ctx)
+		return httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, groupChange(models.Deleted, groupID, nil))
+	return nil
+}
+
+func (m *groupManager) AddMembers(ctx *context.Context, groupID string, keys []string) (models.TextGroup, error) {
+	log.Debugw("groupManager.AddMembers", "groupId", groupID, "keys", keys, "ctx", ctx)
+	err := m.validateMembers(ctx, groupID, keys)
+	if err != nil {
+		return models.TextGroup{}, err
+	}
+
+	err = m.groupRepo.AddTextsToGroup(ctx, groupID, keys)
+	if err != nil {
+		log.Errorw("Failed to add texts to group", "error", err, "ctx", ctx)
+		return models.TextGroup{}, httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, groupChange(models.Updated, groupID, keys))
+	return m.Get(ctx, groupID)
+}
+
+func (m *groupManager) RemoveMembers(ctx *context.Context, groupID string, keys []string) (models.TextGroup, error) {
+	log.Debugw("groupManager.RemoveMembers", "groupId", groupID, "keys", keys, "ctx", ctx)
+	err := m.validateMembers(ctx, groupID, keys)
+	if err != nil {
+		return models.TextGroup{}, err
+	}
+
+	err = m.groupRepo.RemoveTextsFromGroup(ctx, groupID, keys)
+	if err != nil {
+		log.Errorw("Failed to remove texts from group", "error", err, "ctx", ctx)
+		return models.TextGroup{}, httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, groupChange(models.Updated, groupID, keys))
+	return m.Get(ctx, groupID)
+}
+
+func (m *groupManager) validateMembers(ctx *context.Context, groupID string, keys []string) error {
+	if len(keys) == 0 {
+		return httputil.BadRequest("No text keys specified")
+	}
+
+	for _, key := range keys {
+		err := validateKey(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := m.findGroup(ctx, groupID)
+	return err
+}
+
+func (m *groupManager) findGroup(ctx *context.Context, groupID string) (models.TextGroup, error) {
+	group, err := m.groupRepo.Find(ctx, groupID)
+	if err == repository.ErrNotFound {
+		errorMsg := fmt.Sprintf("No such group: %s", groupID)
+		return models.TextGroup{}, httputil.NotFound(errorMsg)
+	}
+	if err != nil {
+		log.Errorw("Failed to find group", "error", err, "ctx", ctx)
+		return models.TextGroup{}, httputil.ErrInternalServerError
+	}
+
+	return group, nil
+}
+
+func (m *groupManager) assertGroupMissing(ctx *context.Context, groupID string) error {
+	err := validateGroupID(groupID)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.groupRepo.Find(ctx, groupID)
+	if err == nil {
+		errorMsg := fmt.Sprintf("Group already exists: %s", groupID)
+		return httputil.Conflict(errorMsg)
+	}
+	if err != repository.ErrNotFound {
+		log.Errorw("Failed to check if group exists", "error", err, "ctx", ctx)
+		return httputil.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func validateGroupID(groupID string) error {
+	return validateIdentifier("group id", groupID)
+}
+
+func validateIdentifier(name, value string) error {
+	if value == "" {
+		return httputil.BadRequest(fmt.Sprintf("No %s specified", name))
+	}
+
+	if len(value) > maxKeyLength || !validKey.MatchString(value) {
+		errorMsg := fmt.Sprintf("Invalid %s: %s", name, value)
+		return httputil.BadRequest(errorMsg)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+)
+
+// maxLanguageIDLength length of the language id columns.
+const maxLanguageIDLength = 35
+
+// validLanguageID BCP 47 language tags made up of a language subtag, an optional script
+// subtag and an optional region subtag, such as sv, sv-SE, zh-Hant, sr-Latn-RS and es-419.
+var validLanguageID = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z]{4})?(-([a-zA-Z]{2}|[0-9]{3}))?$`)
+
+// LanguageManager interface for listing, creating and deleting supported languages.
+// GetAll lists disabled languages as well unless enabledOnly is set.
+type LanguageManager interface {
+	GetAll(ctx *context.Context, enabledOnly bool) ([]models.Language, error)
+	Create(ctx *context.Context, language models.Language) (models.Language, error)
+	Delete(ctx *context.Context, languageID string) error
+}
+
+// NewLanguageManager creates a new LanguageManager using the default implementation.
+// Changes are reported to the listener.
+func NewLanguageManager(languageRepo repository.LanguageRepository, listener ChangeListener) LanguageManager {
+	return &languageManager{
+		languageRepo: languageRepo,
+		listener:     listener,
+	}
+}
+
+type languageManager struct {
+	languageRepo repository.LanguageRepository
+	listener     ChangeListener
+}
+
+func (m *languageManager) GetAll(ctx *context.Context, enabledOnly bool) ([]models.Language, error) {
+	log.Debugw("languageManager.GetAll", "enabledOnly", enabledOnly, "ctx", ctx)
+	languages, err := m.languageRepo.FindAll(ctx)
+	if err != nil {
+		log.Errorw("Failed to find languages", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	if !enabledOnly {
+		return languages, nil
+	}
+
+	enabled := make([]models.Language, 0, len(languages))
+	for _, language := range languages {
+		if language.Enabled {
+			enabled = append(enabled, language)
+		}
+	}
+
+	return enabled, nil
+}
+
+func (m *languageManager) Create(ctx *context.Context, language models.Language) (models.Language, error) {
+	log.Debugw("languageManager.Create", "language", language.ID, "ctx", ctx)
+	if language.Direction == "" {
+		language.Direction = models.LeftToRight
+	}
+
+	err := m.validate(ctx, language)
+	if err != nil {
+		return models.Language{}, err
+	}
+
+	err = m.languageRepo.Save(ctx, language)
+	if err != nil {
+		log.Errorw("Failed to save language", "error", err, "ctx", ctx)
+		return models.Language{}, httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, languageChange(models.Created, language.ID))
+	stored, err := m.languageRepo.Find(ctx, language.ID)
+	if err != nil {
+		log.Errorw("Failed to find stored language", "error", err, "ctx", ctx)
+		return models.Language{}, httputil.ErrInternalServerError
+	}
+
+	return stored, nil
+}
+
+func (m *languageManager) Delete(ctx *context.Context, languageID string) error {
+	log.Debugw("languageManager.Delete", "language", languageID, "ctx", ctx)
+	err := m.languageRepo.Delete(ctx, languageID)
+	if err == repository.ErrNotFound {
+		return httputil.ErrNotFound
+	}
+	if err == repository.ErrInUse {
+		errorMsg := fmt.Sprintf("Language is in use by texts or other languages: %s", languageID)
+		return httputil.Conflict(errorMsg)
+	}
+	if err != nil {
+		log.Errorw("Failed to delete language", "error", err, "ctx", ctx)
+		return httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, languageChange(models.Deleted, languageID))
+	return nil
+}
+
+func (m *languageManager) validate(ctx *context.Context, language models.Language) error {
+	if len(language.ID) > maxLanguageIDLength || !validLanguageID.MatchString(language.ID) {
+		errorMsg := fmt.Sprintf("Invalid language id: %s", language.ID)
+		return httputil.BadRequest(errorMsg)
+	}
+
+	if language.Direction != models.LeftToRight && language.Direction != models.RightToLeft {
+		errorMsg := fmt.Sprintf("Invalid text direction: %s", language.Direction)
+		return httputil.BadRequest(errorMsg)
+	}
+
+	if language.Fallback == language.ID {
+		return httputil.BadRequest("Language cannot be its own fallback")
+	}
+
+	_, err := m.languageRepo.Find(ctx, language.ID)
+	if err == nil {
+		errorMsg := fmt.Sprintf("Language already exists: %s", language.ID)
+		return httputil.Conflict(errorMsg)
+	}
+	if err != repository.ErrNotFound {
+		log.Errorw("Failed to check if language exists", "error", err, "ctx", ctx)
+		return httputil.ErrInternalServerError
+	}
+
+	if language.Fallback == "" {
+		return nil
+	}
+
+	return assertLanguageSupported(ctx, m.languageRepo, language.Fallback)
+}
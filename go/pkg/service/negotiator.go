@@ -0,0 +1,80 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/CzarSimon/text-service/go/pkg/utils/langtag"
+)
+
+// LanguageNegotiator interface for picking the best supported language for an Accept-Language header.
+type LanguageNegotiator interface {
+	Negotiate(ctx *context.Context, acceptLanguage string) (string, error)
+}
+
+// NewLanguageNegotiator creates a new LanguageNegotiator using the default implementation.
+// The default language is used when the header only contains a wildcard range,
+// as long as the header does not exclude it and it is an enabled language.
+func NewLanguageNegotiator(languageRepo repository.LanguageRepository, defaultLanguage string) LanguageNegotiator {
+	return &negotiator{
+		languageRepo:    languageRepo,
+		defaultLanguage: defaultLanguage,
+	}
+}
+
+type negotiator struct {
+	languageRepo    repository.LanguageRepository
+	defaultLanguage string
+}
+
+func (n *negotiator) Negotiate(ctx *context.Context, acceptLanguage string) (string, error) {
+	log.Debugw("negotiator.Negotiate", "acceptLanguage", acceptLanguage, "ctx", ctx)
+	languages, err := n.languageRepo.FindAll(ctx)
+	if err != nil {
+		log.Errorw("Failed to find languages", "error", err, "ctx", ctx)
+		return "", httputil.ErrInternalServerError
+	}
+
+	supported := make([]string, 0, len(languages))
+	for _, lang := range languages {
+		if lang.Enabled {
+			supported = append(supported, lang.ID)
+		}
+	}
+
+	ranges := langtag.ParseAcceptLanguage(acceptLanguage)
+	language, ok := langtag.Lookup(ranges, supported)
+	if ok {
+		return language, nil
+	}
+
+	if hasWildcard(ranges) && contains(supported, n.defaultLanguage) && !langtag.Excluded(ranges, n.defaultLanguage) {
+		return n.defaultLanguage, nil
+	}
+
+	log.Infow("No supported language found", "acceptLanguage", acceptLanguage, "ctx", ctx)
+	errorMsg := fmt.Sprintf("Unsupported language: %s", acceptLanguage)
+	return "", httputil.BadRequest(errorMsg)
+}
+
+func hasWildcard(ranges []langtag.Range) bool {
+	for _, r := range ranges {
+		if r.Tag == langtag.Wildcard && r.Quality > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
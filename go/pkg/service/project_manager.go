@@ -0,0 +1,108 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+)
+
+// ProjectManager interface for listing, creating and deleting projects.
+type ProjectManager interface {
+	GetAll(ctx *context.Context) ([]models.Project, error)
+	Get(ctx *context.Context, projectID string) (models.Project, error)
+	Create(ctx *context.Context, project models.Project) (models.Project, error)
+	Delete(ctx *context.Context, projectID string) error
+}
+
+// NewProjectManager creates a new ProjectManager using the default implementation.
+// Changes are reported to the listener.
+func NewProjectManager(projectRepo repository.ProjectRepository, listener ChangeListener) ProjectManager {
+	return &projectManager{
+		projectRepo: projectRepo,
+		listener:    listener,
+	}
+}
+
+type projectManager struct {
+	projectRepo repository.ProjectRepository
+	listener    ChangeListener
+}
+
+func (m *projectManager) GetAll(ctx *context.Context) ([]models.Project, error) {
+	log.Debugw("projectManager.GetAll", "ctx", ctx)
+	projects, err := m.projectRepo.FindAll(ctx)
+	if err != nil {
+		log.Errorw("Failed to find projects", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	return projects, nil
+}
+
+func (m *projectManager) Get(ctx *context.Context, projectID string) (models.Project, error) {
+	log.Debugw("projectManager.Get", "projectId", projectID, "ctx", ctx)
+	project, err := m.projectRepo.Find(ctx, projectID)
+	if err == repository.ErrNotFound {
+		errorMsg := fmt.Sprintf("No such project: %s", projectID)
+		return models.Project{}, httputil.NotFound(errorMsg)
+	}
+	if err != nil {
+		log.Errorw("Failed to find project", "error", err, "ctx", ctx)
+		return models.Project{}, httputil.ErrInternalServerError
+	}
+
+	return project, nil
+}
+
+func (m *projectManager) Create(ctx *context.Context, project models.Project) (models.Project, error) {
+	log.Debugw("projectManager.Create", "projectId", project.ID, "ctx", ctx)
+	err := validateIdentifier("project id", project.ID)
+	if err != nil {
+		return models.Project{}, err
+	}
+
+	_, err = m.projectRepo.Find(ctx, project.ID)
+	if err == nil {
+		errorMsg := fmt.Sprintf("Project already exists: %s", project.ID)
+		return models.Project{}, httputil.Conflict(errorMsg)
+	}
+	if err != repository.ErrNotFound {
+		log.Errorw("Failed to check if project exists", "error", err, "ctx", ctx)
+		return models.Project{}, httputil.ErrInternalServerError
+	}
+
+	err = m.projectRepo.Save(ctx, project)
+	if err != nil {
+		log.Errorw("Failed to save project", "error", err, "ctx", ctx)
+		return models.Project{}, httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, projectChange(models.Created, project.ID))
+	return m.Get(ctx, project.ID)
+}
+
+func (m *projectManager) Delete(ctx *context.Context, projectID string) error {
+	log.Debugw("projectManager.Delete", "projectId", projectID, "ctx", ctx)
+	if projectID == context.DefaultProject {
+		return httputil.Conflict("The default project cannot be deleted")
+	}
+
+	err := m.projectRepo.Delete(ctx, projectID)
+	if err == repository.ErrNotFound {
+		return httputil.ErrNotFound
+	}
+	if err == repository.ErrInUse {
+		errorMsg := fmt.Sprintf("Project still contains texts, groups, releases, gettext entries or webhooks: %s", projectID)
+		return httputil.Conflict(errorMsg)
+	}
+	if err != nil {
+		log.Errorw("Failed to delete project", "error", err, "ctx", ctx)
+		return httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, projectChange(models.Deleted, projectID))
+	return nil
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+)
+
+// ReleaseManager interface for creating and publishing immutable releases of texts.
+type ReleaseManager interface {
+	GetAll(ctx *context.Context) ([]models.Release, error)
+	Get(ctx *context.Context, releaseID string) (models.Release, error)
+	GetCurrent(ctx *context.Context) (models.Release, error)
+	Create(ctx *context.Context, release models.Release, groupIDs []string) (models.Release, error)
+	SetCurrent(ctx *context.Context, releaseID string) error
+}
+
+// NewReleaseManager creates a new ReleaseManager using the default implementation.
+// Changes are reported to the listener.
+func NewReleaseManager(
+	releaseRepo repository.ReleaseRepository,
+	languageRepo repository.LanguageRepository,
+	textRepo repository.TextRepository,
+	groupRepo repository.GroupRepository,
+	listener ChangeListener) ReleaseManager {
+	return &releaseManager{
+		releaseRepo:  releaseRepo,
+		languageRepo: languageRepo,
+		textRepo:     textRepo,
+		groupRepo:    groupRepo,
+		listener:     listener,
+	}
+}
+
+type releaseManager struct {
+	releaseRepo  repository.ReleaseRepository
+	languageRepo repository.LanguageRepository
+	textRepo     repository.TextRepository
+	groupRepo    repository.GroupRepository
+	listener     ChangeListener
+}
+
+func (m *releaseManager) GetAll(ctx *context.Context) ([]models.Release, error) {
+	log.Debugw("releaseManager.GetAll", "ctx", ctx)
+	releases, err := m.releaseRepo.FindAll(ctx)
+	if err != nil {
+		log.Errorw("Failed to find releases", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	return releases, nil
+}
+
+func (m *releaseManager) Get(ctx *context.Context, releaseID string) (models.Release, error) {
+	log.Debugw("releaseManager.Get", "releaseId", releaseID, "ctx", ctx)
+	release, err := m.releaseRepo.Find(ctx, releaseID)
+	if err == repository.ErrNotFound {
+		errorMsg := fmt.Sprintf("No such release: %s", releaseID)
+		return models.Release{}, httputil.NotFound(errorMsg)
+	}
+	if err != nil {
+		log.Errorw("Failed to find release", "error", err, "ctx", ctx)
+		return models.Release{}, httputil.ErrInternalServerError
+	}
+
+	return release, nil
+}
+
+func (m *releaseManager) GetCurrent(ctx *context.Context) (models.Release, error) {
+	log.Debugw("releaseManager.GetCurrent", "ctx", ctx)
+	release, err := m.releaseRepo.FindCurrent(ctx)
+	if err == repository.ErrNotFound {
+		return models.Release{}, httputil.NotFound("No current release")
+	}
+	if err != nil {
+		log.Errorw("Failed to find current release", "error", err, "ctx", ctx)
+		return models.Release{}, httputil.ErrInternalServerError
+	}
+
+	return release, nil
+}
+
+// Create snapshots texts into a new release. If group ids are given only the
+// texts in those groups are included, otherwise all texts and groups are.
+func (m *releaseManager) Create(ctx *context.Context, release models.Release, groupIDs []string) (models.Release, error) {
+	log.Debugw("releaseManager.Create", "releaseId", release.ID, "groupIds", groupIDs, "ctx", ctx)
+	err := m.assertReleaseMissing(ctx, release.ID)
+	if err != nil {
+		return models.Release{}, err
+	}
+
+	texts, groups, err := m.snapshot(ctx, groupIDs)
+	if err != nil {
+		return models.Release{}, err
+	}
+
+	err = m.releaseRepo.Save(ctx, release, texts, groups)
+	if err != nil {
+		log.Errorw("Failed to save release", "error", err, "ctx", ctx)
+		return models.Release{}, httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, releaseChange(models.Created, release.ID))
+	return m.Get(ctx, release.ID)
+}
+
+func (m *releaseManager) SetCurrent(ctx *context.Context, releaseID string) error {
+	log.Debugw("releaseManager.SetCurrent", "releaseId", releaseID, "ctx", ctx)
+	err := m.releaseRepo.SetCurrent(ctx, releaseID)
+	if err == repository.ErrNotFound {
+		errorMsg := fmt.Sprintf("No such release: %s", releaseID)
+		return httputil.NotFound(errorMsg)
+	}
+	if err != nil {
+		log.Errorw("Failed to set current release", "error", err, "ctx", ctx)
+		return httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, releaseChange(models.Updated, releaseID))
+	return nil
+}
+
+func (m *releaseManager) snapshot(ctx *context.Context, groupIDs []string) ([]models.TranslatedText, map[string][]string, error) {
+	if len(groupIDs) == 0 {
+		return m.snapshotAll(ctx)
+	}
+
+	languages, err := m.languageRepo.FindAll(ctx)
+	if err != nil {
+		log.Errorw("Failed to find languages", "error", err, "ctx", ctx)
+		return nil, nil, httputil.ErrInternalServerError
+	}
+
+	texts := make([]models.TranslatedText, 0)
+	included := make(map[string]bool)
+	groups := make(map[string][]string)
+	for _, groupID := range groupIDs {
+		keys, err := m.findGroupKeys(ctx, groupID)
+		if err != nil {
+			return nil, nil, err
+		}
+		groups[groupID] = keys
+
+		for _, lang := range languages {
+			groupTexts, err := m.groupRepo.FindTexts(ctx, groupID, lang.ID)
+			if err != nil {
+				log.Errorw("Failed to find group texts", "error", err, "ctx", ctx)
+				return nil, nil, httputil.ErrInternalServerError
+			}
+
+			for _, text := range groupTexts {
+				id := text.Key + ":" + text.Language
+				if !included[id] {
+					included[id] = true
+					texts = append(texts, text)
+				}
+			}
+		}
+	}
+
+	return texts, groups, nil
+}
+
+func (m *releaseManager) snapshotAll(ctx *context.Context) ([]models.TranslatedText, map[string][]string, error) {
+	texts, err := m.textRepo.FindAll(ctx)
+	if err != nil {
+		log.Errorw("Failed to find texts", "error", err, "ctx", ctx)
+		return nil, nil, httputil.ErrInternalServerError
+	}
+
+	allGroups, err := m.groupRepo.FindAll(ctx)
+	if err != nil {
+		log.Errorw("Failed to find groups", "error", err, "ctx", ctx)
+		return nil, nil, httputil.ErrInternalServerError
+	}
+
+	groups := make(map[string][]string)
+	for _, group := range allGroups {
+		keys, err := m.findGroupKeys(ctx, group.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		groups[group.ID] = keys
+	}
+
+	return texts, groups, nil
+}
+
+func (m *releaseManager) findGroupKeys(ctx *context.Context, groupID string) ([]string, error) {
+	_, err := m.groupRepo.Find(ctx, groupID)
+	if err == repository.ErrNotFound {
+		errorMsg := fmt.Sprintf("No such group: %s", groupID)
+		return nil, httputil.NotFound(errorMsg)
+	}
+	if err != nil {
+		log.Errorw("Failed to find group", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	keys, err := m.groupRepo.FindKeys(ctx, groupID)
+	if err != nil {
+		log.Errorw("Failed to find group keys", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	return keys, nil
+}
+
+func (m *releaseManager) assertReleaseMissing(ctx *context.Context, releaseID string) error {
+	err := validateIdentifier("release id", releaseID)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.releaseRepo.Find(ctx, releaseID)
+	if err == nil {
+		errorMsg := fmt.Sprintf("Release already exists: %s", releaseID)
+		return httputil.Conflict(errorMsg)
+	}
+	if err != repository.ErrNotFound {
+		log.Errorw("Failed to check if release exists", "error", err, "ctx", ctx)
+		return httputil.ErrInternalServerError
+	}
+
+	return nil
+}
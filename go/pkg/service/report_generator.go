@@ -0,0 +1,138 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+)
+
+// ReportGenerator interface for reporting on the state of translations.
+type ReportGenerator interface {
+	Missing(ctx *context.Context, groupID, language string) ([]models.MissingTranslation, error)
+}
+
+// NewReportGenerator creates a new ReportGenerator using the default implementation.
+func NewReportGenerator(reportRepo repository.ReportRepository, languageRepo repository.LanguageRepository, groupRepo repository.GroupRepository) ReportGenerator {
+	return &reportGenerator{
+		reportRepo:   reportRepo,
+		languageRepo: languageRepo,
+		groupRepo:    groupRepo,
+	}
+}
+
+type reportGenerator struct {
+	reportRepo   repository.ReportRepository
+	languageRepo repository.LanguageRepository
+	groupRepo    repository.GroupRepository
+}
+
+// Missing lists the text keys which are translated to some but not all enabled languages.
+// The report may be narrowed to the members of a group and to keys missing a single language.
+func (r *reportGenerator) Missing(ctx *context.Context, groupID, language string) ([]models.MissingTranslation, error) {
+	log.Debugw("reportGenerator.Missing", "groupId", groupID, "language", language, "ctx", ctx)
+	languages, err := r.reportLanguages(ctx, language)
+	if err != nil {
+		return nil, err
+	}
+
+	if groupID != "" {
+		err = r.assertGroupExists(ctx, groupID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	textLanguages, err := r.reportRepo.FindTextLanguages(ctx, groupID)
+	if err != nil {
+		log.Errorw("Failed to find text languages", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	keys := make([]string, 0, len(textLanguages))
+	for key := range textLanguages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	report := make([]models.MissingTranslation, 0)
+	for _, key := range keys {
+		translated := textLanguages[key]
+		missing := missingLanguages(languages, translated)
+		if len(missing) == 0 {
+			continue
+		}
+
+		report = append(report, models.MissingTranslation{
+			Key:        key,
+			Translated: translated,
+			Missing:    missing,
+		})
+	}
+
+	return report, nil
+}
+
+func (r *reportGenerator) reportLanguages(ctx *context.Context, language string) ([]string, error) {
+	if language != "" {
+		_, err := r.languageRepo.Find(ctx, language)
+		if err == repository.ErrNotFound {
+			errorMsg := fmt.Sprintf("No such language: %s", language)
+			return nil, httputil.NotFound(errorMsg)
+		}
+		if err != nil {
+			log.Errorw("Failed to find language", "error", err, "ctx", ctx)
+			return nil, httputil.ErrInternalServerError
+		}
+
+		return []string{language}, nil
+	}
+
+	all, err := r.languageRepo.FindAll(ctx)
+	if err != nil {
+		log.Errorw("Failed to find languages", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	languages := make([]string, 0, len(all))
+	for _, l := range all {
+		if l.Enabled {
+			languages = append(languages, l.ID)
+		}
+	}
+
+	return languages, nil
+}
+
+func (r *reportGenerator) assertGroupExists(ctx *context.Context, groupID string) error {
+	_, err := r.groupRepo.Find(ctx, groupID)
+	if err == repository.ErrNotFound {
+		errorMsg := fmt.Sprintf("No such group: %s", groupID)
+		return httputil.NotFound(errorMsg)
+	}
+	if err != nil {
+		log.Errorw("Failed to find group", "error", err, "ctx", ctx)
+		return httputil.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func missingLanguages(expected, translated []string) []string {
+	present := make(map[string]bool, len(translated))
+	for _, language := range translated {
+		present[language] = true
+	}
+
+	missing := make([]string, 0)
+	for _, language := range expected {
+		if !present[language] {
+			missing = append(missing, language)
+		}
+	}
+
+	return missing
+}
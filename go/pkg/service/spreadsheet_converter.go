@@ -0,0 +1,324 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+)
+
+// Spreadsheet columns besides the language columns.
+const (
+	SpreadsheetKeyColumn       = "key"
+	SpreadsheetUpdatedAtColumn = "updatedAt"
+)
+
+// SpreadsheetConverter interface for reviewing and editing texts as spreadsheets. A spreadsheet
+// has a row per text key with a column per language, along with the time the key was last updated.
+type SpreadsheetConverter interface {
+	Export(ctx *context.Context, groupID string) ([][]string, error)
+	Import(ctx *context.Context, rows [][]string, dryRun bool) (models.SpreadsheetImport, error)
+}
+
+// NewSpreadsheetConverter creates a new SpreadsheetConverter using the default implementation.
+// Changes are reported to the listener.
+func NewSpreadsheetConverter(
+	languageRepo repository.LanguageRepository,
+	textRepo repository.TextRepository,
+	groupRepo repository.GroupRepository,
+	listener ChangeListener) SpreadsheetConverter {
+	return &spreadsheetConverter{
+		languageRepo: languageRepo,
+		textRepo:     textRepo,
+		groupRepo:    groupRepo,
+		listener:     listener,
+	}
+}
+
+type spreadsheetConverter struct {
+	languageRepo repository.LanguageRepository
+	textRepo     repository.TextRepository
+	groupRepo    repository.GroupRepository
+	listener     ChangeListener
+}
+
+// Export creates a header row followed by a row for each text key, optionally restricted to the
+// members of a group, with the values of the key in each enabled language.
+func (s *spreadsheetConverter) Export(ctx *context.Context, groupID string) ([][]string, error) {
+	log.Debugw("spreadsheetConverter.Export", "groupId", groupID, "ctx", ctx)
+	languages, err := s.findLanguages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	texts, err := s.findTexts(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	header := append([]string{SpreadsheetKeyColumn}, languages...)
+	rows := [][]string{append(header, SpreadsheetUpdatedAtColumn)}
+	for _, key := range sortedTextKeys(texts) {
+		row := []string{key}
+		var updatedAt time.Time
+		for _, language := range languages {
+			text, ok := texts[key][language]
+			row = append(row, text.Value)
+			if ok && text.UpdatedAt.After(updatedAt) {
+				updatedAt = text.UpdatedAt
+			}
+		}
+
+		if updatedAt.IsZero() {
+			continue
+		}
+		rows = append(rows, append(row, updatedAt.UTC().Format(time.RFC3339Nano)))
+	}
+
+	return rows, nil
+}
+
+// Import compares the values of a spreadsheet with the current texts and, unless it is a dry run,
+// creates and updates the texts which differ in a single transaction. Empty cells are left as is.
+// A value conflicts if its text was updated after the updatedAt time of its row, or if its text
+// exists but the row has no updatedAt time. Imports with conflicts are rejected, and conflicts
+// are checked again in the transaction storing the texts.
+func (s *spreadsheetConverter) Import(ctx *context.Context, rows [][]string, dryRun bool) (models.SpreadsheetImport, error) {
+	log.Debugw("spreadsheetConverter.Import", "rows", len(rows), "dryRun", dryRun, "ctx", ctx)
+	columns, updatedAtColumn, err := s.parseHeader(ctx, rows)
+	if err != nil {
+		return models.SpreadsheetImport{}, err
+	}
+
+	texts, err := s.findTexts(ctx, "")
+	if err != nil {
+		return models.SpreadsheetImport{}, err
+	}
+
+	result := models.SpreadsheetImport{
+		DryRun:    dryRun,
+		Creates:   make([]models.SpreadsheetDiff, 0),
+		Updates:   make([]models.SpreadsheetDiff, 0),
+		Conflicts: make([]models.SpreadsheetDiff, 0),
+	}
+	conditions := make([]models.TextCondition, 0)
+	seen := make(map[string]bool)
+	for i, row := range rows[1:] {
+		key := strings.TrimSpace(cell(row, 0))
+		if key == "" {
+			continue
+		}
+
+		err = validateKey(key)
+		if err != nil {
+			return models.SpreadsheetImport{}, err
+		}
+		if seen[key] {
+			errorMsg := fmt.Sprintf("Duplicate key: %s", key)
+			return models.SpreadsheetImport{}, httputil.BadRequest(errorMsg)
+		}
+		seen[key] = true
+
+		var exportedAt time.Time
+		if value := strings.TrimSpace(cell(row, updatedAtColumn)); updatedAtColumn > 0 && value != "" {
+			exportedAt, err = time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				errorMsg := fmt.Sprintf("Invalid updatedAt on row %d, expected an RFC 3339 timestamp", i+2)
+				return models.SpreadsheetImport{}, httputil.BadRequest(errorMsg)
+			}
+		}
+
+		for col, language := range columns {
+			value := cell(row, col)
+			if value == "" {
+				continue
+			}
+
+			current, exists := texts[key][language]
+			if exists && current.Value == value {
+				result.Unchanged++
+				continue
+			}
+
+			err = validateText(ctx, s.languageRepo, models.TranslatedText{Key: key, Language: language, Value: value})
+			if err != nil {
+				return models.SpreadsheetImport{}, err
+			}
+
+			diff := models.SpreadsheetDiff{Key: key, Language: language, Current: current.Value, Value: value}
+			switch {
+			case !exists:
+				result.Creates = append(result.Creates, diff)
+				conditions = append(conditions, models.TextCondition{Key: key, Language: language, Absent: true})
+			case current.UpdatedAt.After(exportedAt):
+				result.Conflicts = append(result.Conflicts, diff)
+			default:
+				result.Updates = append(result.Updates, diff)
+				conditions = append(conditions, models.TextCondition{Key: key, Language: language, UpdatedBefore: exportedAt})
+			}
+		}
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	if len(result.Conflicts) > 0 {
+		errorMsg := fmt.Sprintf("Texts were changed after the spreadsheet was exported. texts=%s", formatDiffs(result.Conflicts))
+		return models.SpreadsheetImport{}, httputil.Conflict(errorMsg)
+	}
+
+	return result, s.commit(ctx, result, conditions)
+}
+
+// commit stores the created and updated texts if the conditions still hold, so that texts which
+// are created or changed by others after they were compared are not overwritten.
+func (s *spreadsheetConverter) commit(ctx *context.Context, result models.SpreadsheetImport, conditions []models.TextCondition) error {
+	changes := make([]models.TranslatedText, 0, len(result.Creates)+len(result.Updates))
+	for _, diffs := range [][]models.SpreadsheetDiff{result.Creates, result.Updates} {
+		for _, diff := range diffs {
+			changes = append(changes, models.TranslatedText{Key: diff.Key, Language: diff.Language, Value: diff.Value})
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	actions, err := s.textRepo.UpsertAll(ctx, changes, conditions)
+	if err == repository.ErrConflict {
+		return httputil.Conflict("Texts were changed during the import")
+	}
+	if err != nil {
+		log.Errorw("Failed to upsert imported texts", "error", err, "ctx", ctx)
+		return httputil.ErrInternalServerError
+	}
+
+	for i, text := range changes {
+		notify(ctx, s.listener, textChange(actions[i], text))
+	}
+
+	return nil
+}
+
+// parseHeader maps the columns of the header row to the languages they hold and finds the
+// updatedAt column, which is -1 if the spreadsheet has none.
+func (s *spreadsheetConverter) parseHeader(ctx *context.Context, rows [][]string) (map[int]string, int, error) {
+	if len(rows) == 0 || strings.TrimSpace(cell(rows[0], 0)) != SpreadsheetKeyColumn {
+		errorMsg := fmt.Sprintf("Invalid spreadsheet, the first column of the header must be %s", SpreadsheetKeyColumn)
+		return nil, -1, httputil.BadRequest(errorMsg)
+	}
+
+	columns := make(map[int]string)
+	updatedAtColumn := -1
+	seen := make(map[string]bool)
+	for col, name := range rows[0][1:] {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if seen[name] {
+			errorMsg := fmt.Sprintf("Duplicate column: %s", name)
+			return nil, -1, httputil.BadRequest(errorMsg)
+		}
+		seen[name] = true
+
+		if name == SpreadsheetUpdatedAtColumn {
+			updatedAtColumn = col + 1
+			continue
+		}
+
+		err := assertLanguageSupported(ctx, s.languageRepo, name)
+		if err != nil {
+			return nil, -1, err
+		}
+		columns[col+1] = name
+	}
+
+	return columns, updatedAtColumn, nil
+}
+
+// findLanguages finds the ids of the enabled languages.
+func (s *spreadsheetConverter) findLanguages(ctx *context.Context) ([]string, error) {
+	all, err := s.languageRepo.FindAll(ctx)
+	if err != nil {
+		log.Errorw("Failed to find languages", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	languages := make([]string, 0, len(all))
+	for _, lang := range all {
+		if lang.Enabled {
+			languages = append(languages, lang.ID)
+		}
+	}
+
+	return languages, nil
+}
+
+// findTexts finds the texts of each key by language, restricted to the members of a group unless the group id is empty.
+func (s *spreadsheetConverter) findTexts(ctx *context.Context, groupID string) (map[string]map[string]models.TranslatedText, error) {
+	var members map[string]bool
+	if groupID != "" {
+		keys, err := findGroupKeys(ctx, s.groupRepo, groupID)
+		if err != nil {
+			return nil, err
+		}
+
+		members = make(map[string]bool, len(keys))
+		for _, key := range keys {
+			members[key] = true
+		}
+	}
+
+	all, err := s.textRepo.FindAll(ctx)
+	if err != nil {
+		log.Errorw("Failed to find texts", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	texts := make(map[string]map[string]models.TranslatedText)
+	for _, text := range all {
+		if members != nil && !members[text.Key] {
+			continue
+		}
+
+		if _, ok := texts[text.Key]; !ok {
+			texts[text.Key] = make(map[string]models.TranslatedText)
+		}
+		texts[text.Key][text.Language] = text
+	}
+
+	return texts, nil
+}
+
+func sortedTextKeys(texts map[string]map[string]models.TranslatedText) []string {
+	keys := make([]string, 0, len(texts))
+	for key := range texts {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+func cell(row []string, col int) string {
+	if col < 0 || col >= len(row) {
+		return ""
+	}
+
+	return row[col]
+}
+
+func formatDiffs(diffs []models.SpreadsheetDiff) string {
+	pairs := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		pairs = append(pairs, diff.Key+"="+diff.Language)
+	}
+
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/cache"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+)
+
+// CachedTextGetter TextGetter which caches texts and is invalidated on changes.
+type CachedTextGetter interface {
+	TextGetter
+	ChangeListener
+}
+
+// NewCachedTextGetter creates a TextGetter caching the results of the wrapped getter.
+// The cache is cleared on every change since a single change can affect
+// the fallback resolution of many keys, groups and languages.
+func NewCachedTextGetter(getter TextGetter, c *cache.Cache) CachedTextGetter {
+	return &cachedGetter{
+		getter: getter,
+		cache:  c,
+	}
+}
+
+type cachedGetter struct {
+	getter TextGetter
+	cache  *cache.Cache
+}
+
+func (g *cachedGetter) Get(ctx *context.Context, key string) (models.ResolvedTexts, error) {
+	return g.load(ctx, textCacheKey(ctx, "key", key), func(ctx *context.Context) (models.ResolvedTexts, error) {
+		return g.getter.Get(ctx, key)
+	})
+}
+
+func (g *cachedGetter) GetGroup(ctx *context.Context, groupID string) (models.ResolvedTexts, error) {
+	return g.load(ctx, textCacheKey(ctx, "group", groupID), func(ctx *context.Context) (models.ResolvedTexts, error) {
+		return g.getter.GetGroup(ctx, groupID)
+	})
+}
+
+func (g *cachedGetter) OnChange(ctx *context.Context, change models.Change) {
+	log.Debugw("Clearing text cache", "type", change.Type, "action", change.Action, "ctx", ctx)
+	g.cache.Clear()
+}
+
+// load gets texts through the cache, previews of drafts are not cached. Texts are loaded
+// with a context detached from the request, as concurrent requests for the same texts
+// share the load and must not fail because the first of them was cancelled.
+func (g *cachedGetter) load(ctx *context.Context, key string, get func(*context.Context) (models.ResolvedTexts, error)) (models.ResolvedTexts, error) {
+	if ctx.Preview {
+		return get(ctx)
+	}
+
+	detached := ctx.Detach()
+	val, err := g.cache.GetOrLoad(key, func() (interface{}, error) {
+		return get(detached)
+	})
+	if err != nil {
+		return models.ResolvedTexts{}, err
+	}
+
+	return copyResolvedTexts(val.(models.ResolvedTexts)), nil
+}
+
+// textCacheKey identifies cached texts by the project, release and language they were resolved for.
+func textCacheKey(ctx *context.Context, kind, id string) string {
+	return kind + ":" + ctx.Project + ":" + ctx.Release + ":" + ctx.Language + ":" + id
+}
+
+// copyResolvedTexts copies cached texts so that callers may modify them.
+func copyResolvedTexts(texts models.ResolvedTexts) models.ResolvedTexts {
+	resolved := newResolvedTexts()
+	for key, value := range texts.Texts {
+		resolved.Texts[key] = value
+	}
+	for key, language := range texts.Fallbacks {
+		resolved.Fallbacks[key] = language
+	}
+	resolved.LastModified = texts.LastModified
+
+	return resolved
+}
+
+// CachedLanguageNegotiator LanguageNegotiator which caches negotiated languages and is invalidated on changes.
+type CachedLanguageNegotiator interface {
+	LanguageNegotiator
+	ChangeListener
+}
+
+// NewCachedLanguageNegotiator creates a LanguageNegotiator caching the results of the wrapped negotiator.
+func NewCachedLanguageNegotiator(negotiator LanguageNegotiator, c *cache.Cache) CachedLanguageNegotiator {
+	return &cachedNegotiator{
+		negotiator: negotiator,
+		cache:      c,
+	}
+}
+
+type cachedNegotiator struct {
+	negotiator LanguageNegotiator
+	cache      *cache.Cache
+}
+
+func (n *cachedNegotiator) Negotiate(ctx *context.Context, acceptLanguage string) (string, error) {
+	detached := ctx.Detach()
+	val, err := n.cache.GetOrLoad(acceptLanguage, func() (interface{}, error) {
+		return n.negotiator.Negotiate(detached, acceptLanguage)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return val.(string), nil
+}
+
+func (n *cachedNegotiator) OnChange(ctx *context.Context, change models.Change) {
+	if change.Type != models.LanguageChange {
+		return
+	}
+
+	log.Debugw("Clearing language cache", "action", change.Action, "ctx", ctx)
+	n.cache.Clear()
+}
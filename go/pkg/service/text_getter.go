@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/CzarSimon/text-service/go/pkg/models"
 	"github.com/CzarSimon/text-service/go/pkg/repository"
@@ -14,74 +15,148 @@ var log = logger.GetDefaultLogger("pkg/service").Sugar()
 
 // TextGetter interface for getting texts by for a given language.
 type TextGetter interface {
-	Get(ctx *context.Context, key string) (models.Texts, error)
-	GetGroup(ctx *context.Context, groupID string) (models.Texts, error)
+	Get(ctx *context.Context, key string) (models.ResolvedTexts, error)
+	GetGroup(ctx *context.Context, groupID string) (models.ResolvedTexts, error)
 }
 
 // NewTextGetter creates a new TextGetter using the default implementation.
+// Texts missing in the requested language are looked up along the language's
+// fallback chain, ending with defaultFallback if it is not empty.
 func NewTextGetter(
 	languageRepo repository.LanguageRepository,
 	textRepo repository.TextRepository,
-	groupRepo repository.GroupRepository) TextGetter {
+	groupRepo repository.GroupRepository,
+	releaseRepo repository.ReleaseRepository,
+	draftRepo repository.DraftRepository,
+	defaultFallback string) TextGetter {
 	return &getter{
-		languageRepo: languageRepo,
-		textRepo:     textRepo,
-		groupRepo:    groupRepo,
+		languageRepo:    languageRepo,
+		textRepo:        textRepo,
+		groupRepo:       groupRepo,
+		releaseRepo:     releaseRepo,
+		draftRepo:       draftRepo,
+		defaultFallback: defaultFallback,
 	}
 }
 
 type getter struct {
-	languageRepo repository.LanguageRepository
-	textRepo     repository.TextRepository
-	groupRepo    repository.GroupRepository
+	languageRepo    repository.LanguageRepository
+	textRepo        repository.TextRepository
+	groupRepo       repository.GroupRepository
+	releaseRepo     repository.ReleaseRepository
+	draftRepo       repository.DraftRepository
+	defaultFallback string
 }
 
-func (g *getter) Get(ctx *context.Context, key string) (models.Texts, error) {
+func (g *getter) Get(ctx *context.Context, key string) (models.ResolvedTexts, error) {
 	log.Debugw("getter.Get", "key", key, "ctx", ctx)
-	err := g.assertLanguageExists(ctx)
+	chain, err := g.fallbackChain(ctx)
 	if err != nil {
-		return nil, err
+		return models.ResolvedTexts{}, err
 	}
 
-	text, err := g.textRepo.Find(ctx, key, ctx.Language)
-	if err == repository.ErrNotFound {
-		return nil, httputil.ErrNotFound
-	}
+	source, err := g.source(ctx)
 	if err != nil {
-		log.Errorw("Failed to find text by key", "error", err, "ctx", ctx)
-		return nil, httputil.ErrInternalServerError
+		return models.ResolvedTexts{}, err
+	}
+
+	for _, language := range chain {
+		text, err := source.find(ctx, key, language)
+		if err == repository.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			log.Errorw("Failed to find text by key", "error", err, "ctx", ctx)
+			return models.ResolvedTexts{}, httputil.ErrInternalServerError
+		}
+
+		resolved := newResolvedTexts()
+		addTexts(ctx, &resolved, text)
+		err = g.addLastChange(ctx, &resolved, func() (time.Time, error) {
+			return source.keyRemovedAt(ctx, key)
+		})
+		if err != nil {
+			return models.ResolvedTexts{}, err
+		}
+
+		return resolved, nil
 	}
 
-	return mapTextsToMap(text), nil
+	return models.ResolvedTexts{}, httputil.ErrNotFound
 }
 
-func (g *getter) GetGroup(ctx *context.Context, groupID string) (models.Texts, error) {
+func (g *getter) GetGroup(ctx *context.Context, groupID string) (models.ResolvedTexts, error) {
 	log.Debugw("getter.GetGroup", "groupId", groupID, "ctx", ctx)
-
-	err := g.assertLanguageExists(ctx)
+	chain, err := g.fallbackChain(ctx)
 	if err != nil {
-		return nil, err
+		return models.ResolvedTexts{}, err
 	}
 
-	texts, err := g.groupRepo.FindTexts(ctx, groupID, ctx.Language)
+	source, err := g.source(ctx)
 	if err != nil {
-		log.Errorw("Failed to find texts by group", "error", err, "ctx", ctx)
-		return nil, httputil.ErrInternalServerError
+		return models.ResolvedTexts{}, err
 	}
 
-	if len(texts) == 0 {
+	resolved := newResolvedTexts()
+	for _, language := range chain {
+		texts, err := source.findGroup(ctx, groupID, language)
+		if err != nil {
+			log.Errorw("Failed to find texts by group", "error", err, "ctx", ctx)
+			return models.ResolvedTexts{}, httputil.ErrInternalServerError
+		}
+
+		addTexts(ctx, &resolved, texts...)
+	}
+
+	if len(resolved.Texts) == 0 {
 		log.Infow("No texts for group. groupId="+groupID, "ctx", ctx)
-		return nil, httputil.ErrNotFound
+		return models.ResolvedTexts{}, httputil.ErrNotFound
 	}
 
-	return mapTextsToMap(texts...), nil
+	err = g.addLastChange(ctx, &resolved, func() (time.Time, error) {
+		return source.groupRemovedAt(ctx, groupID)
+	})
+	if err != nil {
+		return models.ResolvedTexts{}, err
+	}
+
+	return resolved, nil
+}
+
+// addLastChange moves the last modification time of resolved texts forward to when texts
+// were last removed from them or when the current release last changed, as neither shows
+// in the update times of the texts which remain.
+func (g *getter) addLastChange(ctx *context.Context, resolved *models.ResolvedTexts, removedAt func() (time.Time, error)) error {
+	removed, err := removedAt()
+	if err != nil {
+		log.Errorw("Failed to find when texts were removed", "error", err, "ctx", ctx)
+		return httputil.ErrInternalServerError
+	}
+
+	released, err := g.releaseRepo.LastCurrentChange(ctx)
+	if err != nil {
+		log.Errorw("Failed to find when the current release changed", "error", err, "ctx", ctx)
+		return httputil.ErrInternalServerError
+	}
+
+	for _, t := range []time.Time{removed, released} {
+		if t.After(resolved.LastModified) {
+			resolved.LastModified = t
+		}
+	}
+
+	return nil
 }
 
 func (g *getter) assertLanguageExists(ctx *context.Context) error {
-	_, err := g.languageRepo.Find(ctx, ctx.Language)
-	if err == repository.ErrNotFound {
-		log.Infow("Language not found", "ctx", ctx)
-		errorMsg := fmt.Sprintf("Unsupported language: %s", ctx.Language)
+	return assertLanguageSupported(ctx, g.languageRepo, ctx.Language)
+}
+
+func assertLanguageSupported(ctx *context.Context, repo repository.LanguageRepository, language string) error {
+	lang, err := repo.Find(ctx, language)
+	if err == repository.ErrNotFound || (err == nil && !lang.Enabled) {
+		log.Infow("Language not found", "language", language, "ctx", ctx)
+		errorMsg := fmt.Sprintf("Unsupported language: %s", language)
 		return httputil.BadRequest(errorMsg)
 	}
 
@@ -93,11 +168,26 @@ func (g *getter) assertLanguageExists(ctx *context.Context) error {
 	return nil
 }
 
-func mapTextsToMap(texts ...models.TranslatedText) models.Texts {
-	textMap := make(models.Texts)
-	for _, text := range texts {
-		textMap[text.Key] = text.Value
+func newResolvedTexts() models.ResolvedTexts {
+	return models.ResolvedTexts{
+		Texts:     make(models.Texts),
+		Fallbacks: make(map[string]string),
 	}
+}
 
-	return textMap
+// addTexts adds texts which have not already been resolved in a preferred language.
+func addTexts(ctx *context.Context, resolved *models.ResolvedTexts, texts ...models.TranslatedText) {
+	for _, text := range texts {
+		if _, ok := resolved.Texts[text.Key]; ok {
+			continue
+		}
+
+		resolved.Texts[text.Key] = text.Value
+		if text.UpdatedAt.After(resolved.LastModified) {
+			resolved.LastModified = text.UpdatedAt
+		}
+		if text.Language != ctx.Language {
+			resolved.Fallbacks[text.Key] = text.Language
+		}
+	}
 }
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+)
+
+// TextHistory interface for listing and restoring revisions of translated texts.
+type TextHistory interface {
+	Get(ctx *context.Context, key, language string) ([]models.TextRevision, error)
+	Restore(ctx *context.Context, key string, revisionID int) (models.TranslatedText, error)
+}
+
+// NewTextHistory creates a new TextHistory using the default implementation.
+// Restored revisions are written through the text manager.
+func NewTextHistory(historyRepo repository.HistoryRepository, textManager TextManager) TextHistory {
+	return &history{
+		historyRepo: historyRepo,
+		textManager: textManager,
+	}
+}
+
+type history struct {
+	historyRepo repository.HistoryRepository
+	textManager TextManager
+}
+
+func (h *history) Get(ctx *context.Context, key, language string) ([]models.TextRevision, error) {
+	log.Debugw("history.Get", "key", key, "language", language, "ctx", ctx)
+	revisions, err := h.historyRepo.FindAll(ctx, key, language)
+	if err != nil {
+		log.Errorw("Failed to find text history", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	if len(revisions) == 0 {
+		return nil, httputil.ErrNotFound
+	}
+
+	return revisions, nil
+}
+
+func (h *history) Restore(ctx *context.Context, key string, revisionID int) (models.TranslatedText, error) {
+	log.Debugw("history.Restore", "key", key, "revisionId", revisionID, "ctx", ctx)
+	rev, err := h.historyRepo.Find(ctx, revisionID)
+	if err == repository.ErrNotFound || (err == nil && rev.Key != key) {
+		errorMsg := fmt.Sprintf("No such revision: %d", revisionID)
+		return models.TranslatedText{}, httputil.NotFound(errorMsg)
+	}
+	if err != nil {
+		log.Errorw("Failed to find text revision", "error", err, "ctx", ctx)
+		return models.TranslatedText{}, httputil.ErrInternalServerError
+	}
+
+	if rev.Action == models.Deleted {
+		return models.TranslatedText{}, httputil.BadRequest("Cannot restore a deletion")
+	}
+
+	return h.textManager.Put(ctx, models.TranslatedText{
+		Key:      rev.Key,
+		Language: rev.Language,
+		Value:    rev.Value,
+	})
+}
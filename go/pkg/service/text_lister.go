@@ -0,0 +1,91 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+)
+
+// Text listing page limits.
+const (
+	DefaultListLimit = 100
+	MaxListLimit     = 1000
+)
+
+// TextLister interface for walking translated texts page by page.
+type TextLister interface {
+	List(ctx *context.Context, filter models.TextFilter, cursor string, limit int) (models.TextPage, error)
+}
+
+// NewTextLister creates a new TextLister using the default implementation.
+func NewTextLister(textRepo repository.TextRepository) TextLister {
+	return &textLister{
+		textRepo: textRepo,
+	}
+}
+
+type textLister struct {
+	textRepo repository.TextRepository
+}
+
+// List finds a page of texts ordered by key and language, starting after the
+// text identified by the cursor. The cursor of the next page is empty on the last page.
+func (l *textLister) List(ctx *context.Context, filter models.TextFilter, cursor string, limit int) (models.TextPage, error) {
+	log.Debugw("textLister.List", "cursor", cursor, "limit", limit, "ctx", ctx)
+	if limit < 1 || limit > MaxListLimit {
+		errorMsg := fmt.Sprintf("Limit must be between 1 and %d", MaxListLimit)
+		return models.TextPage{}, httputil.BadRequest(errorMsg)
+	}
+
+	if cursor != "" {
+		var err error
+		filter.AfterKey, filter.AfterLanguage, err = decodeTextCursor(cursor)
+		if err != nil {
+			return models.TextPage{}, httputil.BadRequest("Invalid cursor")
+		}
+	}
+
+	texts, err := l.textRepo.FindPage(ctx, filter, limit+1)
+	if err != nil {
+		log.Errorw("Failed to find page of texts", "error", err, "ctx", ctx)
+		return models.TextPage{}, httputil.ErrInternalServerError
+	}
+
+	page := models.TextPage{Texts: texts}
+	if len(texts) > limit {
+		page.Texts = texts[:limit]
+		last := page.Texts[limit-1]
+		page.NextCursor = encodeTextCursor(last.Key, last.Language)
+	}
+
+	return page, nil
+}
+
+func encodeTextCursor(key, language string) string {
+	position, _ := json.Marshal([]string{key, language})
+	return base64.RawURLEncoding.EncodeToString(position)
+}
+
+func decodeTextCursor(cursor string) (string, string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", "", err
+	}
+
+	var position []string
+	err = json.Unmarshal(data, &position)
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(position) != 2 || position[0] == "" {
+		return "", "", fmt.Errorf("invalid cursor position: %v", position)
+	}
+
+	return position[0], position[1], nil
+}
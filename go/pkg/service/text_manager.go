@@ -0,0 +1,176 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/CzarSimon/text-service/go/pkg/utils/messageformat"
+)
+
+const maxKeyLength = 100
+
+var validKey = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+
+// TextManager interface for creating, updating and deleting translated texts.
+type TextManager interface {
+	Create(ctx *context.Context, text models.TranslatedText) (models.TranslatedText, error)
+	Update(ctx *context.Context, text models.TranslatedText) (models.TranslatedText, error)
+	Put(ctx *context.Context, text models.TranslatedText) (models.TranslatedText, error)
+	Delete(ctx *context.Context, key, language string) error
+}
+
+// NewTextManager creates a new TextManager using the default implementation.
+// Changes are reported to the listener.
+func NewTextManager(
+	languageRepo repository.LanguageRepository,
+	textRepo repository.TextRepository,
+	listener ChangeListener) TextManager {
+	return &manager{
+		languageRepo: languageRepo,
+		textRepo:     textRepo,
+		listener:     listener,
+	}
+}
+
+type manager struct {
+	languageRepo repository.LanguageRepository
+	textRepo     repository.TextRepository
+	listener     ChangeListener
+}
+
+func (m *manager) Create(ctx *context.Context, text models.TranslatedText) (models.TranslatedText, error) {
+	log.Debugw("manager.Create", "key", text.Key, "language", text.Language, "ctx", ctx)
+	err := m.validate(ctx, text)
+	if err != nil {
+		return models.TranslatedText{}, err
+	}
+
+	_, err = m.textRepo.Find(ctx, text.Key, text.Language)
+	if err == nil {
+		return models.TranslatedText{}, textExistsError(text)
+	}
+	if err != repository.ErrNotFound {
+		log.Errorw("Failed to check if text exists", "error", err, "ctx", ctx)
+		return models.TranslatedText{}, httputil.ErrInternalServerError
+	}
+
+	// The text may still be created concurrently after the check, which the unique key catches.
+	err = m.textRepo.Save(ctx, text)
+	if err == repository.ErrAlreadyExists {
+		return models.TranslatedText{}, textExistsError(text)
+	}
+	if err != nil {
+		log.Errorw("Failed to save text", "error", err, "ctx", ctx)
+		return models.TranslatedText{}, httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, textChange(models.Created, text))
+	return m.find(ctx, text.Key, text.Language)
+}
+
+func (m *manager) Update(ctx *context.Context, text models.TranslatedText) (models.TranslatedText, error) {
+	log.Debugw("manager.Update", "key", text.Key, "language", text.Language, "ctx", ctx)
+	err := m.validate(ctx, text)
+	if err != nil {
+		return models.TranslatedText{}, err
+	}
+
+	err = m.textRepo.Update(ctx, text)
+	if err == repository.ErrNotFound {
+		return models.TranslatedText{}, httputil.ErrNotFound
+	}
+	if err != nil {
+		log.Errorw("Failed to update text", "error", err, "ctx", ctx)
+		return models.TranslatedText{}, httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, textChange(models.Updated, text))
+	return m.find(ctx, text.Key, text.Language)
+}
+
+func (m *manager) Put(ctx *context.Context, text models.TranslatedText) (models.TranslatedText, error) {
+	log.Debugw("manager.Put", "key", text.Key, "language", text.Language, "ctx", ctx)
+	err := m.validate(ctx, text)
+	if err != nil {
+		return models.TranslatedText{}, err
+	}
+
+	action, err := m.textRepo.Upsert(ctx, text)
+	if err != nil {
+		log.Errorw("Failed to upsert text", "error", err, "ctx", ctx)
+		return models.TranslatedText{}, httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, textChange(action, text))
+	return m.find(ctx, text.Key, text.Language)
+}
+
+func (m *manager) Delete(ctx *context.Context, key, language string) error {
+	log.Debugw("manager.Delete", "key", key, "language", language, "ctx", ctx)
+	err := m.textRepo.Delete(ctx, key, language)
+	if err == repository.ErrNotFound {
+		return httputil.ErrNotFound
+	}
+	if err != nil {
+		log.Errorw("Failed to delete text", "error", err, "ctx", ctx)
+		return httputil.ErrInternalServerError
+	}
+
+	notify(ctx, m.listener, textChange(models.Deleted, models.TranslatedText{Key: key, Language: language}))
+	return nil
+}
+
+func (m *manager) find(ctx *context.Context, key, language string) (models.TranslatedText, error) {
+	text, err := m.textRepo.Find(ctx, key, language)
+	if err != nil {
+		log.Errorw("Failed to find stored text", "error", err, "ctx", ctx)
+		return models.TranslatedText{}, httputil.ErrInternalServerError
+	}
+
+	return text, nil
+}
+
+func (m *manager) validate(ctx *context.Context, text models.TranslatedText) error {
+	return validateText(ctx, m.languageRepo, text)
+}
+
+func validateText(ctx *context.Context, languageRepo repository.LanguageRepository, text models.TranslatedText) error {
+	err := validateKey(text.Key)
+	if err != nil {
+		return err
+	}
+
+	if text.Value == "" {
+		return httputil.BadRequest("No text value specified")
+	}
+
+	_, err = messageformat.Parse(text.Value)
+	if err != nil {
+		errorMsg := fmt.Sprintf("Invalid message format: %s", err)
+		return httputil.BadRequest(errorMsg)
+	}
+
+	return assertLanguageSupported(ctx, languageRepo, text.Language)
+}
+
+func textExistsError(text models.TranslatedText) error {
+	errorMsg := fmt.Sprintf("Text already exists. key=%s language=%s", text.Key, text.Language)
+	return httputil.Conflict(errorMsg)
+}
+
+func validateKey(key string) error {
+	if key == "" {
+		return httputil.BadRequest("No text key specified")
+	}
+
+	if len(key) > maxKeyLength || !validKey.MatchString(key) {
+		errorMsg := fmt.Sprintf("Invalid text key: %s", key)
+		return httputil.BadRequest(errorMsg)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/CzarSimon/text-service/go/pkg/utils/messageformat"
+)
+
+// TextRenderer interface for rendering texts as ICU messages with arguments.
+type TextRenderer interface {
+	Render(ctx *context.Context, key string, args map[string]interface{}) (models.ResolvedTexts, error)
+}
+
+// NewTextRenderer creates a new TextRenderer using the default implementation.
+func NewTextRenderer(textGetter TextGetter) TextRenderer {
+	return &renderer{
+		textGetter: textGetter,
+	}
+}
+
+type renderer struct {
+	textGetter TextGetter
+}
+
+func (r *renderer) Render(ctx *context.Context, key string, args map[string]interface{}) (models.ResolvedTexts, error) {
+	log.Debugw("renderer.Render", "key", key, "ctx", ctx)
+	texts, err := r.textGetter.Get(ctx, key)
+	if err != nil {
+		return models.ResolvedTexts{}, err
+	}
+
+	language := ctx.Language
+	if fallback, ok := texts.Fallbacks[key]; ok {
+		language = fallback
+	}
+
+	msg, err := messageformat.Parse(texts.Texts[key])
+	if err != nil {
+		log.Errorw("Stored text is not a valid message", "key", key, "language", language, "error", err, "ctx", ctx)
+		return models.ResolvedTexts{}, httputil.ErrInternalServerError
+	}
+
+	value, err := msg.Format(language, args)
+	if err != nil {
+		errorMsg := fmt.Sprintf("Failed to render text: %s", err)
+		return models.ResolvedTexts{}, httputil.BadRequest(errorMsg)
+	}
+
+	texts.Texts[key] = value
+	return texts, nil
+}
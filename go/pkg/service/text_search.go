@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+)
+
+// Search page limits.
+const (
+	DefaultSearchLimit = 50
+	MaxSearchLimit     = 500
+)
+
+// TextSearch interface for searching translated texts by key and value.
+type TextSearch interface {
+	Search(ctx *context.Context, query, language, groupID string, offset, limit int) (models.SearchResult, error)
+}
+
+// NewTextSearch creates a new TextSearch using the default implementation.
+func NewTextSearch(searchRepo repository.SearchRepository) TextSearch {
+	return &textSearch{
+		searchRepo: searchRepo,
+	}
+}
+
+type textSearch struct {
+	searchRepo repository.SearchRepository
+}
+
+// Search finds a page of texts whose key contains the query or whose value
+// has words starting with the words of the query.
+func (s *textSearch) Search(ctx *context.Context, query, language, groupID string, offset, limit int) (models.SearchResult, error) {
+	log.Debugw("textSearch.Search", "query", query, "offset", offset, "limit", limit, "ctx", ctx)
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return models.SearchResult{}, httputil.BadRequest("No search query specified")
+	}
+
+	if offset < 0 {
+		return models.SearchResult{}, httputil.BadRequest("Offset may not be negative")
+	}
+
+	if limit < 1 || limit > MaxSearchLimit {
+		errorMsg := fmt.Sprintf("Limit must be between 1 and %d", MaxSearchLimit)
+		return models.SearchResult{}, httputil.BadRequest(errorMsg)
+	}
+
+	texts, err := s.searchRepo.Search(ctx, query, language, groupID, offset, limit+1)
+	if err != nil {
+		log.Errorw("Failed to search texts", "error", err, "ctx", ctx)
+		return models.SearchResult{}, httputil.ErrInternalServerError
+	}
+
+	hasMore := len(texts) > limit
+	if hasMore {
+		texts = texts[:limit]
+	}
+
+	return models.SearchResult{
+		Texts:   texts,
+		Offset:  offset,
+		Limit:   limit,
+		HasMore: hasMore,
+	}, nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+)
+
+// textSource read interface for texts which are either live or part of a release. The removed at
+// methods find when texts were last removed from a key or group, or the zero time if they never were.
+type textSource interface {
+	find(ctx *context.Context, key, language string) (models.TranslatedText, error)
+	findGroup(ctx *context.Context, groupID, language string) ([]models.TranslatedText, error)
+	keyRemovedAt(ctx *context.Context, key string) (time.Time, error)
+	groupRemovedAt(ctx *context.Context, groupID string) (time.Time, error)
+}
+
+type liveSource struct {
+	textRepo  repository.TextRepository
+	groupRepo repository.GroupRepository
+}
+
+func (s liveSource) find(ctx *context.Context, key, language string) (models.TranslatedText, error) {
+	return s.textRepo.Find(ctx, key, language)
+}
+
+func (s liveSource) findGroup(ctx *context.Context, groupID, language string) ([]models.TranslatedText, error) {
+	return s.groupRepo.FindTexts(ctx, groupID, language)
+}
+
+func (s liveSource) keyRemovedAt(ctx *context.Context, key string) (time.Time, error) {
+	return s.textRepo.LastDeletion(ctx, key)
+}
+
+func (s liveSource) groupRemovedAt(ctx *context.Context, groupID string) (time.Time, error) {
+	return s.groupRepo.LastRemoval(ctx, groupID)
+}
+
+type releaseSource struct {
+	releaseID   string
+	releaseRepo repository.ReleaseRepository
+}
+
+func (s releaseSource) find(ctx *context.Context, key, language string) (models.TranslatedText, error) {
+	return s.releaseRepo.FindText(ctx, s.releaseID, key, language)
+}
+
+func (s releaseSource) findGroup(ctx *context.Context, groupID, language string) ([]models.TranslatedText, error) {
+	return s.releaseRepo.FindGroupTexts(ctx, s.releaseID, groupID, language)
+}
+
+// Releases are immutable, so texts are never removed from them.
+func (s releaseSource) keyRemovedAt(ctx *context.Context, key string) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+func (s releaseSource) groupRemovedAt(ctx *context.Context, groupID string) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+// previewSource reads draft values where they exist and published values otherwise.
+type previewSource struct {
+	draftRepo repository.DraftRepository
+	published textSource
+}
+
+func (s previewSource) find(ctx *context.Context, key, language string) (models.TranslatedText, error) {
+	draft, err := s.draftRepo.Find(ctx, key, language)
+	if err == repository.ErrNotFound {
+		return s.published.find(ctx, key, language)
+	}
+	if err != nil {
+		return models.TranslatedText{}, err
+	}
+
+	return draft.Text(), nil
+}
+
+func (s previewSource) findGroup(ctx *context.Context, groupID, language string) ([]models.TranslatedText, error) {
+	drafts, err := s.draftRepo.FindAll(ctx, "", language, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	published, err := s.published.findGroup(ctx, groupID, language)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make([]models.TranslatedText, 0, len(drafts)+len(published))
+	drafted := make(map[string]bool)
+	for _, draft := range drafts {
+		drafted[draft.Key] = true
+		texts = append(texts, draft.Text())
+	}
+
+	for _, text := range published {
+		if !drafted[text.Key] {
+			texts = append(texts, text)
+		}
+	}
+
+	return texts, nil
+}
+
+// Discarded drafts are not tracked, previews are therefore not validated by modification time.
+func (s previewSource) keyRemovedAt(ctx *context.Context, key string) (time.Time, error) {
+	return s.published.keyRemovedAt(ctx, key)
+}
+
+func (s previewSource) groupRemovedAt(ctx *context.Context, groupID string) (time.Time, error) {
+	return s.published.groupRemovedAt(ctx, groupID)
+}
+
+// source selects where texts are read from. Texts are read from the release
+// requested in the context, otherwise from the current release and from
+// the live texts if no release is current. Previews show drafts on top of the
+// live texts, regardless of the current release.
+func (g *getter) source(ctx *context.Context) (textSource, error) {
+	live := liveSource{textRepo: g.textRepo, groupRepo: g.groupRepo}
+	if ctx.Preview && ctx.Release == "" {
+		return previewSource{draftRepo: g.draftRepo, published: live}, nil
+	}
+
+	if ctx.Release != "" {
+		_, err := g.releaseRepo.Find(ctx, ctx.Release)
+		if err == repository.ErrNotFound {
+			errorMsg := fmt.Sprintf("No such release: %s", ctx.Release)
+			return nil, httputil.NotFound(errorMsg)
+		}
+		if err != nil {
+			log.Errorw("Failed to find release", "error", err, "ctx", ctx)
+			return nil, httputil.ErrInternalServerError
+		}
+
+		return releaseSource{releaseID: ctx.Release, releaseRepo: g.releaseRepo}, nil
+	}
+
+	current, err := g.releaseRepo.FindCurrent(ctx)
+	if err == repository.ErrNotFound {
+		return live, nil
+	}
+	if err != nil {
+		log.Errorw("Failed to find current release", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	return releaseSource{releaseID: current.ID, releaseRepo: g.releaseRepo}, nil
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Prometheus metrics, stats covering all texts of a project have an empty group label.
+var (
+	translationKeys = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "translation_keys",
+			Help: "The number of text keys",
+		},
+		[]string{"project", "group"},
+	)
+	translationTranslated = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "translation_translated",
+			Help: "The number of text keys translated to a language",
+		},
+		[]string{"project", "group", "language"},
+	)
+	translationPercentComplete = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "translation_percent_complete",
+			Help: "The percentage of text keys translated to a language",
+		},
+		[]string{"project", "group", "language"},
+	)
+	translationLastUpdated = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "translation_last_updated_timestamp_seconds",
+			Help: "The time a text in a language was last updated",
+		},
+		[]string{"project", "group", "language"},
+	)
+)
+
+// statsRefreshDelay time changes are collected for before the stats of their projects are
+// refreshed, so that bursts of changes such as imports cause a single refresh.
+const statsRefreshDelay = time.Second
+
+// TextStats interface for computing translation completeness statistics.
+// Stats are exported as prometheus gauges and kept current by listening to changes.
+type TextStats interface {
+	ChangeListener
+	Get(ctx *context.Context) (models.Stats, error)
+	RefreshAll(ctx *context.Context)
+	RefreshPending(ctx *context.Context)
+	Run(ctx *context.Context)
+}
+
+// NewTextStats creates a new TextStats using the default implementation.
+func NewTextStats(
+	languageRepo repository.LanguageRepository,
+	reportRepo repository.ReportRepository,
+	projectRepo repository.ProjectRepository,
+) TextStats {
+	return &textStats{
+		languageRepo: languageRepo,
+		reportRepo:   reportRepo,
+		projectRepo:  projectRepo,
+		exported:     make(map[string][]models.GroupStats),
+		pending:      make(map[string]bool),
+		changed:      make(chan struct{}, 1),
+	}
+}
+
+type textStats struct {
+	languageRepo repository.LanguageRepository
+	reportRepo   repository.ReportRepository
+	projectRepo  repository.ProjectRepository
+	mu           sync.Mutex
+	exported     map[string][]models.GroupStats
+	pendingMu    sync.Mutex
+	pending      map[string]bool
+	pendingAll   bool
+	changed      chan struct{}
+}
+
+// Get computes the stats of the current project and updates its gauges.
+func (s *textStats) Get(ctx *context.Context) (models.Stats, error) {
+	log.Debugw("textStats.Get", "ctx", ctx)
+	stats, err := s.compute(ctx)
+	if err != nil {
+		log.Errorw("Failed to compute text stats", "error", err, "ctx", ctx)
+		return models.Stats{}, httputil.ErrInternalServerError
+	}
+
+	s.export(ctx.Project, stats)
+	return stats, nil
+}
+
+// RefreshAll updates the gauges of all projects.
+func (s *textStats) RefreshAll(ctx *context.Context) {
+	projects, err := s.projectRepo.FindAll(ctx)
+	if err != nil {
+		log.Errorw("Failed to find projects", "error", err, "ctx", ctx)
+		return
+	}
+
+	for _, project := range projects {
+		s.refresh(ctx, project.ID)
+	}
+}
+
+// OnChange marks the projects affected by a change for a refresh by Run. Languages
+// are shared between projects so language changes refresh all projects.
+func (s *textStats) OnChange(ctx *context.Context, change models.Change) {
+	s.pendingMu.Lock()
+	switch change.Type {
+	case models.TextChange, models.GroupChange:
+		s.pending[change.Project] = true
+	case models.LanguageChange:
+		s.pendingAll = true
+	case models.ProjectChange:
+		if change.Action == models.Deleted {
+			delete(s.pending, change.Project)
+			s.pendingMu.Unlock()
+			s.export(change.Project, models.Stats{})
+			return
+		}
+	}
+	s.pendingMu.Unlock()
+
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Run refreshes the projects marked by changes, once changes have been collected for
+// statsRefreshDelay, until the context is done.
+func (s *textStats) Run(ctx *context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.changed:
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(statsRefreshDelay):
+			s.RefreshPending(ctx)
+		}
+	}
+}
+
+// RefreshPending refreshes the projects marked by changes.
+func (s *textStats) RefreshPending(ctx *context.Context) {
+	s.pendingMu.Lock()
+	pending, all := s.pending, s.pendingAll
+	s.pending, s.pendingAll = make(map[string]bool), false
+	s.pendingMu.Unlock()
+
+	if all {
+		s.RefreshAll(ctx)
+		return
+	}
+
+	for project := range pending {
+		s.refresh(ctx, project)
+	}
+}
+
+func (s *textStats) refresh(ctx *context.Context, project string) {
+	projectCtx := *ctx
+	projectCtx.Project = project
+
+	stats, err := s.compute(&projectCtx)
+	if err != nil {
+		log.Errorw("Failed to refresh text stats", "project", project, "error", err, "ctx", ctx)
+		return
+	}
+
+	s.export(project, stats)
+}
+
+func (s *textStats) compute(ctx *context.Context) (models.Stats, error) {
+	languages, err := s.languageRepo.FindAll(ctx)
+	if err != nil {
+		return models.Stats{}, err
+	}
+
+	keys, err := s.reportRepo.CountKeys(ctx)
+	if err != nil {
+		return models.Stats{}, err
+	}
+
+	translated, err := s.reportRepo.CountTranslated(ctx)
+	if err != nil {
+		return models.Stats{}, err
+	}
+
+	enabled := make([]string, 0, len(languages))
+	for _, language := range languages {
+		if language.Enabled {
+			enabled = append(enabled, language.ID)
+		}
+	}
+
+	groupIDs := make([]string, 0, len(keys))
+	for groupID := range keys {
+		if groupID != "" {
+			groupIDs = append(groupIDs, groupID)
+		}
+	}
+	sort.Strings(groupIDs)
+
+	groups := make([]models.GroupStats, 0, len(groupIDs))
+	for _, groupID := range groupIDs {
+		groups = append(groups, models.GroupStats{
+			Group:     groupID,
+			Keys:      keys[groupID],
+			Languages: languageStats(enabled, keys[groupID], translated[groupID]),
+		})
+	}
+
+	return models.Stats{
+		Keys:      keys[""],
+		Languages: languageStats(enabled, keys[""], translated[""]),
+		Groups:    groups,
+	}, nil
+}
+
+func languageStats(languages []string, keys int, translated []models.LanguageStats) []models.LanguageStats {
+	counted := make(map[string]models.LanguageStats, len(translated))
+	for _, ls := range translated {
+		counted[ls.Language] = ls
+	}
+
+	stats := make([]models.LanguageStats, 0, len(languages))
+	for _, language := range languages {
+		ls := counted[language]
+		ls.Language = language
+		ls.Keys = keys
+		ls.PercentComplete = 100
+
+		if ls.Keys > 0 {
+			percent := float64(ls.Translated) / float64(ls.Keys) * 100
+			ls.PercentComplete = math.Round(percent*100) / 100
+		}
+
+		stats = append(stats, ls)
+	}
+
+	return stats
+}
+
+// export replaces the gauges of a project with the given stats.
+func (s *textStats) export(project string, stats models.Stats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, group := range s.exported[project] {
+		translationKeys.DeleteLabelValues(project, group.Group)
+		for _, ls := range group.Languages {
+			translationTranslated.DeleteLabelValues(project, group.Group, ls.Language)
+			translationPercentComplete.DeleteLabelValues(project, group.Group, ls.Language)
+			translationLastUpdated.DeleteLabelValues(project, group.Group, ls.Language)
+		}
+	}
+
+	if stats.Languages == nil {
+		delete(s.exported, project)
+		return
+	}
+
+	all := models.GroupStats{Keys: stats.Keys, Languages: stats.Languages}
+	exported := append([]models.GroupStats{all}, stats.Groups...)
+	for _, group := range exported {
+		translationKeys.WithLabelValues(project, group.Group).Set(float64(group.Keys))
+		for _, ls := range group.Languages {
+			translationTranslated.WithLabelValues(project, group.Group, ls.Language).Set(float64(ls.Translated))
+			translationPercentComplete.WithLabelValues(project, group.Group, ls.Language).Set(ls.PercentComplete)
+			if ls.LastUpdated != nil {
+				translationLastUpdated.WithLabelValues(project, group.Group, ls.Language).Set(float64(ls.LastUpdated.Unix()))
+			}
+		}
+	}
+
+	s.exported[project] = exported
+}
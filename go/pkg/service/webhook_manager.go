@@ -0,0 +1,220 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/CzarSimon/text-service/go/pkg/utils/id"
+)
+
+const webhookSecretBytes = 32
+
+var webhookTypes = map[string]bool{
+	models.TextChange:     true,
+	models.GroupChange:    true,
+	models.LanguageChange: true,
+	models.ReleaseChange:  true,
+	models.DraftChange:    true,
+	models.ProjectChange:  true,
+}
+
+// WebhookManager interface for subscribing webhooks to changes and inspecting their deliveries.
+type WebhookManager interface {
+	GetAll(ctx *context.Context) ([]models.Webhook, error)
+	Create(ctx *context.Context, webhook models.Webhook) (models.Webhook, error)
+	Delete(ctx *context.Context, webhookID string) error
+	GetDeliveries(ctx *context.Context, webhookID string) ([]models.WebhookDelivery, error)
+}
+
+// NewWebhookManager creates a new WebhookManager using the default implementation.
+func NewWebhookManager(webhookRepo repository.WebhookRepository) WebhookManager {
+	return &webhookManager{
+		webhookRepo: webhookRepo,
+	}
+}
+
+type webhookManager struct {
+	webhookRepo repository.WebhookRepository
+}
+
+// GetAll lists the webhooks of a project, secrets are only shown on creation.
+func (m *webhookManager) GetAll(ctx *context.Context) ([]models.Webhook, error) {
+	log.Debugw("webhookManager.GetAll", "ctx", ctx)
+	webhooks, err := m.webhookRepo.FindAll(ctx)
+	if err != nil {
+		log.Errorw("Failed to find webhooks", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+
+	return webhooks, nil
+}
+
+// Create subscribes a webhook to changes, a signing secret is generated if none is given.
+func (m *webhookManager) Create(ctx *context.Context, webhook models.Webhook) (models.Webhook, error) {
+	log.Debugw("webhookManager.Create", "url", webhook.URL, "ctx", ctx)
+	err := validateWebhook(webhook)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+
+	if webhook.Secret == "" {
+		webhook.Secret, err = generateWebhookSecret()
+		if err != nil {
+			log.Errorw("Failed to generate webhook secret", "error", err, "ctx", ctx)
+			return models.Webhook{}, httputil.ErrInternalServerError
+		}
+	}
+
+	if webhook.Types == nil {
+		webhook.Types = []string{}
+	}
+	webhook.ID = id.New()
+	webhook.CreatedAt = time.Now()
+
+	err = m.webhookRepo.Save(ctx, webhook)
+	if err != nil {
+		log.Errorw("Failed to save webhook", "error", err, "ctx", ctx)
+		return models.Webhook{}, httputil.ErrInternalServerError
+	}
+
+	return webhook, nil
+}
+
+func (m *webhookManager) Delete(ctx *context.Context, webhookID string) error {
+	log.Debugw("webhookManager.Delete", "webhookId", webhookID, "ctx", ctx)
+	err := m.webhookRepo.Delete(ctx, webhookID)
+	if err == repository.ErrNotFound {
+		return httputil.ErrNotFound
+	}
+	if err != nil {
+		log.Errorw("Failed to delete webhook", "error", err, "ctx", ctx)
+		return httputil.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (m *webhookManager) GetDeliveries(ctx *context.Context, webhookID string) ([]models.WebhookDelivery, error) {
+	log.Debugw("webhookManager.GetDeliveries", "webhookId", webhookID, "ctx", ctx)
+	_, err := m.webhookRepo.Find(ctx, webhookID)
+	if err == repository.ErrNotFound {
+		errorMsg := fmt.Sprintf("No such webhook: %s", webhookID)
+		return nil, httputil.NotFound(errorMsg)
+	}
+	if err != nil {
+		log.Errorw("Failed to find webhook", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	deliveries, err := m.webhookRepo.FindDeliveries(ctx, webhookID)
+	if err != nil {
+		log.Errorw("Failed to find webhook deliveries", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	return deliveries, nil
+}
+
+func validateWebhook(webhook models.Webhook) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errorMsg := fmt.Sprintf("Invalid webhook url: %s", webhook.URL)
+		return httputil.BadRequest(errorMsg)
+	}
+
+	for _, changeType := range webhook.Types {
+		if !webhookTypes[changeType] {
+			errorMsg := fmt.Sprintf("Invalid change type: %s", changeType)
+			return httputil.BadRequest(errorMsg)
+		}
+	}
+
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretBytes)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+// WebhookNotifier is a ChangeListener which queues changes in memory for the WebhookWorker,
+// which turns them into deliveries so that no deliveries are written in the request path.
+type WebhookNotifier interface {
+	ChangeListener
+	Queued() <-chan struct{}
+	Take() []models.Change
+}
+
+// NewWebhookNotifier creates a new WebhookNotifier using the default implementation.
+func NewWebhookNotifier() WebhookNotifier {
+	return &webhookNotifier{
+		queued: make(chan struct{}, 1),
+	}
+}
+
+type webhookNotifier struct {
+	mu      sync.Mutex
+	changes []models.Change
+	queued  chan struct{}
+}
+
+func (n *webhookNotifier) OnChange(ctx *context.Context, change models.Change) {
+	n.mu.Lock()
+	n.changes = append(n.changes, change)
+	n.mu.Unlock()
+
+	select {
+	case n.queued <- struct{}{}:
+	default:
+	}
+}
+
+// Queued signals that changes have been queued since they were last taken.
+func (n *webhookNotifier) Queued() <-chan struct{} {
+	return n.queued
+}
+
+// Take removes and returns the queued changes.
+func (n *webhookNotifier) Take() []models.Change {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	changes := n.changes
+	n.changes = nil
+	return changes
+}
+
+func subscribesTo(webhook models.Webhook, changeType string) bool {
+	if len(webhook.Types) == 0 {
+		return true
+	}
+
+	for _, t := range webhook.Types {
+		if t == changeType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func webhookEvent(change models.Change) string {
+	return fmt.Sprintf("%s.%s", change.Type, change.Action)
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"bytes"
+	stdctx "context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+)
+
+// Headers sent with webhook deliveries.
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+const (
+	deliveryBatchSize = 100
+	maxRetryBackoff   = time.Hour
+)
+
+// WebhookConfig configuration of webhook deliveries.
+type WebhookConfig struct {
+	PollInterval time.Duration
+	MaxAttempts  int
+	RetryBackoff time.Duration
+	Timeout      time.Duration
+}
+
+// WebhookWorker saves deliveries of the changes queued by the WebhookNotifier
+// and delivers them in the background.
+type WebhookWorker interface {
+	Run(ctx *context.Context)
+	DeliverDue(ctx *context.Context) int
+}
+
+// NewWebhookWorker creates a new WebhookWorker using the default implementation.
+func NewWebhookWorker(webhookRepo repository.WebhookRepository, notifier WebhookNotifier, cfg WebhookConfig) WebhookWorker {
+	return &webhookWorker{
+		webhookRepo: webhookRepo,
+		notifier:    notifier,
+		cfg:         cfg,
+		client:      &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+type webhookWorker struct {
+	webhookRepo repository.WebhookRepository
+	notifier    WebhookNotifier
+	cfg         WebhookConfig
+	client      *http.Client
+}
+
+// Run saves deliveries of queued changes as they arrive and delivers due deliveries
+// every poll interval until the context is done. Changes queued by then are saved
+// before returning so that they are delivered after a restart.
+func (w *webhookWorker) Run(ctx *context.Context) {
+	log.Infow("Starting webhook worker", "pollInterval", w.cfg.PollInterval.String())
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.queueDeliveries(context.New(stdctx.Background(), ctx.ID, ""))
+			log.Info("Stopped webhook worker")
+			return
+		case <-w.notifier.Queued():
+			w.queueDeliveries(ctx)
+		case <-ticker.C:
+			w.DeliverDue(ctx)
+		}
+	}
+}
+
+// DeliverDue saves deliveries of queued changes, then claims and attempts all due
+// deliveries and returns the number of attempts made. Claimed deliveries are leased
+// for long enough to attempt the whole batch so that other instances skip them.
+func (w *webhookWorker) DeliverDue(ctx *context.Context) int {
+	w.queueDeliveries(ctx)
+
+	now := time.Now().UTC()
+	leaseUntil := now.Add(w.cfg.Timeout * (deliveryBatchSize + 1))
+	deliveries, err := w.webhookRepo.ClaimDueDeliveries(ctx, now, leaseUntil, deliveryBatchSize)
+	if err != nil {
+		log.Errorw("Failed to claim due webhook deliveries", "error", err, "ctx", ctx)
+		return 0
+	}
+
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return 0
+		}
+
+		w.attempt(ctx, delivery)
+	}
+
+	return len(deliveries)
+}
+
+// queueDeliveries saves a pending delivery of each queued change to every subscribing webhook.
+// Languages are shared between projects so language changes are delivered to the webhooks of all projects.
+func (w *webhookWorker) queueDeliveries(ctx *context.Context) {
+	changes := w.notifier.Take()
+	if len(changes) == 0 {
+		return
+	}
+
+	subscribers := make(map[string][]models.Webhook)
+	deliveries := make([]models.WebhookDelivery, 0, len(changes))
+	now := time.Now().UTC()
+	for _, change := range changes {
+		project := change.Project
+		if change.Type == models.LanguageChange {
+			project = ""
+		}
+
+		webhooks, ok := subscribers[project]
+		if !ok {
+			var err error
+			webhooks, err = w.webhookRepo.FindSubscribers(ctx, project)
+			if err != nil {
+				log.Errorw("Failed to find webhook subscribers", "project", project, "error", err, "ctx", ctx)
+				continue
+			}
+			subscribers[project] = webhooks
+		}
+
+		payload, err := json.Marshal(change)
+		if err != nil {
+			log.Errorw("Failed to serialize change", "error", err, "ctx", ctx)
+			continue
+		}
+
+		for _, webhook := range webhooks {
+			if !subscribesTo(webhook, change.Type) {
+				continue
+			}
+
+			deliveries = append(deliveries, models.WebhookDelivery{
+				WebhookID:     webhook.ID,
+				Event:         webhookEvent(change),
+				Payload:       string(payload),
+				Status:        models.DeliveryPending,
+				NextAttemptAt: now,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			})
+		}
+	}
+
+	err := w.webhookRepo.SaveDeliveries(ctx, deliveries)
+	if err != nil {
+		log.Errorw("Failed to queue webhook deliveries", "count", len(deliveries), "error", err, "ctx", ctx)
+	}
+}
+
+func (w *webhookWorker) attempt(ctx *context.Context, delivery models.WebhookDelivery) {
+	log.Debugw("webhookWorker.attempt", "deliveryId", delivery.ID, "webhookId", delivery.WebhookID, "ctx", ctx)
+	code, err := w.send(ctx, delivery)
+
+	now := time.Now().UTC()
+	delivery.Attempts++
+	delivery.ResponseCode = code
+	delivery.UpdatedAt = now
+	delivery.Error = ""
+
+	if err == nil {
+		delivery.Status = models.DeliveryDelivered
+	} else if delivery.Attempts >= w.cfg.MaxAttempts {
+		delivery.Status = models.DeliveryFailed
+		delivery.Error = err.Error()
+	} else {
+		delivery.Error = err.Error()
+		delivery.NextAttemptAt = now.Add(retryBackoff(w.cfg.RetryBackoff, delivery.Attempts))
+	}
+
+	err = w.webhookRepo.UpdateDelivery(ctx, delivery)
+	if err != nil {
+		log.Errorw("Failed to update webhook delivery", "deliveryId", delivery.ID, "error", err, "ctx", ctx)
+	}
+}
+
+func (w *webhookWorker) send(ctx *context.Context, delivery models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, delivery.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, delivery.Event)
+	req.Header.Set(WebhookDeliveryHeader, strconv.Itoa(delivery.ID))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(delivery.Secret, []byte(delivery.Payload)))
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("Unexpected response status: %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+// SignWebhookPayload creates the signature sent with a webhook payload,
+// the hex encoded HMAC-SHA256 of the payload prefixed by the algorithm.
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryBackoff doubles the base backoff for each failed attempt up to maxRetryBackoff.
+func retryBackoff(base time.Duration, attempts int) time.Duration {
+	backoff := base
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+
+	return backoff
+}
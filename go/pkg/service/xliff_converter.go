@@ -0,0 +1,243 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/CzarSimon/text-service/go/pkg/models"
+	"github.com/CzarSimon/text-service/go/pkg/repository"
+	"github.com/CzarSimon/text-service/go/pkg/utils/context"
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+	"github.com/CzarSimon/text-service/go/pkg/utils/xliff"
+)
+
+// XLIFFConverter interface for exchanging texts with translators as XLIFF documents.
+type XLIFFConverter interface {
+	Export(ctx *context.Context, version, source, target, groupID string) (xliff.Document, error)
+	Import(ctx *context.Context, doc xliff.Document) (models.XLIFFImport, error)
+}
+
+// NewXLIFFConverter creates a new XLIFFConverter using the default implementation.
+// Changes are reported to the listener.
+func NewXLIFFConverter(
+	languageRepo repository.LanguageRepository,
+	textRepo repository.TextRepository,
+	groupRepo repository.GroupRepository,
+	listener ChangeListener) XLIFFConverter {
+	return &xliffConverter{
+		languageRepo: languageRepo,
+		textRepo:     textRepo,
+		groupRepo:    groupRepo,
+		listener:     listener,
+	}
+}
+
+type xliffConverter struct {
+	languageRepo repository.LanguageRepository
+	textRepo     repository.TextRepository
+	groupRepo    repository.GroupRepository
+	listener     ChangeListener
+}
+
+// Export creates a document with a unit for each text in the source language, optionally
+// restricted to the members of a group, along with its value in the target language if translated.
+func (x *xliffConverter) Export(ctx *context.Context, version, source, target, groupID string) (xliff.Document, error) {
+	log.Debugw("xliffConverter.Export", "source", source, "target", target, "groupId", groupID, "ctx", ctx)
+	if version != xliff.Version12 && version != xliff.Version20 {
+		errorMsg := fmt.Sprintf("Unsupported XLIFF version: %s", version)
+		return xliff.Document{}, httputil.BadRequest(errorMsg)
+	}
+
+	err := x.assertLanguagePair(ctx, source, target)
+	if err != nil {
+		return xliff.Document{}, err
+	}
+
+	texts, err := x.findTexts(ctx, groupID)
+	if err != nil {
+		return xliff.Document{}, err
+	}
+
+	doc := xliff.Document{
+		Version:        version,
+		SourceLanguage: source,
+		TargetLanguage: target,
+		Units:          make([]xliff.Unit, 0),
+	}
+	for _, key := range sortedKeys(texts) {
+		values := texts[key]
+		if value, ok := values[source]; ok {
+			doc.Units = append(doc.Units, xliff.Unit{ID: key, Source: value, Target: values[target]})
+		}
+	}
+
+	return doc, nil
+}
+
+// Import updates the target texts of translated units which differ from the current values.
+// The document is rejected if the source of any unit no longer matches the current source text,
+// as the translation may then be outdated, or if any target is not a valid message. The sources
+// of the stored units are checked again in the transaction storing their targets.
+func (x *xliffConverter) Import(ctx *context.Context, doc xliff.Document) (models.XLIFFImport, error) {
+	log.Debugw("xliffConverter.Import", "source", doc.SourceLanguage, "target", doc.TargetLanguage, "units", len(doc.Units), "ctx", ctx)
+	err := x.assertLanguagePair(ctx, doc.SourceLanguage, doc.TargetLanguage)
+	if err != nil {
+		return models.XLIFFImport{}, err
+	}
+
+	texts, err := x.findTexts(ctx, "")
+	if err != nil {
+		return models.XLIFFImport{}, err
+	}
+
+	result := models.XLIFFImport{
+		SourceLanguage: doc.SourceLanguage,
+		TargetLanguage: doc.TargetLanguage,
+		Created:        make([]string, 0),
+		Updated:        make([]string, 0),
+		Untranslated:   make([]string, 0),
+	}
+	mismatched := make([]string, 0)
+	changed := make([]models.TranslatedText, 0)
+	sources := make([]models.TextCondition, 0)
+	for _, unit := range doc.Units {
+		values := texts[unit.ID]
+		if source, ok := values[doc.SourceLanguage]; !ok || source != unit.Source {
+			mismatched = append(mismatched, unit.ID)
+			continue
+		}
+
+		if !unit.Translated() {
+			result.Untranslated = append(result.Untranslated, unit.ID)
+			continue
+		}
+
+		if values[doc.TargetLanguage] == unit.Target {
+			result.Unchanged++
+			continue
+		}
+
+		text := models.TranslatedText{Key: unit.ID, Language: doc.TargetLanguage, Value: unit.Target}
+		err = validateText(ctx, x.languageRepo, text)
+		if err != nil {
+			return models.XLIFFImport{}, err
+		}
+
+		changed = append(changed, text)
+		sources = append(sources, models.TextCondition{Key: unit.ID, Language: doc.SourceLanguage, Value: unit.Source})
+	}
+
+	if len(mismatched) > 0 {
+		errorMsg := fmt.Sprintf("Source text no longer matches the current value. units=%s", strings.Join(mismatched, ","))
+		return models.XLIFFImport{}, httputil.Conflict(errorMsg)
+	}
+
+	if len(changed) == 0 {
+		return result, nil
+	}
+
+	actions, err := x.textRepo.UpsertAll(ctx, changed, sources)
+	if err == repository.ErrConflict {
+		return models.XLIFFImport{}, httputil.Conflict("Source texts were changed during the import")
+	}
+	if err != nil {
+		log.Errorw("Failed to upsert imported texts", "error", err, "ctx", ctx)
+		return models.XLIFFImport{}, httputil.ErrInternalServerError
+	}
+
+	for i, text := range changed {
+		if actions[i] == models.Created {
+			result.Created = append(result.Created, text.Key)
+		} else {
+			result.Updated = append(result.Updated, text.Key)
+		}
+		notify(ctx, x.listener, textChange(actions[i], text))
+	}
+
+	return result, nil
+}
+
+func (x *xliffConverter) assertLanguagePair(ctx *context.Context, source, target string) error {
+	if source == "" || target == "" {
+		return httputil.BadRequest("Both a source and a target language must be specified")
+	}
+
+	if source == target {
+		return httputil.BadRequest("Source and target languages must differ")
+	}
+
+	err := assertLanguageSupported(ctx, x.languageRepo, source)
+	if err != nil {
+		return err
+	}
+
+	return assertLanguageSupported(ctx, x.languageRepo, target)
+}
+
+// findTexts finds the values of each text key by language, restricted to the members of a group unless the group id is empty.
+func (x *xliffConverter) findTexts(ctx *context.Context, groupID string) (map[string]map[string]string, error) {
+	var members map[string]bool
+	if groupID != "" {
+		keys, err := findGroupKeys(ctx, x.groupRepo, groupID)
+		if err != nil {
+			return nil, err
+		}
+
+		members = make(map[string]bool, len(keys))
+		for _, key := range keys {
+			members[key] = true
+		}
+	}
+
+	all, err := x.textRepo.FindAll(ctx)
+	if err != nil {
+		log.Errorw("Failed to find texts", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	texts := make(map[string]map[string]string)
+	for _, text := range all {
+		if members != nil && !members[text.Key] {
+			continue
+		}
+
+		if _, ok := texts[text.Key]; !ok {
+			texts[text.Key] = make(map[string]string)
+		}
+		texts[text.Key][text.Language] = text.Value
+	}
+
+	return texts, nil
+}
+
+// findGroupKeys finds the keys of the members of a group, or fails with not found if there is no such group.
+func findGroupKeys(ctx *context.Context, groupRepo repository.GroupRepository, groupID string) ([]string, error) {
+	_, err := groupRepo.Find(ctx, groupID)
+	if err == repository.ErrNotFound {
+		errorMsg := fmt.Sprintf("No such group: %s", groupID)
+		return nil, httputil.NotFound(errorMsg)
+	}
+	if err != nil {
+		log.Errorw("Failed to find group", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	keys, err := groupRepo.FindKeys(ctx, groupID)
+	if err != nil {
+		log.Errorw("Failed to find group keys", "error", err, "ctx", ctx)
+		return nil, httputil.ErrInternalServerError
+	}
+
+	return keys, nil
+}
+
+func sortedKeys(texts map[string]map[string]string) []string {
+	keys := make([]string, 0, len(texts))
+	for key := range texts {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
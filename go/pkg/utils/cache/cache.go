@@ -0,0 +1,150 @@
+package cache
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/httputil"
+)
+
+// Cache size bounded least recently used cache where entries expire after a ttl.
+type Cache struct {
+	name    string
+	size    int
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	gen     uint64
+	group   Group
+}
+
+type entry struct {
+	key       string
+	value     interface{}
+	expiresAt time.Time
+}
+
+// New creates a new cache holding at most size entries for the duration of the ttl.
+func New(name string, size int, ttl time.Duration) *Cache {
+	return &Cache{
+		name:    name,
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Get gets a value from the cache.
+func (c *Cache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		httputil.RecordCacheLookup(c.name, false)
+		return nil, false
+	}
+
+	e := elem.Value.(*entry)
+	if time.Now().After(e.expiresAt) {
+		c.remove(elem)
+		httputil.RecordCacheLookup(c.name, false)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	httputil.RecordCacheLookup(c.name, true)
+	return e.value, true
+}
+
+// Set stores a value in the cache, evicting the least recently used entry if the cache is full.
+func (c *Cache) Set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(key, value)
+}
+
+// setIfGeneration stores a value unless the cache has been cleared since the
+// value started loading, as the value might then be stale.
+func (c *Cache) setIfGeneration(key string, value interface{}, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.gen != gen {
+		return
+	}
+
+	c.set(key, value)
+}
+
+func (c *Cache) set(key string, value interface{}) {
+	expiresAt := time.Now().Add(c.ttl)
+	if elem, ok := c.entries[key]; ok {
+		e := elem.Value.(*entry)
+		e.value = value
+		e.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+		httputil.RecordCacheEviction(c.name)
+	}
+}
+
+// GetOrLoad gets a value from the cache or loads and stores it if missing.
+// Concurrent loads of the same key are de-duplicated. Errors are not cached.
+func (c *Cache) GetOrLoad(key string, load func() (interface{}, error)) (interface{}, error) {
+	value, ok := c.Get(key)
+	if ok {
+		return value, nil
+	}
+
+	gen := c.generation()
+	return c.group.Do(fmt.Sprintf("%d:%s", gen, key), func() (interface{}, error) {
+		value, err := load()
+		if err != nil {
+			return nil, err
+		}
+
+		c.setIfGeneration(key, value, gen)
+		return value, nil
+	})
+}
+
+// Clear removes all entries from the cache.
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	c.gen++
+}
+
+func (c *Cache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.gen
+}
+
+// Len returns the number of entries in the cache.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *Cache) remove(elem *list.Element) {
+	e := c.order.Remove(elem).(*entry)
+	delete(c.entries, e.key)
+}
@@ -0,0 +1,106 @@
+package cache_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAndSet(t *testing.T) {
+	assert := assert.New(t)
+	c := cache.New("TestGetAndSet", 2, time.Minute)
+
+	_, ok := c.Get("a")
+	assert.False(ok)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	val, ok := c.Get("a")
+	assert.True(ok)
+	assert.Equal(1, val)
+
+	// b is the least recently used entry and should be evicted.
+	c.Set("c", 3)
+	assert.Equal(2, c.Len())
+	_, ok = c.Get("b")
+	assert.False(ok)
+	_, ok = c.Get("a")
+	assert.True(ok)
+	_, ok = c.Get("c")
+	assert.True(ok)
+
+	c.Clear()
+	assert.Equal(0, c.Len())
+	_, ok = c.Get("a")
+	assert.False(ok)
+}
+
+func TestExpiry(t *testing.T) {
+	assert := assert.New(t)
+	c := cache.New("TestExpiry", 10, 10*time.Millisecond)
+
+	c.Set("a", 1)
+	_, ok := c.Get("a")
+	assert.True(ok)
+
+	time.Sleep(20 * time.Millisecond)
+	_, ok = c.Get("a")
+	assert.False(ok)
+	assert.Equal(0, c.Len())
+}
+
+func TestGetOrLoad(t *testing.T) {
+	assert := assert.New(t)
+	c := cache.New("TestGetOrLoad", 10, time.Minute)
+
+	var loads int32
+	release := make(chan struct{})
+	load := func() (interface{}, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		return "value", nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			val, err := c.GetOrLoad("key", load)
+			assert.NoError(err)
+			assert.Equal("value", val)
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(int32(1), atomic.LoadInt32(&loads))
+
+	_, err := c.GetOrLoad("failing", func() (interface{}, error) {
+		return nil, errors.New("failed")
+	})
+	assert.Error(err)
+	_, ok := c.Get("failing")
+	assert.False(ok)
+}
+
+func TestGetOrLoadClearedWhileLoading(t *testing.T) {
+	assert := assert.New(t)
+	c := cache.New("TestGetOrLoadClearedWhileLoading", 10, time.Minute)
+
+	val, err := c.GetOrLoad("key", func() (interface{}, error) {
+		c.Clear()
+		return "stale", nil
+	})
+	assert.NoError(err)
+	assert.Equal("stale", val)
+
+	_, ok := c.Get("key")
+	assert.False(ok)
+}
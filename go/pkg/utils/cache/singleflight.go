@@ -0,0 +1,44 @@
+package cache
+
+import "sync"
+
+// Group de-duplicates concurrent calls for the same key, so that only one
+// call is in flight for a given key at a time and its result is shared.
+type Group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+type call struct {
+	wg    sync.WaitGroup
+	value interface{}
+	err   error
+}
+
+// Do executes fn once for concurrent callers with the same key and returns its result to all of them.
+func (g *Group) Do(key string, fn func() (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call)
+	}
+
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.value, c.err
+	}
+
+	c := new(call)
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.value, c.err = fn()
+	c.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return c.value, c.err
+}
@@ -3,6 +3,7 @@ package context
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 type contextKey struct{}
@@ -10,10 +11,24 @@ type contextKey struct{}
 // ContextIDKey id key for context.
 var ContextIDKey = contextKey{}
 
+// DefaultProject project used when none is specified.
+const DefaultProject = "default"
+
+// Principal authenticated caller of a request.
+type Principal struct {
+	ID   string
+	Role string
+}
+
 // Context request context.
 type Context struct {
-	ID       string
-	Language string
+	ID        string
+	Project   string
+	Language  string
+	User      string
+	Principal Principal
+	Release   string
+	Preview   bool
 	context.Context
 }
 
@@ -21,11 +36,40 @@ type Context struct {
 func New(parent context.Context, id, language string) *Context {
 	return &Context{
 		ID:       id,
+		Project:  DefaultProject,
 		Language: language,
 		Context:  context.WithValue(parent, ContextIDKey, id),
 	}
 }
 
 func (c *Context) String() string {
-	return fmt.Sprintf("Context(id=[%s], language=%s)", c.ID, c.Language)
+	return fmt.Sprintf("Context(id=[%s], project=%s, language=%s, user=%s)", c.ID, c.Project, c.Language, c.User)
+}
+
+// Detach creates a copy of the context which keeps the values of the context,
+// but is not cancelled when the context is. Used for work shared between requests.
+func (c *Context) Detach() *Context {
+	detached := *c
+	detached.Context = withoutCancel{parent: c.Context}
+	return &detached
+}
+
+type withoutCancel struct {
+	parent context.Context
+}
+
+func (withoutCancel) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (withoutCancel) Done() <-chan struct{} {
+	return nil
+}
+
+func (withoutCancel) Err() error {
+	return nil
+}
+
+func (c withoutCancel) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
 }
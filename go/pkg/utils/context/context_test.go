@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	myCtx "github.com/CzarSimon/text-service/go/pkg/utils/context"
 	"github.com/CzarSimon/text-service/go/pkg/utils/id"
@@ -19,6 +20,7 @@ func TestNew(t *testing.T) {
 	var stdctx context.Context = ctx
 
 	assert.Equal(ctxID, ctx.ID)
+	assert.Equal(myCtx.DefaultProject, ctx.Project)
 
 	val := stdctx.Value(myCtx.ContextIDKey)
 	stdctxID, ok := val.(string)
@@ -26,3 +28,24 @@ func TestNew(t *testing.T) {
 
 	assert.Equal(ctx.ID, stdctxID)
 }
+
+func TestDetach(t *testing.T) {
+	assert := assert.New(t)
+	parent, cancel := context.WithTimeout(context.Background(), time.Minute)
+
+	ctx := myCtx.New(parent, id.New(), "sv")
+	ctx.Project = "other-project"
+	detached := ctx.Detach()
+	cancel()
+
+	assert.Error(ctx.Err())
+	assert.NoError(detached.Err())
+	assert.Nil(detached.Done())
+	_, ok := detached.Deadline()
+	assert.False(ok)
+
+	assert.Equal(ctx.ID, detached.ID)
+	assert.Equal("other-project", detached.Project)
+	assert.Equal("sv", detached.Language)
+	assert.Equal(ctx.ID, detached.Value(myCtx.ContextIDKey))
+}
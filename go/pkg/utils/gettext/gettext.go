@@ -0,0 +1,69 @@
+// Package gettext reads and writes gettext catalogs, both textual .po files
+// and compiled .mo files, and evaluates Plural-Forms expressions.
+package gettext
+
+import (
+	"strings"
+)
+
+// Message catalog entry. Str holds a single translation or one translation
+// per plural form when IDPlural is set.
+type Message struct {
+	Context            string
+	ID                 string
+	IDPlural           string
+	Str                []string
+	TranslatorComments []string
+	ExtractedComments  []string
+	References         []string
+	Flags              []string
+}
+
+// Key identifies the message in a catalog, the context and id separated by EOT as in .mo files.
+func (m Message) Key() string {
+	if m.Context == "" {
+		return m.ID
+	}
+
+	return m.Context + "\x04" + m.ID
+}
+
+// Translated checks if the message has a non empty translation.
+func (m Message) Translated() bool {
+	for _, str := range m.Str {
+		if str != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasFlag checks if the message is marked with a flag, e.g. fuzzy.
+func (m Message) HasFlag(flag string) bool {
+	for _, f := range m.Flags {
+		if f == flag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// File gettext catalog. The header is the translation of the empty msgid.
+type File struct {
+	Header   string
+	Messages []Message
+}
+
+// HeaderField gets the value of a header field, e.g. Plural-Forms.
+func (f File) HeaderField(name string) string {
+	prefix := strings.ToLower(name) + ":"
+	for _, line := range strings.Split(f.Header, "\n") {
+		if strings.HasPrefix(strings.ToLower(line), prefix) {
+			return strings.TrimSpace(line[len(prefix):])
+		}
+	}
+
+	return ""
+}
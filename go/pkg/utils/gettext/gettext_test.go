@@ -0,0 +1,151 @@
+package gettext_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/gettext"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPO = `# Swedish translations.
+msgid ""
+msgstr ""
+"Language: sv\n"
+"Plural-Forms: nplurals=2; plural=(n != 1);\n"
+
+# Shown on the start page.
+#. Greeting in the header
+#: src/app.js:10 src/app.js:12
+#, fuzzy, javascript-format
+msgctxt "HEADER"
+msgid "Hello"
+msgstr "Hej"
+
+msgid "Line one\n"
+"line \"two\""
+msgstr ""
+"Rad ett\n"
+"rad \"två\""
+
+msgid "One file"
+msgid_plural "%d files"
+msgstr[0] "En fil"
+msgstr[1] "%d filer"
+
+#~ msgid "Obsolete"
+#~ msgstr "Föråldrad"
+`
+
+func TestParsePO(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := gettext.ParsePO(strings.NewReader(testPO))
+	assert.NoError(err)
+	assert.Equal("sv", f.HeaderField("Language"))
+	assert.Equal("nplurals=2; plural=(n != 1);", f.HeaderField("plural-forms"))
+	assert.Len(f.Messages, 3)
+
+	msg := f.Messages[0]
+	assert.Equal("HEADER", msg.Context)
+	assert.Equal("Hello", msg.ID)
+	assert.Equal("HEADER\x04Hello", msg.Key())
+	assert.Equal([]string{"Hej"}, msg.Str)
+	assert.Equal([]string{"Shown on the start page."}, msg.TranslatorComments)
+	assert.Equal([]string{"Greeting in the header"}, msg.ExtractedComments)
+	assert.Equal([]string{"src/app.js:10", "src/app.js:12"}, msg.References)
+	assert.Equal([]string{"fuzzy", "javascript-format"}, msg.Flags)
+	assert.True(msg.HasFlag("fuzzy"))
+
+	msg = f.Messages[1]
+	assert.Equal("Line one\nline \"two\"", msg.ID)
+	assert.Equal([]string{"Rad ett\nrad \"två\""}, msg.Str)
+
+	msg = f.Messages[2]
+	assert.Equal("%d files", msg.IDPlural)
+	assert.Equal([]string{"En fil", "%d filer"}, msg.Str)
+
+	_, err = gettext.ParsePO(strings.NewReader("msgid \"a\"\nmsgstr[1] \"b\"\n"))
+	assert.Error(err)
+	_, err = gettext.ParsePO(strings.NewReader("msgid \"unterminated\n"))
+	assert.Error(err)
+}
+
+func TestWritePORoundtrip(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := gettext.ParsePO(strings.NewReader(testPO))
+	assert.NoError(err)
+
+	var buf bytes.Buffer
+	err = gettext.WritePO(&buf, f)
+	assert.NoError(err)
+	assert.Contains(buf.String(), "#, fuzzy, javascript-format\nmsgctxt \"HEADER\"\n")
+	assert.Contains(buf.String(), "msgstr \"\"\n\"Rad ett\\n\"\n\"rad \\\"två\\\"\"\n")
+
+	parsed, err := gettext.ParsePO(&buf)
+	assert.NoError(err)
+	assert.Equal(f, parsed)
+}
+
+func TestMORoundtrip(t *testing.T) {
+	assert := assert.New(t)
+
+	f, err := gettext.ParsePO(strings.NewReader(testPO))
+	assert.NoError(err)
+
+	var buf bytes.Buffer
+	err = gettext.WriteMO(&buf, f)
+	assert.NoError(err)
+
+	assert.True(gettext.IsMO(buf.Bytes()))
+	assert.False(gettext.IsMO([]byte(testPO)))
+
+	parsed, err := gettext.ParseMO(&buf)
+	assert.NoError(err)
+	assert.Equal(f.Header, parsed.Header)
+	// The fuzzy message is left out and the rest are sorted by their original strings.
+	assert.Equal([]gettext.Message{
+		{ID: "Line one\nline \"two\"", Str: []string{"Rad ett\nrad \"två\""}},
+		{ID: "One file", IDPlural: "%d files", Str: []string{"En fil", "%d filer"}},
+	}, parsed.Messages)
+
+	_, err = gettext.ParseMO(strings.NewReader("not a mo file at all, really"))
+	assert.Equal(gettext.ErrInvalidMO, err)
+}
+
+func TestPluralForms(t *testing.T) {
+	assert := assert.New(t)
+
+	russian := "nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);"
+	tests := []struct {
+		header   string
+		n        int
+		expected int
+	}{
+		{header: "nplurals=2; plural=(n != 1);", n: 1, expected: 0},
+		{header: "nplurals=2; plural=(n != 1);", n: 0, expected: 1},
+		{header: "nplurals=2; plural=n>1;", n: 1, expected: 0},
+		{header: "nplurals=2; plural=n>1;", n: 2, expected: 1},
+		{header: "nplurals=1; plural=0;", n: 5, expected: 0},
+		{header: russian, n: 1, expected: 0},
+		{header: russian, n: 22, expected: 1},
+		{header: russian, n: 11, expected: 2},
+		{header: russian, n: 25, expected: 2},
+		{header: "nplurals=2; plural=!(n == 1);", n: 1, expected: 0},
+		{header: "nplurals=2; plural=n+5;", n: 1, expected: 0},
+	}
+
+	for _, test := range tests {
+		forms, err := gettext.ParsePluralForms(test.header)
+		assert.NoError(err, test.header)
+		assert.Equal(test.expected, forms.Index(test.n), "%s n=%d", test.header, test.n)
+	}
+
+	assert.Equal(1, gettext.DefaultPluralForms.Index(2))
+	for _, header := range []string{"", "nplurals=2;", "nplurals=x; plural=n;", "nplurals=2; plural=(n;", "nplurals=2; plural=n ? 1;"} {
+		_, err := gettext.ParsePluralForms(header)
+		assert.Error(err, header)
+	}
+}
@@ -0,0 +1,151 @@
+package gettext
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+const (
+	moMagic      = 0x950412de
+	moHeaderSize = 28
+)
+
+// ErrInvalidMO returned when a file is not a valid .mo file.
+var ErrInvalidMO = errors.New("invalid mo file")
+
+// WriteMO writes a catalog as a little endian .mo file. Untranslated and fuzzy
+// messages are left out, as are comments which .mo files have no place for.
+func WriteMO(w io.Writer, f File) error {
+	type pair struct{ original, translation string }
+	pairs := []pair{{original: "", translation: f.Header}}
+	for _, msg := range f.Messages {
+		if !msg.Translated() || msg.HasFlag("fuzzy") {
+			continue
+		}
+
+		original := msg.Key()
+		if msg.IDPlural != "" {
+			original += "\x00" + msg.IDPlural
+		}
+		pairs = append(pairs, pair{original: original, translation: strings.Join(msg.Str, "\x00")})
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].original < pairs[j].original
+	})
+
+	n := uint32(len(pairs))
+	originalTable := uint32(moHeaderSize)
+	translationTable := originalTable + 8*n
+	offset := translationTable + 8*n
+
+	table := make([]uint32, 0, 4*n)
+	var data bytes.Buffer
+	for _, p := range pairs {
+		table = append(table, uint32(len(p.original)), offset+uint32(data.Len()))
+		data.WriteString(p.original + "\x00")
+	}
+	for _, p := range pairs {
+		table = append(table, uint32(len(p.translation)), offset+uint32(data.Len()))
+		data.WriteString(p.translation + "\x00")
+	}
+
+	header := []uint32{moMagic, 0, n, originalTable, translationTable, 0, offset}
+	for _, values := range [][]uint32{header, table} {
+		err := binary.Write(w, binary.LittleEndian, values)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := w.Write(data.Bytes())
+	return err
+}
+
+// IsMO checks if data starts with the magic number of .mo files.
+func IsMO(data []byte) bool {
+	if len(data) < 4 {
+		return false
+	}
+
+	return binary.LittleEndian.Uint32(data) == moMagic || binary.BigEndian.Uint32(data) == moMagic
+}
+
+// ParseMO parses a .mo file of either byte order.
+func ParseMO(r io.Reader) (File, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return File{}, err
+	}
+	if len(data) < moHeaderSize {
+		return File{}, ErrInvalidMO
+	}
+
+	var order binary.ByteOrder
+	switch {
+	case binary.LittleEndian.Uint32(data) == moMagic:
+		order = binary.LittleEndian
+	case binary.BigEndian.Uint32(data) == moMagic:
+		order = binary.BigEndian
+	default:
+		return File{}, ErrInvalidMO
+	}
+
+	if revision := order.Uint32(data[4:]) >> 16; revision > 1 {
+		return File{}, fmt.Errorf("%v: unsupported revision %d", ErrInvalidMO, revision)
+	}
+
+	n := order.Uint32(data[8:])
+	originalTable := order.Uint32(data[12:])
+	translationTable := order.Uint32(data[16:])
+	readString := func(table, i uint32) (string, error) {
+		entry := uint64(table) + 8*uint64(i)
+		if entry+8 > uint64(len(data)) {
+			return "", ErrInvalidMO
+		}
+
+		length := uint64(order.Uint32(data[entry:]))
+		offset := uint64(order.Uint32(data[entry+4:]))
+		if offset+length > uint64(len(data)) {
+			return "", ErrInvalidMO
+		}
+
+		return string(data[offset : offset+length]), nil
+	}
+
+	f := File{Messages: make([]Message, 0, n)}
+	for i := uint32(0); i < n; i++ {
+		original, err := readString(originalTable, i)
+		if err != nil {
+			return File{}, err
+		}
+
+		translation, err := readString(translationTable, i)
+		if err != nil {
+			return File{}, err
+		}
+
+		if original == "" {
+			f.Header = translation
+			continue
+		}
+
+		msg := Message{Str: strings.Split(translation, "\x00")}
+		if j := strings.IndexByte(original, '\x04'); j >= 0 {
+			msg.Context, original = original[:j], original[j+1:]
+		}
+		if j := strings.IndexByte(original, '\x00'); j >= 0 {
+			msg.IDPlural = original[j+1:]
+			original = original[:j]
+		}
+		msg.ID = original
+		f.Messages = append(f.Messages, msg)
+	}
+
+	return f, nil
+}
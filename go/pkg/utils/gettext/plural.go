@@ -0,0 +1,295 @@
+package gettext
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PluralForms parsed Plural-Forms header, e.g. "nplurals=2; plural=(n != 1);".
+type PluralForms struct {
+	N    int
+	expr expr
+}
+
+// DefaultPluralForms the germanic plural forms assumed when a catalog has no Plural-Forms header.
+var DefaultPluralForms = PluralForms{N: 2, expr: binaryExpr{op: "!=", left: variable{}, right: constant(1)}}
+
+// ParsePluralForms parses the value of a Plural-Forms header.
+func ParsePluralForms(header string) (PluralForms, error) {
+	var forms PluralForms
+	var plural string
+	for _, part := range strings.Split(header, ";") {
+		i := strings.IndexByte(part, '=')
+		if i < 0 {
+			continue
+		}
+
+		name, value := strings.TrimSpace(part[:i]), strings.TrimSpace(part[i+1:])
+		switch name {
+		case "nplurals":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return PluralForms{}, fmt.Errorf("invalid nplurals: %s", value)
+			}
+			forms.N = n
+		case "plural":
+			plural = value
+		}
+	}
+
+	if forms.N == 0 || plural == "" {
+		return PluralForms{}, fmt.Errorf("invalid plural forms: %s", header)
+	}
+
+	p := &exprParser{input: plural}
+	e, err := p.parse()
+	if err != nil {
+		return PluralForms{}, fmt.Errorf("invalid plural expression %q: %v", plural, err)
+	}
+
+	forms.expr = e
+	return forms, nil
+}
+
+// Index gets the index of the plural form used for the number n.
+func (p PluralForms) Index(n int) int {
+	i := p.expr.eval(n)
+	if i < 0 || i >= p.N {
+		return 0
+	}
+
+	return i
+}
+
+type expr interface {
+	eval(n int) int
+}
+
+type variable struct{}
+
+func (variable) eval(n int) int { return n }
+
+type constant int
+
+func (c constant) eval(int) int { return int(c) }
+
+type notExpr struct{ operand expr }
+
+func (e notExpr) eval(n int) int { return boolToInt(e.operand.eval(n) == 0) }
+
+type conditionalExpr struct{ condition, then, otherwise expr }
+
+func (e conditionalExpr) eval(n int) int {
+	if e.condition.eval(n) != 0 {
+		return e.then.eval(n)
+	}
+
+	return e.otherwise.eval(n)
+}
+
+type binaryExpr struct {
+	op          string
+	left, right expr
+}
+
+func (e binaryExpr) eval(n int) int {
+	l := e.left.eval(n)
+	switch e.op {
+	case "||":
+		return boolToInt(l != 0 || e.right.eval(n) != 0)
+	case "&&":
+		return boolToInt(l != 0 && e.right.eval(n) != 0)
+	}
+
+	r := e.right.eval(n)
+	switch e.op {
+	case "==":
+		return boolToInt(l == r)
+	case "!=":
+		return boolToInt(l != r)
+	case "<":
+		return boolToInt(l < r)
+	case "<=":
+		return boolToInt(l <= r)
+	case ">":
+		return boolToInt(l > r)
+	case ">=":
+		return boolToInt(l >= r)
+	case "+":
+		return l + r
+	case "-":
+		return l - r
+	case "*":
+		return l * r
+	case "/", "%":
+		if r == 0 {
+			return 0
+		}
+		if e.op == "/" {
+			return l / r
+		}
+		return l % r
+	}
+
+	return 0
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// binaryPrecedence operators of the C subset used in plural expressions, from lowest to highest precedence.
+var binaryPrecedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<=", ">=", "<", ">"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+// exprParser recursive descent parser of plural expressions.
+type exprParser struct {
+	input string
+	pos   int
+}
+
+func (p *exprParser) parse() (expr, error) {
+	e, err := p.parseConditional()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if p.pos != len(p.input) {
+		return nil, fmt.Errorf("unexpected %q at %d", p.input[p.pos:], p.pos)
+	}
+
+	return e, nil
+}
+
+func (p *exprParser) parseConditional() (expr, error) {
+	condition, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.consume("?") {
+		return condition, nil
+	}
+
+	then, err := p.parseConditional()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.consume(":") {
+		return nil, fmt.Errorf("expected : at %d", p.pos)
+	}
+
+	otherwise, err := p.parseConditional()
+	if err != nil {
+		return nil, err
+	}
+
+	return conditionalExpr{condition: condition, then: then, otherwise: otherwise}, nil
+}
+
+func (p *exprParser) parseBinary(level int) (expr, error) {
+	if level == len(binaryPrecedence) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := p.consumeAny(binaryPrecedence[level])
+		if op == "" {
+			return left, nil
+		}
+
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseUnary() (expr, error) {
+	if p.consume("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{operand: operand}, nil
+	}
+
+	if p.consume("(") {
+		e, err := p.parseConditional()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, fmt.Errorf("expected ) at %d", p.pos)
+		}
+		return e, nil
+	}
+
+	if p.consume("n") {
+		return variable{}, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		return nil, fmt.Errorf("unexpected %q at %d", p.input[p.pos:], p.pos)
+	}
+
+	value, err := strconv.Atoi(p.input[start:p.pos])
+	if err != nil {
+		return nil, err
+	}
+
+	return constant(value), nil
+}
+
+func (p *exprParser) consumeAny(tokens []string) string {
+	for _, token := range tokens {
+		if p.consume(token) {
+			return token
+		}
+	}
+
+	return ""
+}
+
+func (p *exprParser) peek(token string) bool {
+	p.skipSpace()
+	return strings.HasPrefix(p.input[p.pos:], token)
+}
+
+func (p *exprParser) consume(token string) bool {
+	if !p.peek(token) {
+		return false
+	}
+
+	p.pos += len(token)
+	return true
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
+		p.pos++
+	}
+}
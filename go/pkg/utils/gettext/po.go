@@ -0,0 +1,265 @@
+package gettext
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParsePO parses a .po file. Obsolete (#~) entries and previous strings (#|) are ignored.
+func ParsePO(r io.Reader) (File, error) {
+	p := &poParser{file: File{Messages: make([]Message, 0)}}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		p.line++
+		err := p.parseLine(strings.TrimSpace(scanner.Text()))
+		if err != nil {
+			return File{}, err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return File{}, err
+	}
+
+	p.flush()
+	return p.file, nil
+}
+
+type poParser struct {
+	file    File
+	msg     Message
+	started bool
+	target  *string
+	line    int
+}
+
+func (p *poParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
+
+func (p *poParser) parseLine(line string) error {
+	switch {
+	case line == "":
+		p.flush()
+	case strings.HasPrefix(line, "#~"), strings.HasPrefix(line, "#|"):
+	case strings.HasPrefix(line, "#"):
+		p.parseComment(line)
+	case strings.HasPrefix(line, `"`):
+		if p.target == nil {
+			return p.errorf("unexpected string continuation")
+		}
+
+		s, err := unquote(line)
+		if err != nil {
+			return p.errorf("%s", err)
+		}
+		*p.target += s
+	default:
+		return p.parseKeyword(line)
+	}
+
+	return nil
+}
+
+func (p *poParser) parseComment(line string) {
+	if p.started && p.target != nil {
+		p.flush()
+	}
+
+	if len(line) == 1 {
+		p.msg.TranslatorComments = append(p.msg.TranslatorComments, "")
+		return
+	}
+
+	text := strings.TrimSpace(line[2:])
+	switch line[1] {
+	case '.':
+		p.msg.ExtractedComments = append(p.msg.ExtractedComments, text)
+	case ':':
+		p.msg.References = append(p.msg.References, strings.Fields(text)...)
+	case ',':
+		for _, flag := range strings.Split(text, ",") {
+			if flag = strings.TrimSpace(flag); flag != "" {
+				p.msg.Flags = append(p.msg.Flags, flag)
+			}
+		}
+	default:
+		p.msg.TranslatorComments = append(p.msg.TranslatorComments, strings.TrimPrefix(line[1:], " "))
+	}
+}
+
+func (p *poParser) parseKeyword(line string) error {
+	i := strings.IndexAny(line, " \t")
+	if i < 0 {
+		return p.errorf("expected a keyword followed by a string")
+	}
+
+	keyword, rest := line[:i], strings.TrimSpace(line[i:])
+	value, err := unquote(rest)
+	if err != nil {
+		return p.errorf("%s", err)
+	}
+
+	if keyword == "msgctxt" || (keyword == "msgid" && p.target != nil && p.target != &p.msg.Context) {
+		p.flush()
+	}
+	p.started = true
+
+	switch {
+	case keyword == "msgctxt":
+		p.msg.Context = value
+		p.target = &p.msg.Context
+	case keyword == "msgid":
+		p.msg.ID = value
+		p.target = &p.msg.ID
+	case keyword == "msgid_plural":
+		p.msg.IDPlural = value
+		p.target = &p.msg.IDPlural
+	case keyword == "msgstr":
+		p.msg.Str = []string{value}
+		p.target = &p.msg.Str[0]
+	case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+		index, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+		if err != nil || index != len(p.msg.Str) {
+			return p.errorf("unexpected plural form: %s", keyword)
+		}
+		p.msg.Str = append(p.msg.Str, value)
+		p.target = &p.msg.Str[index]
+	default:
+		return p.errorf("unknown keyword: %s", keyword)
+	}
+
+	return nil
+}
+
+// flush ends the current message, the first message with an empty id is the header.
+func (p *poParser) flush() {
+	if !p.started {
+		return
+	}
+
+	if p.msg.ID == "" && p.msg.Context == "" && len(p.file.Messages) == 0 && p.file.Header == "" {
+		if len(p.msg.Str) > 0 {
+			p.file.Header = p.msg.Str[0]
+		}
+	} else {
+		p.file.Messages = append(p.file.Messages, p.msg)
+	}
+
+	p.msg = Message{}
+	p.started = false
+	p.target = nil
+}
+
+func unquote(s string) (string, error) {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("expected a quoted string: %s", s)
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(s)-1; i++ {
+		c := s[i]
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+
+		i++
+		if i >= len(s)-1 {
+			return "", fmt.Errorf("unterminated escape sequence: %s", s)
+		}
+
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '"', '\\':
+			b.WriteByte(s[i])
+		default:
+			return "", fmt.Errorf("unsupported escape sequence \\%c", s[i])
+		}
+	}
+
+	return b.String(), nil
+}
+
+// WritePO writes a catalog as a .po file.
+func WritePO(w io.Writer, f File) error {
+	bw := bufio.NewWriter(w)
+	writePOString(bw, "msgid", "")
+	writePOString(bw, "msgstr", f.Header)
+
+	for _, msg := range f.Messages {
+		bw.WriteString("\n")
+		for _, comment := range msg.TranslatorComments {
+			if comment == "" {
+				bw.WriteString("#\n")
+			} else {
+				bw.WriteString("# " + comment + "\n")
+			}
+		}
+		for _, comment := range msg.ExtractedComments {
+			bw.WriteString("#. " + comment + "\n")
+		}
+		if len(msg.References) > 0 {
+			bw.WriteString("#: " + strings.Join(msg.References, " ") + "\n")
+		}
+		if len(msg.Flags) > 0 {
+			bw.WriteString("#, " + strings.Join(msg.Flags, ", ") + "\n")
+		}
+
+		if msg.Context != "" {
+			writePOString(bw, "msgctxt", msg.Context)
+		}
+		writePOString(bw, "msgid", msg.ID)
+		if msg.IDPlural == "" {
+			str := ""
+			if len(msg.Str) > 0 {
+				str = msg.Str[0]
+			}
+			writePOString(bw, "msgstr", str)
+			continue
+		}
+
+		writePOString(bw, "msgid_plural", msg.IDPlural)
+		forms := msg.Str
+		if len(forms) == 0 {
+			forms = []string{"", ""}
+		}
+		for i, str := range forms {
+			writePOString(bw, fmt.Sprintf("msgstr[%d]", i), str)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// writePOString writes a keyword and its string, splitting multi-line strings after each newline.
+func writePOString(w *bufio.Writer, keyword, value string) {
+	lines := strings.SplitAfter(value, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) <= 1 {
+		w.WriteString(keyword + " " + quote(value) + "\n")
+		return
+	}
+
+	w.WriteString(keyword + " \"\"\n")
+	for _, line := range lines {
+		w.WriteString(quote(line) + "\n")
+	}
+}
+
+func quote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+	return `"` + r.Replace(s) + `"`
+}
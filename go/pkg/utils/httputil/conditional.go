@@ -0,0 +1,64 @@
+package httputil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Caching header keys
+const (
+	ETagHeader            = "ETag"
+	IfNoneMatchHeader     = "If-None-Match"
+	IfModifiedSinceHeader = "If-Modified-Since"
+	LastModifiedHeader    = "Last-Modified"
+	CacheControlHeader    = "Cache-Control"
+)
+
+// ETag creates a strong entity tag from a hash of the given content parts.
+func ETag(parts ...[]byte) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write(part)
+		h.Write([]byte{0})
+	}
+
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// NotModified checks if a request's conditional headers match the current
+// entity tag or last modification time, in which case a 304 Not Modified
+// response should be sent. If-Modified-Since is only considered when
+// the request has no If-None-Match header and lastModified is set.
+func NotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	ifNoneMatch := r.Header.Get(IfNoneMatchHeader)
+	if ifNoneMatch != "" {
+		return matchesETag(ifNoneMatch, etag)
+	}
+
+	ifModifiedSince := r.Header.Get(IfModifiedSinceHeader)
+	if ifModifiedSince == "" || lastModified.IsZero() {
+		return false
+	}
+
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// matchesETag weakly compares an If-None-Match header value with an entity tag.
+func matchesETag(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
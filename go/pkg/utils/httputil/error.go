@@ -15,7 +15,10 @@ var errLog = logger.GetDefaultLogger("httputil/errorLog")
 // Default errors
 var (
 	ErrBadRequest          = BadRequest("")
+	ErrUnauthorized        = Unauthorized("")
+	ErrForbidden           = Forbidden("")
 	ErrNotFound            = NotFound("")
+	ErrConflict            = Conflict("")
 	ErrInternalServerError = InternalServerError("")
 )
 
@@ -66,11 +69,26 @@ func BadRequest(message string) *Error {
 	return NewError(message, http.StatusBadRequest)
 }
 
+// Unauthorized creates a new unauthorized (401) error.
+func Unauthorized(message string) *Error {
+	return NewError(message, http.StatusUnauthorized)
+}
+
+// Forbidden creates a new forbidden (403) error.
+func Forbidden(message string) *Error {
+	return NewError(message, http.StatusForbidden)
+}
+
 // NotFound creates a new not found (404) error.
 func NotFound(message string) *Error {
 	return NewError(message, http.StatusNotFound)
 }
 
+// Conflict creates a new conflict (409) error.
+func Conflict(message string) *Error {
+	return NewError(message, http.StatusConflict)
+}
+
 // InternalServerError creates a new internal server error (500).
 func InternalServerError(message string) *Error {
 	return NewError(message, http.StatusInternalServerError)
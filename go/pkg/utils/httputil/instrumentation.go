@@ -15,8 +15,16 @@ const metricsPath = "/metrics"
 
 // Header keys
 const (
-	RequestIDHeader = "X-Request-ID"
-	AcceptLanguage  = "Accept-Language"
+	RequestIDHeader         = "X-Request-ID"
+	AcceptLanguage          = "Accept-Language"
+	ContentLanguage         = "Content-Language"
+	VaryHeader              = "Vary"
+	FallbackLanguagesHeader = "X-Fallback-Languages"
+	PreviewHeader           = "X-Preview"
+	AuthorizationHeader     = "Authorization"
+	APIKeyHeader            = "X-API-Key"
+	AcceptHeader            = "Accept"
+	ContentDisposition      = "Content-Disposition"
 )
 
 // Prometheus metrics.
@@ -35,6 +43,20 @@ var (
 		},
 		[]string{"endpoint", "method", "status"},
 	)
+	cacheRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_requests_total",
+			Help: "The total number of cache lookups",
+		},
+		[]string{"cache", "result"},
+	)
+	cacheEvictionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_evictions_total",
+			Help: "The total number of entries evicted from the cache",
+		},
+		[]string{"cache"},
+	)
 )
 
 func prometheusHandler() gin.HandlerFunc {
@@ -63,6 +85,21 @@ func Metrics() gin.HandlerFunc {
 	}
 }
 
+// RecordCacheLookup records a lookup in a cache as either a hit or a miss.
+func RecordCacheLookup(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	cacheRequestsTotal.WithLabelValues(cache, result).Inc()
+}
+
+// RecordCacheEviction records the eviction of an entry from a cache.
+func RecordCacheEviction(cache string) {
+	cacheEvictionsTotal.WithLabelValues(cache).Inc()
+}
+
 // RequestID annotates request with unique request id.
 func RequestID() gin.HandlerFunc {
 	return func(c *gin.Context) {
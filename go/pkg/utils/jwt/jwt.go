@@ -0,0 +1,238 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+// Supported signing algorithms.
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+)
+
+// Token validation errors.
+var (
+	ErrMalformed        = errors.New("malformed token")
+	ErrUnsupportedAlg   = errors.New("unsupported signing algorithm")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrExpired          = errors.New("token expired")
+	ErrNotYetValid      = errors.New("token not yet valid")
+)
+
+// Claims registered claims of a token along with the role of the subject.
+type Claims struct {
+	Subject   string `json:"sub"`
+	Role      string `json:"role,omitempty"`
+	Issuer    string `json:"iss,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	NotBefore int64  `json:"nbf,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+}
+
+// Verifier validates tokens signed with a single algorithm and key.
+type Verifier struct {
+	alg       string
+	secret    []byte
+	publicKey *rsa.PublicKey
+	now       func() time.Time
+}
+
+// NewHS256Verifier creates a Verifier for tokens signed with HMAC SHA-256 using the secret.
+func NewHS256Verifier(secret []byte) *Verifier {
+	return &Verifier{alg: HS256, secret: secret, now: time.Now}
+}
+
+// NewRS256Verifier creates a Verifier for tokens signed with RSA SHA-256 using the public key.
+func NewRS256Verifier(publicKey *rsa.PublicKey) *Verifier {
+	return &Verifier{alg: RS256, publicKey: publicKey, now: time.Now}
+}
+
+// NewVerifierFromFile creates a Verifier from a key file. A PEM encoded RSA public key
+// gives an RS256 verifier, any other content is used as an HS256 secret.
+func NewVerifierFromFile(path string) (*Verifier, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %v", err)
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		secret := []byte(strings.TrimSpace(string(content)))
+		if len(secret) == 0 {
+			return nil, errors.New("empty key file")
+		}
+
+		return NewHS256Verifier(secret), nil
+	}
+
+	publicKey, err := parsePublicKey(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRS256Verifier(publicKey), nil
+}
+
+func parsePublicKey(block *pem.Block) (*rsa.PublicKey, error) {
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %v", err)
+	}
+
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not an RSA key")
+	}
+
+	return publicKey, nil
+}
+
+// Verify checks the signature and validity period of a token and returns its claims.
+// Tokens signed with another algorithm than the verifier's are rejected.
+func (v *Verifier) Verify(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrMalformed
+	}
+
+	var h header
+	err := decodeSegment(parts[0], &h)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	if h.Alg != v.alg {
+		return Claims{}, ErrUnsupportedAlg
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+
+	err = v.verifySignature(parts[0]+"."+parts[1], signature)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	var claims Claims
+	err = decodeSegment(parts[1], &claims)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	now := v.now().Unix()
+	if claims.ExpiresAt != 0 && now >= claims.ExpiresAt {
+		return Claims{}, ErrExpired
+	}
+	if claims.NotBefore != 0 && now < claims.NotBefore {
+		return Claims{}, ErrNotYetValid
+	}
+
+	return claims, nil
+}
+
+func (v *Verifier) verifySignature(signingInput string, signature []byte) error {
+	switch v.alg {
+	case HS256:
+		if !hmac.Equal(signHMAC(signingInput, v.secret), signature) {
+			return ErrInvalidSignature
+		}
+		return nil
+	case RS256:
+		digest := sha256.Sum256([]byte(signingInput))
+		err := rsa.VerifyPKCS1v15(v.publicKey, crypto.SHA256, digest[:], signature)
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+	default:
+		return ErrUnsupportedAlg
+	}
+}
+
+// SignHS256 creates a token with the claims signed with HMAC SHA-256 using the secret.
+func SignHS256(claims Claims, secret []byte) (string, error) {
+	signingInput, err := encodeSigningInput(HS256, claims)
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + encodeSegment(signHMAC(signingInput, secret)), nil
+}
+
+// SignRS256 creates a token with the claims signed with RSA SHA-256 using the private key.
+func SignRS256(claims Claims, privateKey *rsa.PrivateKey) (string, error) {
+	signingInput, err := encodeSigningInput(RS256, claims)
+	if err != nil {
+		return "", err
+	}
+
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %v", err)
+	}
+
+	return signingInput + "." + encodeSegment(signature), nil
+}
+
+func encodeSigningInput(alg string, claims Claims) (string, error) {
+	h, err := json.Marshal(header{Alg: alg, Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	c, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	return encodeSegment(h) + "." + encodeSegment(c), nil
+}
+
+func signHMAC(signingInput string, secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
+
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return ErrMalformed
+	}
+
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		return ErrMalformed
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package jwt_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyHS256(t *testing.T) {
+	assert := assert.New(t)
+	secret := []byte("test-secret")
+	verifier := jwt.NewHS256Verifier(secret)
+
+	claims := jwt.Claims{Subject: "user-1", Role: "admin", ExpiresAt: time.Now().Add(time.Hour).Unix()}
+	token, err := jwt.SignHS256(claims, secret)
+	assert.NoError(err)
+
+	verified, err := verifier.Verify(token)
+	assert.NoError(err)
+	assert.Equal(claims, verified)
+
+	forged, err := jwt.SignHS256(claims, []byte("other-secret"))
+	assert.NoError(err)
+	_, err = verifier.Verify(forged)
+	assert.Equal(jwt.ErrInvalidSignature, err)
+
+	parts := strings.Split(token, ".")
+	_, err = verifier.Verify(parts[0] + "." + parts[1])
+	assert.Equal(jwt.ErrMalformed, err)
+
+	expired := jwt.Claims{Subject: "user-1", ExpiresAt: time.Now().Add(-time.Minute).Unix()}
+	token, err = jwt.SignHS256(expired, secret)
+	assert.NoError(err)
+	_, err = verifier.Verify(token)
+	assert.Equal(jwt.ErrExpired, err)
+
+	notYetValid := jwt.Claims{Subject: "user-1", NotBefore: time.Now().Add(time.Minute).Unix()}
+	token, err = jwt.SignHS256(notYetValid, secret)
+	assert.NoError(err)
+	_, err = verifier.Verify(token)
+	assert.Equal(jwt.ErrNotYetValid, err)
+}
+
+func TestVerifyRejectsOtherAlgorithms(t *testing.T) {
+	assert := assert.New(t)
+	verifier := jwt.NewHS256Verifier([]byte("test-secret"))
+
+	// {"alg":"none"}.{"sub":"user-1","role":"admin"}.
+	unsigned := "eyJhbGciOiJub25lIn0.eyJzdWIiOiJ1c2VyLTEiLCJyb2xlIjoiYWRtaW4ifQ."
+	_, err := verifier.Verify(unsigned)
+	assert.Equal(jwt.ErrUnsupportedAlg, err)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(err)
+	token, err := jwt.SignRS256(jwt.Claims{Subject: "user-1"}, privateKey)
+	assert.NoError(err)
+	_, err = verifier.Verify(token)
+	assert.Equal(jwt.ErrUnsupportedAlg, err)
+}
+
+func TestVerifyRS256(t *testing.T) {
+	assert := assert.New(t)
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(err)
+	verifier := jwt.NewRS256Verifier(&privateKey.PublicKey)
+
+	claims := jwt.Claims{Subject: "user-1", Role: "reader"}
+	token, err := jwt.SignRS256(claims, privateKey)
+	assert.NoError(err)
+
+	verified, err := verifier.Verify(token)
+	assert.NoError(err)
+	assert.Equal(claims, verified)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(err)
+	token, err = jwt.SignRS256(claims, otherKey)
+	assert.NoError(err)
+	_, err = verifier.Verify(token)
+	assert.Equal(jwt.ErrInvalidSignature, err)
+}
+
+func TestNewVerifierFromFile(t *testing.T) {
+	assert := assert.New(t)
+
+	secretFile := writeTempFile(t, []byte("file-secret\n"))
+	defer os.Remove(secretFile)
+
+	verifier, err := jwt.NewVerifierFromFile(secretFile)
+	assert.NoError(err)
+	token, err := jwt.SignHS256(jwt.Claims{Subject: "user-1"}, []byte("file-secret"))
+	assert.NoError(err)
+	_, err = verifier.Verify(token)
+	assert.NoError(err)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(err)
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	assert.NoError(err)
+	keyFile := writeTempFile(t, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	defer os.Remove(keyFile)
+
+	verifier, err = jwt.NewVerifierFromFile(keyFile)
+	assert.NoError(err)
+	token, err = jwt.SignRS256(jwt.Claims{Subject: "user-1"}, privateKey)
+	assert.NoError(err)
+	_, err = verifier.Verify(token)
+	assert.NoError(err)
+
+	_, err = jwt.NewVerifierFromFile("/missing/key/file")
+	assert.Error(err)
+}
+
+func writeTempFile(t *testing.T, content []byte) string {
+	f, err := ioutil.TempFile("", "jwt-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	_, err = f.Write(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return f.Name()
+}
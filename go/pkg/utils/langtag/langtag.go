@@ -0,0 +1,118 @@
+package langtag
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Wildcard language range matching any language.
+const Wildcard = "*"
+
+// Range language range with its quality value from an Accept-Language header.
+type Range struct {
+	Tag     string
+	Quality float64
+}
+
+// ParseAcceptLanguage parses an Accept-Language header into language ranges
+// ordered by descending quality. Ranges with an invalid quality value are left
+// out, while ranges with a quality of zero are kept last as exclusions.
+func ParseAcceptLanguage(header string) []Range {
+	ranges := make([]Range, 0)
+	for _, part := range strings.Split(header, ",") {
+		r, ok := parseRange(part)
+		if !ok {
+			continue
+		}
+
+		ranges = append(ranges, r)
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].Quality > ranges[j].Quality
+	})
+	return ranges
+}
+
+func parseRange(part string) (Range, bool) {
+	params := strings.Split(part, ";")
+	tag := strings.TrimSpace(params[0])
+	if tag == "" {
+		return Range{}, false
+	}
+
+	quality := 1.0
+	for _, param := range params[1:] {
+		param = strings.TrimSpace(param)
+		if !strings.HasPrefix(param, "q=") {
+			continue
+		}
+
+		q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+		if err != nil || q < 0 || q > 1 {
+			return Range{}, false
+		}
+		quality = q
+	}
+
+	return Range{Tag: tag, Quality: quality}, true
+}
+
+// Lookup finds the best matching supported language tag for the given ranges
+// using the RFC 4647 lookup scheme. Each range is tried in order and
+// progressively truncated (sv-Latn-SE -> sv-Latn -> sv) until it matches a
+// supported tag which is not excluded by a range with a quality of zero.
+// Matching is case insensitive and the supported tag is returned as given.
+// Wildcard ranges are skipped.
+func Lookup(ranges []Range, supported []string) (string, bool) {
+	tags := make(map[string]string, len(supported))
+	for _, tag := range supported {
+		tags[strings.ToLower(tag)] = tag
+	}
+
+	for _, r := range ranges {
+		if r.Tag == Wildcard || r.Quality == 0 {
+			continue
+		}
+
+		candidate := strings.ToLower(r.Tag)
+		for candidate != "" {
+			if tag, ok := tags[candidate]; ok && !Excluded(ranges, tag) {
+				return tag, true
+			}
+			candidate = truncate(candidate)
+		}
+	}
+
+	return "", false
+}
+
+// Excluded checks if a language tag is marked as not acceptable by a range with
+// a quality of zero, such as sv in "sv-SE, sv;q=0".
+func Excluded(ranges []Range, tag string) bool {
+	for _, r := range ranges {
+		if r.Quality == 0 && strings.EqualFold(r.Tag, tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// truncate removes the last subtag of a language tag, along with any
+// preceding single character subtag which would otherwise be left dangling.
+func truncate(tag string) string {
+	i := strings.LastIndex(tag, "-")
+	if i < 0 {
+		return ""
+	}
+
+	tag = tag[:i]
+	i = strings.LastIndex(tag, "-")
+	if i >= 0 && len(tag)-i == 2 {
+		tag = tag[:i]
+	}
+
+	return tag
+}
@@ -0,0 +1,66 @@
+package langtag_test
+
+import (
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/langtag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	assert := assert.New(t)
+
+	ranges := langtag.ParseAcceptLanguage("en;q=0.8, sv-SE,sv;q=0.9 ,fr;q=0,de;q=abc,*;q=0.1")
+	assert.Equal([]langtag.Range{
+		{Tag: "sv-SE", Quality: 1.0},
+		{Tag: "sv", Quality: 0.9},
+		{Tag: "en", Quality: 0.8},
+		{Tag: "*", Quality: 0.1},
+		{Tag: "fr", Quality: 0},
+	}, ranges)
+
+	assert.Equal(0, len(langtag.ParseAcceptLanguage("")))
+	assert.Equal([]langtag.Range{{Tag: "sv", Quality: 1.0}}, langtag.ParseAcceptLanguage("sv"))
+}
+
+func TestLookup(t *testing.T) {
+	assert := assert.New(t)
+	supported := []string{"en", "sv", "sv-FI", "zh-Hant"}
+
+	tests := []struct {
+		header   string
+		expected string
+		ok       bool
+	}{
+		{header: "sv-SE,sv;q=0.9,en;q=0.8", expected: "sv", ok: true},
+		{header: "sv-fi", expected: "sv-FI", ok: true},
+		{header: "fr,en;q=0.5", expected: "en", ok: true},
+		{header: "en;q=0.5,sv;q=0.6", expected: "sv", ok: true},
+		{header: "zh-Hant-CN-x-private1", expected: "zh-Hant", ok: true},
+		{header: "fr,de", expected: "", ok: false},
+		{header: "sv-SE, sv;q=0", expected: "", ok: false},
+		{header: "sv-SE, SV;q=0, en;q=0.5", expected: "en", ok: true},
+		{header: "sv-FI, sv;q=0", expected: "sv-FI", ok: true},
+		{header: "sv;q=0, sv", expected: "", ok: false},
+		{header: "*", expected: "", ok: false},
+		{header: "", expected: "", ok: false},
+	}
+
+	for _, test := range tests {
+		ranges := langtag.ParseAcceptLanguage(test.header)
+		tag, ok := langtag.Lookup(ranges, supported)
+		assert.Equal(test.ok, ok, test.header)
+		assert.Equal(test.expected, tag, test.header)
+	}
+}
+
+func TestExcluded(t *testing.T) {
+	assert := assert.New(t)
+	ranges := langtag.ParseAcceptLanguage("sv-SE, en;q=0, *;q=0.5")
+
+	assert.True(langtag.Excluded(ranges, "en"))
+	assert.True(langtag.Excluded(ranges, "EN"))
+	assert.False(langtag.Excluded(ranges, "sv"))
+	assert.False(langtag.Excluded(ranges, "sv-SE"))
+	assert.False(langtag.Excluded(ranges, "en-GB"))
+}
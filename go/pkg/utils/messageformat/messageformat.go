@@ -0,0 +1,576 @@
+// Package messageformat parses and formats ICU MessageFormat messages.
+//
+// Supported are simple arguments ({name}), number arguments ({n, number} with
+// the integer and percent styles), date and time arguments which are formatted
+// verbatim, select, plural and selectordinal arguments with offsets, exact
+// matches (=0) and # replacement, and apostrophe quoting.
+package messageformat
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Argument types.
+const (
+	typeNone          = ""
+	typeNumber        = "number"
+	typeDate          = "date"
+	typeTime          = "time"
+	typeSelect        = "select"
+	typePlural        = "plural"
+	typeSelectOrdinal = "selectordinal"
+)
+
+const otherCase = "other"
+
+// Message parsed message.
+type Message struct {
+	parts  []part
+	source string
+}
+
+type part interface {
+	format(f *formatter, b *strings.Builder) error
+}
+
+// Parse parses an ICU MessageFormat message.
+func Parse(message string) (*Message, error) {
+	p := &parser{input: []rune(message)}
+	msg, err := p.parseMessage(0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.input) {
+		return nil, p.errorf("unexpected '%c'", p.input[p.pos])
+	}
+
+	return msg, nil
+}
+
+// Format parses a message and formats it for the given language and arguments.
+func Format(language, message string, args map[string]interface{}) (string, error) {
+	msg, err := Parse(message)
+	if err != nil {
+		return "", err
+	}
+
+	return msg.Format(language, args)
+}
+
+// Format formats the message for the given language and arguments.
+func (m *Message) Format(language string, args map[string]interface{}) (string, error) {
+	f := &formatter{language: language, args: args}
+	var b strings.Builder
+	err := m.format(f, &b)
+	if err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// Source returns the message as it was written.
+func (m *Message) Source() string {
+	return m.source
+}
+
+// Plural returns the cases of a message which consists of a single plural argument
+// without an offset, as the name of the argument and the message of each case keyed by selector.
+func (m *Message) Plural() (string, map[string]*Message, bool) {
+	if len(m.parts) != 1 {
+		return "", nil, false
+	}
+
+	plural, ok := m.parts[0].(pluralPart)
+	if !ok || plural.ordinal || plural.offset != 0 {
+		return "", nil, false
+	}
+
+	return plural.name, plural.cases, true
+}
+
+// Segment literal text, argument or # placeholder of a message.
+type Segment struct {
+	Text     string
+	Arg      string
+	ArgType  string
+	ArgStyle string
+	Hash     bool
+}
+
+// Segments returns the text and arguments of a message in order, which is only
+// possible for messages without select, plural and selectordinal arguments.
+func (m *Message) Segments() ([]Segment, bool) {
+	segments := make([]Segment, 0, len(m.parts))
+	for _, p := range m.parts {
+		switch p := p.(type) {
+		case textPart:
+			segments = append(segments, Segment{Text: string(p)})
+		case hashPart:
+			segments = append(segments, Segment{Hash: true})
+		case argPart:
+			segments = append(segments, Segment{Arg: p.name, ArgType: p.typ, ArgStyle: p.style})
+		default:
+			return nil, false
+		}
+	}
+
+	return segments, true
+}
+
+func (m *Message) format(f *formatter, b *strings.Builder) error {
+	for _, p := range m.parts {
+		err := p.format(f, b)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type formatter struct {
+	language string
+	args     map[string]interface{}
+	numbers  []float64
+}
+
+func (f *formatter) arg(name string) (interface{}, error) {
+	val, ok := f.args[name]
+	if !ok {
+		return nil, fmt.Errorf("missing argument: %s", name)
+	}
+
+	return val, nil
+}
+
+func (f *formatter) number(name string) (float64, string, error) {
+	val, err := f.arg(name)
+	if err != nil {
+		return 0, "", err
+	}
+
+	n, text, ok := toNumber(val)
+	if !ok {
+		return 0, "", fmt.Errorf("argument is not a number: %s", name)
+	}
+
+	return n, text, nil
+}
+
+type textPart string
+
+func (t textPart) format(f *formatter, b *strings.Builder) error {
+	b.WriteString(string(t))
+	return nil
+}
+
+// hashPart is the # placeholder inside plural cases.
+type hashPart struct{}
+
+func (h hashPart) format(f *formatter, b *strings.Builder) error {
+	n := f.numbers[len(f.numbers)-1]
+	b.WriteString(formatNumber(n))
+	return nil
+}
+
+type argPart struct {
+	name  string
+	typ   string
+	style string
+}
+
+func (a argPart) format(f *formatter, b *strings.Builder) error {
+	if a.typ != typeNumber {
+		val, err := f.arg(a.name)
+		if err != nil {
+			return err
+		}
+
+		b.WriteString(fmt.Sprint(val))
+		return nil
+	}
+
+	n, _, err := f.number(a.name)
+	if err != nil {
+		return err
+	}
+
+	switch a.style {
+	case "integer":
+		b.WriteString(formatNumber(math.Round(n)))
+	case "percent":
+		b.WriteString(formatNumber(math.Round(n*100)) + "%")
+	default:
+		b.WriteString(formatNumber(n))
+	}
+
+	return nil
+}
+
+type selectPart struct {
+	name  string
+	cases map[string]*Message
+}
+
+func (s selectPart) format(f *formatter, b *strings.Builder) error {
+	val, err := f.arg(s.name)
+	if err != nil {
+		return err
+	}
+
+	msg, ok := s.cases[fmt.Sprint(val)]
+	if !ok {
+		msg = s.cases[otherCase]
+	}
+
+	return msg.format(f, b)
+}
+
+type pluralPart struct {
+	name    string
+	ordinal bool
+	offset  float64
+	cases   map[string]*Message
+}
+
+func (p pluralPart) format(f *formatter, b *strings.Builder) error {
+	n, text, err := f.number(p.name)
+	if err != nil {
+		return err
+	}
+
+	msg, ok := p.cases["="+formatNumber(n)]
+	if !ok {
+		if p.offset != 0 {
+			text = formatNumber(n - p.offset)
+		}
+
+		category := PluralCategory(f.language, text, p.ordinal)
+		msg, ok = p.cases[category]
+		if !ok {
+			msg = p.cases[otherCase]
+		}
+	}
+
+	f.numbers = append(f.numbers, n-p.offset)
+	err = msg.format(f, b)
+	f.numbers = f.numbers[:len(f.numbers)-1]
+	return err
+}
+
+type parser struct {
+	input []rune
+	pos   int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("messageformat: position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// parseMessage parses message text until end of input or an unmatched '}'.
+func (p *parser) parseMessage(depth int, inPlural bool) (*Message, error) {
+	msg := &Message{parts: make([]part, 0)}
+	start := p.pos
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			msg.parts = append(msg.parts, textPart(text.String()))
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		switch {
+		case c == '\'':
+			p.parseQuoted(&text, inPlural)
+		case c == '{':
+			flush()
+			arg, err := p.parseArgument(depth)
+			if err != nil {
+				return nil, err
+			}
+			msg.parts = append(msg.parts, arg)
+		case c == '}':
+			if depth == 0 {
+				return nil, p.errorf("unmatched '}'")
+			}
+			flush()
+			msg.source = string(p.input[start:p.pos])
+			return msg, nil
+		case c == '#' && inPlural:
+			flush()
+			msg.parts = append(msg.parts, hashPart{})
+			p.pos++
+		default:
+			text.WriteRune(c)
+			p.pos++
+		}
+	}
+
+	if depth > 0 {
+		return nil, p.errorf("unterminated message, missing '}'")
+	}
+
+	flush()
+	msg.source = string(p.input[start:p.pos])
+	return msg, nil
+}
+
+// parseQuoted handles apostrophes. A doubled apostrophe is a literal
+// apostrophe, an apostrophe before a syntax character starts quoted literal
+// text and any other apostrophe is literal.
+func (p *parser) parseQuoted(text *strings.Builder, inPlural bool) {
+	p.pos++
+	if p.pos >= len(p.input) {
+		text.WriteRune('\'')
+		return
+	}
+
+	next := p.input[p.pos]
+	if next == '\'' {
+		text.WriteRune('\'')
+		p.pos++
+		return
+	}
+
+	if next != '{' && next != '}' && !(next == '#' && inPlural) {
+		text.WriteRune('\'')
+		return
+	}
+
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		p.pos++
+		if c != '\'' {
+			text.WriteRune(c)
+			continue
+		}
+
+		if p.pos < len(p.input) && p.input[p.pos] == '\'' {
+			text.WriteRune('\'')
+			p.pos++
+			continue
+		}
+
+		return
+	}
+}
+
+func (p *parser) parseArgument(depth int) (part, error) {
+	p.pos++
+	p.skipSpace()
+	name := p.parseIdentifier()
+	if name == "" {
+		return nil, p.errorf("missing argument name")
+	}
+
+	p.skipSpace()
+	if p.consume('}') {
+		return argPart{name: name, typ: typeNone}, nil
+	}
+
+	if !p.consume(',') {
+		return nil, p.errorf("expected ',' or '}' after argument name")
+	}
+
+	p.skipSpace()
+	typ := p.parseIdentifier()
+	p.skipSpace()
+	switch typ {
+	case typeNumber, typeDate, typeTime:
+		return p.parseSimpleArgument(name, typ)
+	case typeSelect:
+		return p.parseSelect(name, depth)
+	case typePlural, typeSelectOrdinal:
+		return p.parsePlural(name, typ == typeSelectOrdinal, depth)
+	default:
+		return nil, p.errorf("unknown argument type: %s", typ)
+	}
+}
+
+func (p *parser) parseSimpleArgument(name, typ string) (part, error) {
+	if p.consume('}') {
+		return argPart{name: name, typ: typ}, nil
+	}
+
+	if !p.consume(',') {
+		return nil, p.errorf("expected ',' or '}' after argument type")
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] != '}' {
+		if p.input[p.pos] == '{' {
+			return nil, p.errorf("unexpected '{' in argument style")
+		}
+		p.pos++
+	}
+
+	if !p.consume('}') {
+		return nil, p.errorf("unterminated argument")
+	}
+
+	style := strings.TrimSpace(string(p.input[start : p.pos-1]))
+	return argPart{name: name, typ: typ, style: style}, nil
+}
+
+func (p *parser) parseSelect(name string, depth int) (part, error) {
+	if !p.consume(',') {
+		return nil, p.errorf("expected ',' after select")
+	}
+
+	cases, err := p.parseCases(depth, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return selectPart{name: name, cases: cases}, nil
+}
+
+func (p *parser) parsePlural(name string, ordinal bool, depth int) (part, error) {
+	if !p.consume(',') {
+		return nil, p.errorf("expected ',' after plural")
+	}
+
+	p.skipSpace()
+	offset := 0.0
+	if strings.HasPrefix(string(p.input[p.pos:]), "offset:") {
+		p.pos += len("offset:")
+		p.skipSpace()
+		start := p.pos
+		for p.pos < len(p.input) && isDigit(p.input[p.pos]) {
+			p.pos++
+		}
+
+		val, err := strconv.Atoi(string(p.input[start:p.pos]))
+		if err != nil {
+			return nil, p.errorf("invalid plural offset")
+		}
+		offset = float64(val)
+	}
+
+	cases, err := p.parseCases(depth, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return pluralPart{name: name, ordinal: ordinal, offset: offset, cases: cases}, nil
+}
+
+func (p *parser) parseCases(depth int, inPlural, allowExact bool) (map[string]*Message, error) {
+	cases := make(map[string]*Message)
+	for {
+		p.skipSpace()
+		if p.consume('}') {
+			break
+		}
+
+		selector := p.parseSelector(allowExact)
+		if selector == "" {
+			return nil, p.errorf("expected case selector")
+		}
+
+		if _, ok := cases[selector]; ok {
+			return nil, p.errorf("duplicate case: %s", selector)
+		}
+
+		p.skipSpace()
+		if !p.consume('{') {
+			return nil, p.errorf("expected '{' after case %s", selector)
+		}
+
+		msg, err := p.parseMessage(depth+1, inPlural)
+		if err != nil {
+			return nil, err
+		}
+		p.pos++
+
+		cases[selector] = msg
+	}
+
+	if _, ok := cases[otherCase]; !ok {
+		return nil, p.errorf("missing 'other' case")
+	}
+
+	return cases, nil
+}
+
+func (p *parser) parseSelector(allowExact bool) string {
+	if allowExact && p.consume('=') {
+		start := p.pos
+		for p.pos < len(p.input) && (isDigit(p.input[p.pos]) || p.input[p.pos] == '.') {
+			p.pos++
+		}
+
+		if p.pos == start {
+			return ""
+		}
+
+		return "=" + string(p.input[start:p.pos])
+	}
+
+	return p.parseIdentifier()
+}
+
+func (p *parser) parseIdentifier() string {
+	start := p.pos
+	for p.pos < len(p.input) && isIdentifierRune(p.input[p.pos]) {
+		p.pos++
+	}
+
+	return string(p.input[start:p.pos])
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.input) && isSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *parser) consume(c rune) bool {
+	if p.pos < len(p.input) && p.input[p.pos] == c {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func isIdentifierRune(c rune) bool {
+	return c == '_' || c == '-' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c rune) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isSpace(c rune) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func toNumber(val interface{}) (float64, string, bool) {
+	switch v := val.(type) {
+	case float64:
+		return v, formatNumber(v), true
+	case float32:
+		return float64(v), formatNumber(float64(v)), true
+	case int:
+		return float64(v), strconv.Itoa(v), true
+	case int64:
+		return float64(v), strconv.FormatInt(v, 10), true
+	case string:
+		n, err := strconv.ParseFloat(v, 64)
+		return n, v, err == nil
+	default:
+		return 0, "", false
+	}
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
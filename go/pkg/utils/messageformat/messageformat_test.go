@@ -0,0 +1,163 @@
+package messageformat_test
+
+import (
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/messageformat"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	assert := assert.New(t)
+	plural := "{count, plural, =0 {No messages} one {# message} other {# messages}}"
+
+	tests := []struct {
+		language string
+		message  string
+		args     map[string]interface{}
+		expected string
+	}{
+		{language: "en", message: "Plain text", expected: "Plain text"},
+		{language: "en", message: "Hello {name}!", args: map[string]interface{}{"name": "Ada"}, expected: "Hello Ada!"},
+		{language: "en", message: plural, args: map[string]interface{}{"count": 0}, expected: "No messages"},
+		{language: "en", message: plural, args: map[string]interface{}{"count": 1}, expected: "1 message"},
+		{language: "en", message: plural, args: map[string]interface{}{"count": 1.5}, expected: "1.5 messages"},
+		{language: "en", message: plural, args: map[string]interface{}{"count": "1.0"}, expected: "1 messages"},
+		{language: "sv", message: plural, args: map[string]interface{}{"count": float64(42)}, expected: "42 messages"},
+		{
+			language: "ru",
+			message:  "{n, plural, one {# файл} few {# файла} many {# файлов} other {# файла}}",
+			args:     map[string]interface{}{"n": 22},
+			expected: "22 файла",
+		},
+		{
+			language: "ru",
+			message:  "{n, plural, one {# файл} few {# файла} many {# файлов} other {# файла}}",
+			args:     map[string]interface{}{"n": 11},
+			expected: "11 файлов",
+		},
+		{
+			language: "en",
+			message:  "{guests, plural, offset:1 =0 {Nobody} =1 {{host}} one {{host} and # other} other {{host} and # others}}",
+			args:     map[string]interface{}{"guests": 3, "host": "Ada"},
+			expected: "Ada and 2 others",
+		},
+		{
+			language: "en",
+			message:  "{guests, plural, offset:1 =0 {Nobody} =1 {{host}} one {{host} and # other} other {{host} and # others}}",
+			args:     map[string]interface{}{"guests": 2, "host": "Ada"},
+			expected: "Ada and 1 other",
+		},
+		{
+			language: "en",
+			message:  "{gender, select, female {She} male {He} other {They}} replied",
+			args:     map[string]interface{}{"gender": "unknown"},
+			expected: "They replied",
+		},
+		{
+			language: "en",
+			message:  "You finished {place, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}",
+			args:     map[string]interface{}{"place": 23},
+			expected: "You finished 23rd",
+		},
+		{language: "en", message: "{share, number, percent} done", args: map[string]interface{}{"share": 0.25}, expected: "25% done"},
+		{language: "en", message: "It''s '{escaped}' and it's fine", expected: "It's {escaped} and it's fine"},
+		{language: "en", message: "{n, plural, other {'#' is #}}", args: map[string]interface{}{"n": 5}, expected: "# is 5"},
+	}
+
+	for _, test := range tests {
+		result, err := messageformat.Format(test.language, test.message, test.args)
+		assert.NoError(err, test.message)
+		assert.Equal(test.expected, result, test.message)
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := messageformat.Format("en", "Hello {name}", map[string]interface{}{})
+	assert.Error(err)
+
+	_, err = messageformat.Format("en", "{n, plural, other {#}}", map[string]interface{}{"n": "many"})
+	assert.Error(err)
+}
+
+func TestParseErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	invalid := []string{
+		"Hello {name",
+		"Hello name}",
+		"Hello {}",
+		"{n, plural, one {# item}}",
+		"{n, plural, one {# item} one {#} other {#}}",
+		"{n, unknown}",
+		"{gender, select, female {She} other {They}",
+	}
+
+	for _, message := range invalid {
+		_, err := messageformat.Parse(message)
+		assert.Error(err, message)
+	}
+}
+
+func TestPluralCategory(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(messageformat.One, messageformat.PluralCategory("en", "1", false))
+	assert.Equal(messageformat.Other, messageformat.PluralCategory("en-GB", "1.0", false))
+	assert.Equal(messageformat.One, messageformat.PluralCategory("fr", "0", false))
+	assert.Equal(messageformat.Other, messageformat.PluralCategory("ja", "1", false))
+	assert.Equal(messageformat.Few, messageformat.PluralCategory("pl", "3", false))
+	assert.Equal(messageformat.Many, messageformat.PluralCategory("pl", "5", false))
+	assert.Equal(messageformat.Two, messageformat.PluralCategory("ar", "2", false))
+	assert.Equal(messageformat.Many, messageformat.PluralCategory("ar", "11", false))
+	assert.Equal(messageformat.One, messageformat.PluralCategory("sv", "2", true))
+	assert.Equal(messageformat.Other, messageformat.PluralCategory("sv", "12", true))
+}
+
+func TestPlural(t *testing.T) {
+	assert := assert.New(t)
+
+	msg, err := messageformat.Parse("{n, plural, =0 {No files} one {# file} other {# files in '{'dir'}'}}")
+	assert.NoError(err)
+	name, cases, ok := msg.Plural()
+	assert.True(ok)
+	assert.Equal("n", name)
+	assert.Len(cases, 3)
+	assert.Equal("No files", cases["=0"].Source())
+	assert.Equal("# file", cases["one"].Source())
+	assert.Equal("# files in '{'dir'}'", cases["other"].Source())
+
+	for _, message := range []string{"Plain text", "{n, plural, one {#} other {#}} files", "{n, plural, offset:1 other {#}}", "{n, selectordinal, other {#}}"} {
+		msg, err := messageformat.Parse(message)
+		assert.NoError(err)
+		_, _, ok := msg.Plural()
+		assert.False(ok, message)
+	}
+}
+
+func TestSegments(t *testing.T) {
+	assert := assert.New(t)
+
+	msg, err := messageformat.Parse("Hi {name}, it''s {n, number, integer}")
+	assert.NoError(err)
+	segments, ok := msg.Segments()
+	assert.True(ok)
+	assert.Equal([]messageformat.Segment{
+		{Text: "Hi "},
+		{Arg: "name"},
+		{Text: ", it's "},
+		{Arg: "n", ArgType: "number", ArgStyle: "integer"},
+	}, segments)
+
+	msg, err = messageformat.Parse("{n, plural, other {# files}}")
+	assert.NoError(err)
+	_, cases, _ := msg.Plural()
+	segments, ok = cases["other"].Segments()
+	assert.True(ok)
+	assert.Equal([]messageformat.Segment{{Hash: true}, {Text: " files"}}, segments)
+
+	_, ok = msg.Segments()
+	assert.False(ok)
+}
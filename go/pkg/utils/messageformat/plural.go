@@ -0,0 +1,176 @@
+package messageformat
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Plural categories.
+const (
+	Zero  = "zero"
+	One   = "one"
+	Two   = "two"
+	Few   = "few"
+	Many  = "many"
+	Other = "other"
+)
+
+// operands CLDR plural operands of a number.
+//
+//	n: absolute value
+//	i: integer digits
+//	v: number of visible fraction digits
+//	f: visible fraction digits
+type operands struct {
+	n float64
+	i int64
+	v int
+	f int64
+}
+
+// newOperands computes plural operands from the decimal representation of a
+// number, so that trailing fraction zeros ("1.0") are taken into account.
+func newOperands(text string) operands {
+	text = strings.TrimPrefix(strings.TrimSpace(text), "-")
+	n, _ := strconv.ParseFloat(text, 64)
+	o := operands{n: math.Abs(n), i: int64(math.Abs(n))}
+
+	dot := strings.IndexByte(text, '.')
+	if dot < 0 {
+		return o
+	}
+
+	fraction := text[dot+1:]
+	o.v = len(fraction)
+	o.f, _ = strconv.ParseInt(fraction, 10, 64)
+	return o
+}
+
+func (o operands) isInt() bool {
+	return o.v == 0
+}
+
+func inRange(n, from, to int64) bool {
+	return n >= from && n <= to
+}
+
+// PluralCategory returns the CLDR cardinal or ordinal plural category for the
+// decimal number in a language. Languages without known rules use the English rules.
+func PluralCategory(language, number string, ordinal bool) string {
+	o := newOperands(number)
+	base := strings.ToLower(language)
+	if i := strings.IndexByte(base, '-'); i > 0 {
+		base = base[:i]
+	}
+
+	if ordinal {
+		return ordinalCategory(base, o)
+	}
+
+	return cardinalCategory(base, o)
+}
+
+func cardinalCategory(language string, o operands) string {
+	i10, i100 := o.i%10, o.i%100
+	switch language {
+	case "ja", "zh", "ko", "th", "vi", "id", "ms", "lo", "my", "km":
+		return Other
+	case "fr":
+		if o.i == 0 || o.i == 1 {
+			return One
+		}
+	case "pt":
+		if inRange(o.i, 0, 1) {
+			return One
+		}
+	case "es", "el", "hu", "tr", "bg", "nb", "no":
+		if o.n == 1 {
+			return One
+		}
+	case "ru", "uk", "be":
+		if !o.isInt() {
+			return Other
+		}
+		if i10 == 1 && i100 != 11 {
+			return One
+		}
+		if inRange(i10, 2, 4) && !inRange(i100, 12, 14) {
+			return Few
+		}
+		return Many
+	case "pl":
+		if !o.isInt() {
+			return Other
+		}
+		if o.i == 1 {
+			return One
+		}
+		if inRange(i10, 2, 4) && !inRange(i100, 12, 14) {
+			return Few
+		}
+		return Many
+	case "cs", "sk":
+		if !o.isInt() {
+			return Many
+		}
+		if o.i == 1 {
+			return One
+		}
+		if inRange(o.i, 2, 4) {
+			return Few
+		}
+	case "ar":
+		n100 := math.Mod(o.n, 100)
+		switch {
+		case o.n == 0:
+			return Zero
+		case o.n == 1:
+			return One
+		case o.n == 2:
+			return Two
+		case o.isInt() && n100 >= 3 && n100 <= 10:
+			return Few
+		case o.isInt() && n100 >= 11 && n100 <= 99:
+			return Many
+		}
+	case "he":
+		if o.i == 1 && o.isInt() {
+			return One
+		}
+		if o.i == 2 && o.isInt() {
+			return Two
+		}
+	default:
+		if o.i == 1 && o.isInt() {
+			return One
+		}
+	}
+
+	return Other
+}
+
+func ordinalCategory(language string, o operands) string {
+	n10, n100 := math.Mod(o.n, 10), math.Mod(o.n, 100)
+	switch language {
+	case "en":
+		switch {
+		case n10 == 1 && n100 != 11:
+			return One
+		case n10 == 2 && n100 != 12:
+			return Two
+		case n10 == 3 && n100 != 13:
+			return Few
+		}
+	case "sv":
+		if (n10 == 1 || n10 == 2) && n100 != 11 && n100 != 12 {
+			return One
+		}
+	case "fr":
+		if o.n == 1 {
+			return One
+		}
+	}
+
+	return Other
+}
@@ -0,0 +1,92 @@
+package resource
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var androidVerbs = verbs{object: "s", integer: "d"}
+
+// invalidAndroidName characters which are not allowed in Android resource names. Dots are
+// allowed by aapt but turned into underscores in the R class, so they are replaced as well.
+var invalidAndroidName = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+var androidEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+
+// NameCollisionError is returned when several keys map to the same resource name.
+type NameCollisionError struct {
+	Name string
+	Keys []string
+}
+
+func (e *NameCollisionError) Error() string {
+	return fmt.Sprintf("resource: keys %s map to the same resource name %s", strings.Join(e.Keys, ", "), e.Name)
+}
+
+// WriteAndroid writes texts as an Android strings.xml resource. Plural messages are written
+// as plurals resources, without exact matches such as =0 which Android has no quantity for.
+// Keys are written with invalid characters replaced by underscores, and prefixed by an underscore
+// if they start with a digit. A *NameCollisionError is returned if two keys end up with the same name.
+func WriteAndroid(w io.Writer, texts map[string]string) error {
+	keys := sortedKeys(texts)
+	names, err := androidNames(keys)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n<resources>\n")
+	for _, key := range keys {
+		name := names[key]
+		msg := convert(texts[key], androidVerbs)
+		if !msg.plural() {
+			bw.WriteString(`    <string name="` + name + `">` + escapeAndroid(msg.format) + "</string>\n")
+			continue
+		}
+
+		bw.WriteString(`    <plurals name="` + name + `">` + "\n")
+		for _, category := range pluralCategories {
+			if form, ok := msg.pluralForm[category]; ok {
+				bw.WriteString(`        <item quantity="` + category + `">` + escapeAndroid(form) + "</item>\n")
+			}
+		}
+		bw.WriteString("    </plurals>\n")
+	}
+	bw.WriteString("</resources>\n")
+
+	return bw.Flush()
+}
+
+// androidNames maps keys to Android resource names, which must be unique.
+func androidNames(keys []string) (map[string]string, error) {
+	names := make(map[string]string, len(keys))
+	owners := make(map[string]string, len(keys))
+	for _, key := range keys {
+		name := invalidAndroidName.ReplaceAllString(key, "_")
+		if name != "" && name[0] >= '0' && name[0] <= '9' {
+			name = "_" + name
+		}
+		if other, ok := owners[name]; ok {
+			return nil, &NameCollisionError{Name: name, Keys: []string{other, key}}
+		}
+
+		owners[name] = key
+		names[key] = name
+	}
+
+	return names, nil
+}
+
+// escapeAndroid escapes quotes, backslashes and control characters as aapt expects, along
+// with a leading @ or ? which would otherwise be read as a resource reference.
+func escapeAndroid(s string) string {
+	s = androidEscaper.Replace(s)
+	if strings.HasPrefix(s, "@") || strings.HasPrefix(s, "?") {
+		s = `\` + s
+	}
+
+	return xmlEscaper.Replace(s)
+}
@@ -0,0 +1,72 @@
+package resource
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/messageformat"
+)
+
+var appleVerbs = verbs{object: "@", integer: "ld"}
+
+var stringsEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+
+// WriteStrings writes texts as an iOS Localizable.strings file. Plural messages
+// are left out as they belong in the stringsdict file.
+func WriteStrings(w io.Writer, texts map[string]string) error {
+	bw := bufio.NewWriter(w)
+	for _, key := range sortedKeys(texts) {
+		msg := convert(texts[key], appleVerbs)
+		if msg.plural() {
+			continue
+		}
+
+		bw.WriteString(`"` + stringsEscaper.Replace(key) + `" = "` + stringsEscaper.Replace(msg.format) + "\";\n")
+	}
+
+	return bw.Flush()
+}
+
+// WriteStringsdict writes the plural messages of texts as an iOS Localizable.stringsdict
+// property list. An exact =0 case is used as the zero rule, which iOS applies to 0 in all languages.
+func WriteStringsdict(w io.Writer, texts map[string]string) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+`)
+	for _, key := range sortedKeys(texts) {
+		msg := convert(texts[key], appleVerbs)
+		if !msg.plural() {
+			continue
+		}
+
+		if _, ok := msg.pluralForm[messageformat.Zero]; !ok {
+			if form, ok := msg.pluralForm["=0"]; ok {
+				msg.pluralForm[messageformat.Zero] = form
+			}
+		}
+
+		bw.WriteString("  <key>" + xmlEscaper.Replace(key) + "</key>\n  <dict>\n")
+		writePlistEntry(bw, "    ", "NSStringLocalizedFormatKey", "%#@"+msg.pluralArg+"@")
+		bw.WriteString("    <key>" + msg.pluralArg + "</key>\n    <dict>\n")
+		writePlistEntry(bw, "      ", "NSStringFormatSpecTypeKey", "NSStringPluralRuleType")
+		writePlistEntry(bw, "      ", "NSStringFormatValueTypeKey", appleVerbs.integer)
+		for _, category := range pluralCategories {
+			if form, ok := msg.pluralForm[category]; ok {
+				writePlistEntry(bw, "      ", category, form)
+			}
+		}
+		bw.WriteString("    </dict>\n  </dict>\n")
+	}
+	bw.WriteString("</dict>\n</plist>\n")
+
+	return bw.Flush()
+}
+
+func writePlistEntry(w *bufio.Writer, indent, key, value string) {
+	w.WriteString(indent + "<key>" + xmlEscaper.Replace(key) + "</key>\n")
+	w.WriteString(indent + "<string>" + xmlEscaper.Replace(value) + "</string>\n")
+}
@@ -0,0 +1,67 @@
+package resource
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// I18nextWriter writes texts as i18next JSON resources, nesting keys split on a separator.
+// A key which would nest under another key's text, or have other keys nested under its text,
+// is written unsplit at the top level, where i18next finds it when key separation is off.
+func I18nextWriter(separator string) func(w io.Writer, texts map[string]string) error {
+	return func(w io.Writer, texts map[string]string) error {
+		root := make(map[string]interface{})
+		conflicts := make(map[string]string)
+		for _, key := range sortedKeys(texts) {
+			if separator == "" || !nest(root, strings.Split(key, separator), texts[key]) {
+				conflicts[key] = texts[key]
+			}
+		}
+
+		for key, value := range conflicts {
+			if _, ok := root[key]; ok {
+				continue
+			}
+			root[key] = value
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		return enc.Encode(root)
+	}
+}
+
+// nest adds a value at a path of nested objects, unless the path is taken by another value.
+func nest(node map[string]interface{}, path []string, value string) bool {
+	for _, name := range path {
+		if name == "" {
+			return false
+		}
+	}
+
+	for i, name := range path {
+		child, ok := node[name]
+		if i == len(path)-1 {
+			if ok {
+				return false
+			}
+			node[name] = value
+			return true
+		}
+
+		if !ok {
+			child = make(map[string]interface{})
+			node[name] = child
+		}
+
+		next, ok := child.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		node = next
+	}
+
+	return false
+}
@@ -0,0 +1,61 @@
+package resource
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+// WriteProperties writes texts as a Java .properties file in ISO-8859-1, as read by
+// Properties.load and ResourceBundle, with characters outside of ASCII written as Unicode escapes.
+func WriteProperties(w io.Writer, texts map[string]string) error {
+	bw := bufio.NewWriter(w)
+	for _, key := range sortedKeys(texts) {
+		bw.WriteString(escapeProperty(key, true) + "=" + escapeProperty(texts[key], false) + "\n")
+	}
+
+	return bw.Flush()
+}
+
+// escapeProperty escapes backslashes, control characters and characters outside of ASCII,
+// along with separators and comment characters in keys and leading spaces in values.
+func escapeProperty(s string, key bool) string {
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case r == ' ' && (key || i == 0):
+			b.WriteString(`\ `)
+		case key && strings.ContainsRune("=:#!", r):
+			b.WriteString(`\` + string(r))
+		case r < 0x20 || r > 0x7e:
+			writeUnicodeEscape(&b, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// writeUnicodeEscape writes a rune as \uXXXX escapes, using a surrogate pair outside of the basic multilingual plane.
+func writeUnicodeEscape(b *strings.Builder, r rune) {
+	if r > 0xffff {
+		high, low := utf16.EncodeRune(r)
+		fmt.Fprintf(b, `\u%04x\u%04x`, high, low)
+		return
+	}
+
+	fmt.Fprintf(b, `\u%04x`, r)
+}
@@ -0,0 +1,130 @@
+// Package resource writes texts as the string resource files of mobile platforms,
+// converting ICU messages to printf style formats with positional arguments.
+package resource
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/messageformat"
+)
+
+// pluralCategories CLDR plural categories in the order platforms list them.
+var pluralCategories = []string{
+	messageformat.Zero,
+	messageformat.One,
+	messageformat.Two,
+	messageformat.Few,
+	messageformat.Many,
+	messageformat.Other,
+}
+
+// verbs printf verbs of a platform for object and integer arguments.
+type verbs struct {
+	object  string
+	integer string
+}
+
+// message text converted to a platform format. A plural message has a format for each
+// plural category present in the message, other messages have a single format.
+type message struct {
+	format     string
+	pluralArg  string
+	pluralForm map[string]string
+}
+
+func (m message) plural() bool {
+	return m.pluralForm != nil
+}
+
+// convert converts a text to a platform format. Texts which do not parse or which use
+// select arguments or nested plurals can not be converted and are kept as literal text.
+func convert(value string, v verbs) message {
+	msg, err := messageformat.Parse(value)
+	if err != nil {
+		return message{format: value}
+	}
+
+	if name, cases, ok := msg.Plural(); ok {
+		positions := newPositions(name)
+		forms := make(map[string]string, len(cases))
+		for selector, c := range cases {
+			segments, ok := c.Segments()
+			if !ok {
+				return message{format: value}
+			}
+			forms[selector] = printf(segments, positions, name, v, true)
+		}
+
+		return message{pluralArg: name, pluralForm: forms}
+	}
+
+	segments, ok := msg.Segments()
+	if !ok {
+		return message{format: value}
+	}
+
+	return message{format: printf(segments, newPositions(), "", v, false)}
+}
+
+// positions assigns positions to arguments in order of appearance.
+type positions map[string]int
+
+func newPositions(names ...string) positions {
+	p := make(positions)
+	for _, name := range names {
+		p.of(name)
+	}
+
+	return p
+}
+
+func (p positions) of(name string) int {
+	if i, ok := p[name]; ok {
+		return i
+	}
+
+	p[name] = len(p) + 1
+	return p[name]
+}
+
+// printf converts message segments to a printf style format. Literal percent signs are
+// escaped if the format has arguments, as it is then passed through a formatter.
+func printf(segments []messageformat.Segment, p positions, pluralArg string, v verbs, formatted bool) string {
+	for _, s := range segments {
+		formatted = formatted || s.Hash || s.Arg != ""
+	}
+
+	var b strings.Builder
+	for _, s := range segments {
+		switch {
+		case s.Hash:
+			fmt.Fprintf(&b, "%%%d$%s", p.of(pluralArg), v.integer)
+		case s.Arg != "":
+			verb := v.object
+			if s.ArgType == "number" && s.ArgStyle == "integer" {
+				verb = v.integer
+			}
+			fmt.Fprintf(&b, "%%%d$%s", p.of(s.Arg), verb)
+		case formatted:
+			b.WriteString(strings.Replace(s.Text, "%", "%%", -1))
+		default:
+			b.WriteString(s.Text)
+		}
+	}
+
+	return b.String()
+}
+
+func sortedKeys(texts map[string]string) []string {
+	keys := make([]string, 0, len(texts))
+	for key := range texts {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
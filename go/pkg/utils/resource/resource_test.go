@@ -0,0 +1,189 @@
+package resource_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+var testTexts = map[string]string{
+	"greeting":   "Hi {name}, it''s 100% {count, number, integer} days",
+	"discount":   "Save 20% & more",
+	"at-start":   "@home <b>\"quoted\"</b>",
+	"files":      "{n, plural, =0 {No files} one {# file} other {# files of {owner}}}",
+	"gender":     "{g, select, female {She} other {They}}",
+	"line.break": "One\nTwo",
+}
+
+func TestWriteAndroid(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	err := resource.WriteAndroid(&buf, testTexts)
+	assert.NoError(err)
+	assert.Equal(`<?xml version="1.0" encoding="utf-8"?>
+<resources>
+    <string name="at_start">\@home &lt;b&gt;\"quoted\"&lt;/b&gt;</string>
+    <string name="discount">Save 20% &amp; more</string>
+    <plurals name="files">
+        <item quantity="one">%1$d file</item>
+        <item quantity="other">%1$d files of %2$s</item>
+    </plurals>
+    <string name="gender">{g, select, female {She} other {They}}</string>
+    <string name="greeting">Hi %1$s, it\'s 100%% %2$d days</string>
+    <string name="line_break">One\nTwo</string>
+</resources>
+`, buf.String())
+}
+
+func TestWriteAndroidNames(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	err := resource.WriteAndroid(&buf, map[string]string{"1st.place": "a", "menu.title": "b"})
+	assert.NoError(err)
+	assert.Contains(buf.String(), `<string name="_1st_place">a</string>`)
+	assert.Contains(buf.String(), `<string name="menu_title">b</string>`)
+
+	err = resource.WriteAndroid(&buf, map[string]string{"menu.title": "a", "menu_title": "b"})
+	collision, ok := err.(*resource.NameCollisionError)
+	assert.True(ok)
+	assert.Equal("menu_title", collision.Name)
+	assert.Equal([]string{"menu.title", "menu_title"}, collision.Keys)
+}
+
+func TestWriteAndroidNameCollision(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	err := resource.WriteAndroid(&buf, map[string]string{"at-start": "a", "at_start": "b", "other": "c"})
+	collision, ok := err.(*resource.NameCollisionError)
+	assert.True(ok)
+	assert.Equal("at_start", collision.Name)
+	assert.Equal([]string{"at-start", "at_start"}, collision.Keys)
+	assert.Empty(buf.String())
+}
+
+func TestWriteStrings(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	err := resource.WriteStrings(&buf, testTexts)
+	assert.NoError(err)
+	assert.Equal(`"at-start" = "@home <b>\"quoted\"</b>";
+"discount" = "Save 20% & more";
+"gender" = "{g, select, female {She} other {They}}";
+"greeting" = "Hi %1$@, it's 100%% %2$ld days";
+"line.break" = "One\nTwo";
+`, buf.String())
+}
+
+func TestWriteStringsdict(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	err := resource.WriteStringsdict(&buf, testTexts)
+	assert.NoError(err)
+	assert.Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+  <key>files</key>
+  <dict>
+    <key>NSStringLocalizedFormatKey</key>
+    <string>%#@n@</string>
+    <key>n</key>
+    <dict>
+      <key>NSStringFormatSpecTypeKey</key>
+      <string>NSStringPluralRuleType</string>
+      <key>NSStringFormatValueTypeKey</key>
+      <string>ld</string>
+      <key>zero</key>
+      <string>No files</string>
+      <key>one</key>
+      <string>%1$ld file</string>
+      <key>other</key>
+      <string>%1$ld files of %2$@</string>
+    </dict>
+  </dict>
+</dict>
+</plist>
+`, buf.String())
+}
+
+func TestWriteProperties(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	err := resource.WriteProperties(&buf, map[string]string{
+		"greeting":      "Hej {name}, välkommen!",
+		"key with=sep:": " leading space\tand tab",
+		"emoji":         "Smile 😀\nnext line \\ done",
+	})
+	assert.NoError(err)
+	assert.Equal(`emoji=Smile \ud83d\ude00\nnext line \\ done
+greeting=Hej {name}, v\u00e4lkommen!
+key\ with\=sep\:=\ leading space\tand tab
+`, buf.String())
+}
+
+func TestWriteYAML(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	err := resource.WriteYAML(&buf, map[string]string{
+		"greeting": "Hej \"{name}\": välkommen!",
+		"yes":      "One\nTwo",
+	})
+	assert.NoError(err)
+	assert.Equal(`"greeting": "Hej \"{name}\": välkommen!"
+"yes": "One\nTwo"
+`, buf.String())
+
+	buf.Reset()
+	err = resource.WriteYAML(&buf, map[string]string{})
+	assert.NoError(err)
+	assert.Equal("{}\n", buf.String())
+}
+
+func TestI18nextWriter(t *testing.T) {
+	assert := assert.New(t)
+	texts := map[string]string{
+		"menu.file.open":  "Open",
+		"menu.file.close": "Close <file>",
+		"menu":            "Menu",
+		"menu.edit":       "Edit",
+		"title":           "Title",
+		"broken..key":     "Broken",
+	}
+
+	var buf bytes.Buffer
+	err := resource.I18nextWriter(".")(&buf, texts)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"menu": "Menu",
+		"menu.edit": "Edit",
+		"menu.file.close": "Close <file>",
+		"menu.file.open": "Open",
+		"title": "Title",
+		"broken..key": "Broken"
+	}`, buf.String())
+
+	delete(texts, "menu")
+	buf.Reset()
+	err = resource.I18nextWriter(".")(&buf, texts)
+	assert.NoError(err)
+	assert.JSONEq(`{
+		"menu": {"edit": "Edit", "file": {"close": "Close <file>", "open": "Open"}},
+		"title": "Title",
+		"broken..key": "Broken"
+	}`, buf.String())
+	assert.Contains(buf.String(), "Close <file>")
+
+	buf.Reset()
+	err = resource.I18nextWriter("_")(&buf, map[string]string{"menu_file": "File", "menu.edit": "Edit"})
+	assert.NoError(err)
+	assert.JSONEq(`{"menu": {"file": "File"}, "menu.edit": "Edit"}`, buf.String())
+}
@@ -0,0 +1,22 @@
+package resource
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+)
+
+// WriteYAML writes texts as a YAML mapping of keys to values. Keys and values are
+// written as double quoted scalars, which support the escape sequences of Go strings.
+func WriteYAML(w io.Writer, texts map[string]string) error {
+	bw := bufio.NewWriter(w)
+	if len(texts) == 0 {
+		bw.WriteString("{}\n")
+	}
+
+	for _, key := range sortedKeys(texts) {
+		bw.WriteString(strconv.Quote(key) + ": " + strconv.Quote(texts[key]) + "\n")
+	}
+
+	return bw.Flush()
+}
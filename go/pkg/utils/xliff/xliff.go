@@ -0,0 +1,280 @@
+// Package xliff reads and writes XLIFF 1.2 and 2.0 documents of plain text
+// translation units, as exchanged with translation agencies.
+package xliff
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// Supported XLIFF versions.
+const (
+	Version12 = "1.2"
+	Version20 = "2.0"
+)
+
+const (
+	namespace12 = "urn:oasis:names:tc:xliff:document:1.2"
+	namespace20 = "urn:oasis:names:tc:xliff:document:2.0"
+)
+
+// Unit translation states, in the vocabulary of each version.
+const (
+	state12New        = "new"
+	state12Needs      = "needs-translation"
+	state12Translated = "translated"
+	state20Initial    = "initial"
+	state20Translated = "translated"
+)
+
+const (
+	fileID       = "texts"
+	fileDatatype = "plaintext"
+)
+
+// Document XLIFF document of units translated from the source to the target language.
+type Document struct {
+	Version        string
+	SourceLanguage string
+	TargetLanguage string
+	Units          []Unit
+}
+
+// Unit translation unit, identified by the key of the text it translates.
+type Unit struct {
+	ID     string
+	Source string
+	Target string
+	State  string
+}
+
+// Translated checks if the unit has a target which is not marked as a new or untranslated.
+func (u Unit) Translated() bool {
+	switch u.State {
+	case state12New, state12Needs, state20Initial:
+		return false
+	}
+
+	return u.Target != ""
+}
+
+type document12 struct {
+	XMLName xml.Name `xml:"xliff"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+	Version string   `xml:"version,attr"`
+	Files   []file12 `xml:"file"`
+}
+
+type file12 struct {
+	Original       string   `xml:"original,attr"`
+	SourceLanguage string   `xml:"source-language,attr"`
+	TargetLanguage string   `xml:"target-language,attr,omitempty"`
+	Datatype       string   `xml:"datatype,attr"`
+	Units          []unit12 `xml:"body>trans-unit"`
+}
+
+type unit12 struct {
+	ID      string    `xml:"id,attr"`
+	Resname string    `xml:"resname,attr,omitempty"`
+	Source  string    `xml:"source"`
+	Target  *target12 `xml:"target"`
+}
+
+type target12 struct {
+	State string `xml:"state,attr,omitempty"`
+	Text  string `xml:",chardata"`
+}
+
+type document20 struct {
+	XMLName        xml.Name `xml:"xliff"`
+	Xmlns          string   `xml:"xmlns,attr,omitempty"`
+	Version        string   `xml:"version,attr"`
+	SourceLanguage string   `xml:"srcLang,attr"`
+	TargetLanguage string   `xml:"trgLang,attr,omitempty"`
+	Files          []file20 `xml:"file"`
+}
+
+type file20 struct {
+	ID    string   `xml:"id,attr"`
+	Units []unit20 `xml:"unit"`
+}
+
+type unit20 struct {
+	ID       string      `xml:"id,attr"`
+	Segments []segment20 `xml:"segment"`
+}
+
+type segment20 struct {
+	State  string `xml:"state,attr,omitempty"`
+	Source string `xml:"source"`
+	Target string `xml:"target,omitempty"`
+}
+
+// Write writes a document in its version, defaulting to XLIFF 1.2.
+func Write(w io.Writer, doc Document) error {
+	var root interface{}
+	switch doc.Version {
+	case Version12, "":
+		root = toDocument12(doc)
+	case Version20:
+		root = toDocument20(doc)
+	default:
+		return fmt.Errorf("unsupported xliff version: %s", doc.Version)
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	err = enc.Encode(root)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+func toDocument12(doc Document) document12 {
+	file := file12{
+		Original:       fileID,
+		SourceLanguage: doc.SourceLanguage,
+		TargetLanguage: doc.TargetLanguage,
+		Datatype:       fileDatatype,
+		Units:          make([]unit12, 0, len(doc.Units)),
+	}
+
+	for _, u := range doc.Units {
+		state := state12Translated
+		if u.Target == "" {
+			state = state12Needs
+		}
+
+		file.Units = append(file.Units, unit12{
+			ID:      u.ID,
+			Resname: u.ID,
+			Source:  u.Source,
+			Target:  &target12{State: state, Text: u.Target},
+		})
+	}
+
+	return document12{Xmlns: namespace12, Version: Version12, Files: []file12{file}}
+}
+
+func toDocument20(doc Document) document20 {
+	file := file20{ID: fileID, Units: make([]unit20, 0, len(doc.Units))}
+	for _, u := range doc.Units {
+		state := state20Translated
+		if u.Target == "" {
+			state = state20Initial
+		}
+
+		file.Units = append(file.Units, unit20{
+			ID:       u.ID,
+			Segments: []segment20{{State: state, Source: u.Source, Target: u.Target}},
+		})
+	}
+
+	return document20{
+		Xmlns:          namespace20,
+		Version:        Version20,
+		SourceLanguage: doc.SourceLanguage,
+		TargetLanguage: doc.TargetLanguage,
+		Files:          []file20{file},
+	}
+}
+
+// Parse parses an XLIFF 1.2 or 2.0 document. The units of all files are
+// combined and the segments of an XLIFF 2.0 unit are joined.
+func Parse(r io.Reader) (Document, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return Document{}, err
+	}
+
+	var root struct {
+		XMLName xml.Name
+		Version string `xml:"version,attr"`
+	}
+	err = xml.Unmarshal(data, &root)
+	if err != nil {
+		return Document{}, err
+	}
+
+	if root.XMLName.Local != "xliff" {
+		return Document{}, fmt.Errorf("expected an xliff document, found <%s>", root.XMLName.Local)
+	}
+
+	switch {
+	case strings.HasPrefix(root.Version, "1."):
+		return parse12(data)
+	case strings.HasPrefix(root.Version, "2."):
+		return parse20(data)
+	default:
+		return Document{}, fmt.Errorf("unsupported xliff version: %s", root.Version)
+	}
+}
+
+func parse12(data []byte) (Document, error) {
+	var raw document12
+	err := xml.NewDecoder(bytes.NewReader(data)).Decode(&raw)
+	if err != nil {
+		return Document{}, err
+	}
+
+	doc := Document{Version: Version12, Units: make([]Unit, 0)}
+	for i, file := range raw.Files {
+		if i == 0 {
+			doc.SourceLanguage = file.SourceLanguage
+			doc.TargetLanguage = file.TargetLanguage
+		}
+
+		for _, u := range file.Units {
+			unit := Unit{ID: u.ID, Source: u.Source}
+			if u.Target != nil {
+				unit.Target = u.Target.Text
+				unit.State = u.Target.State
+			}
+			doc.Units = append(doc.Units, unit)
+		}
+	}
+
+	return doc, nil
+}
+
+func parse20(data []byte) (Document, error) {
+	var raw document20
+	err := xml.NewDecoder(bytes.NewReader(data)).Decode(&raw)
+	if err != nil {
+		return Document{}, err
+	}
+
+	doc := Document{
+		Version:        Version20,
+		SourceLanguage: raw.SourceLanguage,
+		TargetLanguage: raw.TargetLanguage,
+		Units:          make([]Unit, 0),
+	}
+	for _, file := range raw.Files {
+		for _, u := range file.Units {
+			unit := Unit{ID: u.ID}
+			for _, segment := range u.Segments {
+				unit.Source += segment.Source
+				unit.Target += segment.Target
+				if unit.State == "" || segment.State == state20Initial {
+					unit.State = segment.State
+				}
+			}
+			doc.Units = append(doc.Units, unit)
+		}
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,79 @@
+package xliff_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/xliff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteAndParse(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, version := range []string{xliff.Version12, xliff.Version20} {
+		doc := xliff.Document{
+			Version:        version,
+			SourceLanguage: "en",
+			TargetLanguage: "sv",
+			Units: []xliff.Unit{
+				{ID: "GREETING", Source: "Hello <b>{name}</b> & welcome", Target: "Hej <b>{name}</b> & välkommen"},
+				{ID: "FAREWELL", Source: "Goodbye"},
+			},
+		}
+
+		var buf bytes.Buffer
+		err := xliff.Write(&buf, doc)
+		assert.NoError(err, version)
+		assert.Contains(buf.String(), `version="`+version+`"`)
+		assert.Contains(buf.String(), "&lt;b&gt;{name}&lt;/b&gt; &amp; welcome")
+
+		parsed, err := xliff.Parse(&buf)
+		assert.NoError(err, version)
+		assert.Equal(version, parsed.Version)
+		assert.Equal("en", parsed.SourceLanguage)
+		assert.Equal("sv", parsed.TargetLanguage)
+		assert.Len(parsed.Units, 2)
+		assert.Equal("GREETING", parsed.Units[0].ID)
+		assert.Equal(doc.Units[0].Source, parsed.Units[0].Source)
+		assert.Equal(doc.Units[0].Target, parsed.Units[0].Target)
+		assert.True(parsed.Units[0].Translated())
+		assert.Equal("", parsed.Units[1].Target)
+		assert.False(parsed.Units[1].Translated())
+	}
+}
+
+func TestParse(t *testing.T) {
+	assert := assert.New(t)
+
+	doc, err := xliff.Parse(strings.NewReader(`<?xml version="1.0"?>
+<xliff version="1.2" xmlns="urn:oasis:names:tc:xliff:document:1.2">
+  <file original="app" source-language="en" target-language="de" datatype="plaintext">
+    <body>
+      <trans-unit id="A"><source>One</source><target state="needs-translation">One</target></trans-unit>
+      <trans-unit id="B"><source>Two</source><target>Zwei</target></trans-unit>
+    </body>
+  </file>
+</xliff>`))
+	assert.NoError(err)
+	assert.Equal("de", doc.TargetLanguage)
+	assert.False(doc.Units[0].Translated())
+	assert.True(doc.Units[1].Translated())
+
+	doc, err = xliff.Parse(strings.NewReader(`<xliff version="2.0" srcLang="en" trgLang="de">
+  <file id="f1">
+    <unit id="A">
+      <segment state="translated"><source>One. </source><target>Eins. </target></segment>
+      <segment state="translated"><source>Two.</source><target>Zwei.</target></segment>
+    </unit>
+  </file>
+</xliff>`))
+	assert.NoError(err)
+	assert.Equal([]xliff.Unit{{ID: "A", Source: "One. Two.", Target: "Eins. Zwei.", State: "translated"}}, doc.Units)
+
+	for _, body := range []string{"", "<resources/>", `<xliff version="3.0"/>`, "<xliff"} {
+		_, err = xliff.Parse(strings.NewReader(body))
+		assert.Error(err, body)
+	}
+}
@@ -0,0 +1,330 @@
+// Package xlsx reads and writes the first worksheet of Office Open XML (.xlsx)
+// workbooks as rows of text cells, which is all spreadsheets of texts need.
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// MaxEntrySize max uncompressed size in bytes of a file in a workbook, larger files are not read.
+const MaxEntrySize = 64 << 20
+
+// Errors returned when reading workbooks.
+var (
+	ErrNoWorksheet   = errors.New("xlsx: workbook has no worksheet")
+	ErrEntryTooLarge = errors.New("xlsx: workbook file exceeds the max uncompressed size")
+)
+
+// IsXLSX checks if data starts with the signature of a zip archive, which xlsx files are.
+func IsXLSX(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("PK\x03\x04"))
+}
+
+const contentTypesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+
+const rootRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+const workbookXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+const workbookRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`
+
+// Write writes rows as a workbook with a single worksheet of inline string cells.
+func Write(w io.Writer, sheetName string, rows [][]string) error {
+	var name bytes.Buffer
+	xml.EscapeText(&name, []byte(sheetName))
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{name: "[Content_Types].xml", content: contentTypesXML},
+		{name: "_rels/.rels", content: rootRelsXML},
+		{name: "xl/workbook.xml", content: fmt.Sprintf(workbookXML, name.String())},
+		{name: "xl/_rels/workbook.xml.rels", content: workbookRelsXML},
+		{name: "xl/worksheets/sheet1.xml", content: worksheetXML(rows)},
+	}
+
+	zw := zip.NewWriter(w)
+	for _, part := range parts {
+		fw, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(fw, part.content)
+		if err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func worksheetXML(rows [][]string) string {
+	var b bytes.Buffer
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for i, row := range rows {
+		fmt.Fprintf(&b, `<row r="%d">`, i+1)
+		for j, value := range row {
+			if value == "" {
+				continue
+			}
+
+			fmt.Fprintf(&b, `<c r="%s%d" t="inlineStr"><is><t xml:space="preserve">`, columnName(j), i+1)
+			xml.EscapeText(&b, []byte(value))
+			b.WriteString(`</t></is></c>`)
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+
+	return b.String()
+}
+
+// columnName converts a zero based column index to its name, e.g. 0 to A and 27 to AB.
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+
+	return name
+}
+
+// columnIndex converts a cell reference, e.g. AB3, to the zero based index of its column.
+func columnIndex(ref string) (int, error) {
+	i := 0
+	n := 0
+	for ; n < len(ref) && ref[n] >= 'A' && ref[n] <= 'Z'; n++ {
+		i = i*26 + int(ref[n]-'A'+1)
+	}
+
+	if n == 0 {
+		return 0, fmt.Errorf("xlsx: invalid cell reference %q", ref)
+	}
+
+	return i - 1, nil
+}
+
+type relationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type workbook struct {
+	Sheets []struct {
+		ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type sharedStrings struct {
+	Items []richText `xml:"si"`
+}
+
+// richText text of a shared or inline string, either plain or made up of formatted runs.
+type richText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (r richText) String() string {
+	if len(r.Runs) == 0 {
+		return r.T
+	}
+
+	var b strings.Builder
+	for _, run := range r.Runs {
+		b.WriteString(run.T)
+	}
+
+	return b.String()
+}
+
+type worksheet struct {
+	Rows []struct {
+		Index int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline richText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// Read reads the cells of the first worksheet of a workbook as rows of text. Numbers
+// and booleans are read as written in the file, empty rows and cells are kept.
+func Read(r io.ReaderAt, size int64) ([][]string, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var strs sharedStrings
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		err = decodeFile(f, &strs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, ErrNoWorksheet
+	}
+
+	var sheet worksheet
+	err = decodeFile(f, &sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		for row.Index > len(rows)+1 {
+			rows = append(rows, []string{})
+		}
+
+		cells := make([]string, 0, len(row.Cells))
+		for _, c := range row.Cells {
+			col := len(cells)
+			if c.Ref != "" {
+				col, err = columnIndex(c.Ref)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			for col >= len(cells) {
+				cells = append(cells, "")
+			}
+
+			cells[col], err = cellValue(c.Type, c.Value, c.Inline, strs)
+			if err != nil {
+				return nil, err
+			}
+		}
+		rows = append(rows, cells)
+	}
+
+	return rows, nil
+}
+
+func cellValue(typ, value string, inline richText, strs sharedStrings) (string, error) {
+	switch typ {
+	case "s":
+		i, err := strconv.Atoi(value)
+		if err != nil || i < 0 || i >= len(strs.Items) {
+			return "", fmt.Errorf("xlsx: invalid shared string %q", value)
+		}
+		return strs.Items[i].String(), nil
+	case "inlineStr":
+		return inline.String(), nil
+	default:
+		return value, nil
+	}
+}
+
+// firstSheetPath finds the path of the first worksheet of the workbook through its relationships.
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	f, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", ErrNoWorksheet
+	}
+
+	var wb workbook
+	err := decodeFile(f, &wb)
+	if err != nil {
+		return "", err
+	}
+	if len(wb.Sheets) == 0 {
+		return "", ErrNoWorksheet
+	}
+
+	f, ok = files["xl/_rels/workbook.xml.rels"]
+	if !ok {
+		return "xl/worksheets/sheet1.xml", nil
+	}
+
+	var rels relationships
+	err = decodeFile(f, &rels)
+	if err != nil {
+		return "", err
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.ID != wb.Sheets[0].ID {
+			continue
+		}
+
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+
+	return "", ErrNoWorksheet
+}
+
+// decodeFile decodes a file of the workbook, reading at most MaxEntrySize bytes as the size
+// recorded in the archive may not match the actual size of the file.
+func decodeFile(f *zip.File, v interface{}) error {
+	if f.UncompressedSize64 > MaxEntrySize {
+		return ErrEntryTooLarge
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	r := &io.LimitedReader{R: rc, N: MaxEntrySize + 1}
+	err = xml.NewDecoder(r).Decode(v)
+	if r.N <= 0 {
+		return ErrEntryTooLarge
+	}
+	if err != nil {
+		return fmt.Errorf("xlsx: invalid %s: %v", f.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package xlsx_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/CzarSimon/text-service/go/pkg/utils/xlsx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteAndRead(t *testing.T) {
+	assert := assert.New(t)
+
+	rows := [][]string{
+		{"key", "sv", "en"},
+		{"GREETING", "Hej <b>{name}</b> & välkommen", "  Hello\n{name}  "},
+		{"FAREWELL", "", "Goodbye"},
+	}
+	columns := make([]string, 30)
+	columns[0] = "WIDE"
+	columns[29] = "last"
+	rows = append(rows, columns)
+
+	var buf bytes.Buffer
+	err := xlsx.Write(&buf, "Texts & more", rows)
+	assert.NoError(err)
+	assert.True(xlsx.IsXLSX(buf.Bytes()))
+
+	read, err := xlsx.Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(err)
+	assert.Equal(rows, read)
+
+	assert.False(xlsx.IsXLSX([]byte("key,sv,en\n")))
+	_, err = xlsx.Read(bytes.NewReader([]byte("key,sv,en\n")), 10)
+	assert.Error(err)
+}
+
+func TestReadSharedStrings(t *testing.T) {
+	assert := assert.New(t)
+
+	data := createWorkbook(t, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="First" sheetId="1" r:id="rId3"/><sheet name="Second" sheetId="2" r:id="rId1"/></sheets>
+		</workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId1" Target="worksheets/sheet2.xml"/>
+			<Relationship Id="rId3" Target="/xl/worksheets/sheet1.xml"/>
+		</Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+			<si><t>key</t></si>
+			<si><t>sv</t></si>
+			<si><r><t>Hej </t></r><r><rPr><b/></rPr><t>där</t></r></si>
+		</sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row>
+			<row r="3"><c r="A3" t="str"><v>GREETING</v></c><c r="C3" t="s"><v>2</v></c><c r="D3"><v>42</v></c></row>
+		</sheetData></worksheet>`,
+		"xl/worksheets/sheet2.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData/></worksheet>`,
+	})
+
+	rows, err := xlsx.Read(bytes.NewReader(data), int64(len(data)))
+	assert.NoError(err)
+	assert.Equal([][]string{
+		{"key", "", "sv"},
+		{},
+		{"GREETING", "", "Hej där", "42"},
+	}, rows)
+
+	data = createWorkbook(t, map[string]string{
+		"xl/workbook.xml": `<workbook><sheets/></workbook>`,
+	})
+	_, err = xlsx.Read(bytes.NewReader(data), int64(len(data)))
+	assert.Equal(xlsx.ErrNoWorksheet, err)
+}
+
+func TestReadEntryTooLarge(t *testing.T) {
+	assert := assert.New(t)
+
+	worksheet := `<worksheet><sheetData>` + strings.Repeat(" ", xlsx.MaxEntrySize) + `</sheetData></worksheet>`
+	data := createWorkbook(t, map[string]string{
+		"xl/workbook.xml":          `<workbook><sheets><sheet name="First" sheetId="1"/></sheets></workbook>`,
+		"xl/worksheets/sheet1.xml": worksheet,
+	})
+	assert.True(len(data) < 1<<20)
+
+	_, err := xlsx.Read(bytes.NewReader(data), int64(len(data)))
+	assert.Equal(xlsx.ErrEntryTooLarge, err)
+}
+
+func createWorkbook(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(content))
+	}
+
+	err := w.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
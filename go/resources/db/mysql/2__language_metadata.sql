@@ -0,0 +1,24 @@
+-- +migrate Up
+SET FOREIGN_KEY_CHECKS = 0;
+ALTER TABLE `language` MODIFY COLUMN `id` VARCHAR(35) NOT NULL;
+SET FOREIGN_KEY_CHECKS = 1;
+
+ALTER TABLE `language`
+  ADD COLUMN `english_name` VARCHAR(100) NOT NULL DEFAULT '',
+  ADD COLUMN `native_name`  VARCHAR(100) NOT NULL DEFAULT '',
+  ADD COLUMN `direction`    VARCHAR(3) NOT NULL DEFAULT 'ltr',
+  ADD COLUMN `fallback`     VARCHAR(35),
+  ADD COLUMN `enabled`      BOOLEAN NOT NULL DEFAULT TRUE,
+  ADD FOREIGN KEY (`fallback`) REFERENCES `language`(`id`);
+
+-- +migrate Down
+ALTER TABLE `language`
+  DROP COLUMN `enabled`,
+  DROP COLUMN `fallback`,
+  DROP COLUMN `direction`,
+  DROP COLUMN `native_name`,
+  DROP COLUMN `english_name`;
+
+SET FOREIGN_KEY_CHECKS = 0;
+ALTER TABLE `language` MODIFY COLUMN `id` VARCHAR(5) NOT NULL;
+SET FOREIGN_KEY_CHECKS = 1;
@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE `text_draft` (
+  `id`         INT AUTO_INCREMENT PRIMARY KEY,
+  `key`        VARCHAR(100) NOT NULL,
+  `language`   VARCHAR(50) NOT NULL,
+  `value`      TEXT NOT NULL,
+  `changed_by` VARCHAR(100) NOT NULL,
+  `created_at` TIMESTAMP NOT NULL,
+  `updated_at` TIMESTAMP NOT NULL,
+  FOREIGN KEY (`language`) REFERENCES `language`(`id`),
+  UNIQUE(`key`, `language`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;;
+
+-- +migrate Down
+DROP TABLE IF EXISTS `text_draft`;
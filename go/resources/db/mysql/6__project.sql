@@ -0,0 +1,81 @@
+-- +migrate Up
+CREATE TABLE `project` (
+  `id`          VARCHAR(100) PRIMARY KEY,
+  `description` VARCHAR(255) NOT NULL,
+  `created_at`  TIMESTAMP NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;;
+
+INSERT INTO `project`(`id`, `description`, `created_at`) VALUES ('default', 'Default project', CURRENT_TIMESTAMP);
+
+ALTER TABLE `translated_text` ADD COLUMN `project` VARCHAR(100) NOT NULL DEFAULT 'default' REFERENCES `project`(`id`);
+ALTER TABLE `translated_text` DROP INDEX `key`;
+ALTER TABLE `translated_text` ADD CONSTRAINT `translated_text_project_key_language_key` UNIQUE (`project`, `key`, `language`);
+
+ALTER TABLE `text_group_membership` DROP FOREIGN KEY `text_group_membership_ibfk_1`;
+ALTER TABLE `text_group_membership` ADD COLUMN `project` VARCHAR(100) NOT NULL DEFAULT 'default';
+ALTER TABLE `text_group` ADD COLUMN `project` VARCHAR(100) NOT NULL DEFAULT 'default' REFERENCES `project`(`id`);
+ALTER TABLE `text_group` DROP PRIMARY KEY;
+ALTER TABLE `text_group` ADD PRIMARY KEY (`project`, `id`);
+ALTER TABLE `text_group_membership` ADD CONSTRAINT `text_group_membership_project_group_id_fkey`
+  FOREIGN KEY (`project`, `group_id`) REFERENCES `text_group`(`project`, `id`);
+
+ALTER TABLE `text_history` ADD COLUMN `project` VARCHAR(100) NOT NULL DEFAULT 'default';
+
+ALTER TABLE `release_text` DROP FOREIGN KEY `release_text_ibfk_1`;
+ALTER TABLE `release_text` DROP INDEX `release_id`;
+ALTER TABLE `release_text` ADD COLUMN `project` VARCHAR(100) NOT NULL DEFAULT 'default';
+ALTER TABLE `release_group_membership` DROP FOREIGN KEY `release_group_membership_ibfk_1`;
+ALTER TABLE `release_group_membership` DROP INDEX `release_id`;
+ALTER TABLE `release_group_membership` ADD COLUMN `project` VARCHAR(100) NOT NULL DEFAULT 'default';
+ALTER TABLE `text_release` ADD COLUMN `project` VARCHAR(100) NOT NULL DEFAULT 'default' REFERENCES `project`(`id`);
+ALTER TABLE `text_release` DROP PRIMARY KEY;
+ALTER TABLE `text_release` ADD PRIMARY KEY (`project`, `id`);
+ALTER TABLE `release_text` ADD CONSTRAINT `release_text_project_release_id_fkey`
+  FOREIGN KEY (`project`, `release_id`) REFERENCES `text_release`(`project`, `id`);
+ALTER TABLE `release_text` ADD CONSTRAINT `release_text_project_release_id_key_language_key`
+  UNIQUE (`project`, `release_id`, `key`, `language`);
+ALTER TABLE `release_group_membership` ADD CONSTRAINT `release_group_membership_project_release_id_fkey`
+  FOREIGN KEY (`project`, `release_id`) REFERENCES `text_release`(`project`, `id`);
+ALTER TABLE `release_group_membership` ADD CONSTRAINT `release_group_membership_project_release_id_group_id_text_key_key`
+  UNIQUE (`project`, `release_id`, `group_id`, `text_key`);
+
+ALTER TABLE `text_draft` ADD COLUMN `project` VARCHAR(100) NOT NULL DEFAULT 'default' REFERENCES `project`(`id`);
+ALTER TABLE `text_draft` DROP INDEX `key`;
+ALTER TABLE `text_draft` ADD CONSTRAINT `text_draft_project_key_language_key` UNIQUE (`project`, `key`, `language`);
+
+-- +migrate Down
+DELETE FROM `translated_text` WHERE `project` <> 'default';
+DELETE FROM `text_group_membership` WHERE `project` <> 'default';
+DELETE FROM `text_group` WHERE `project` <> 'default';
+DELETE FROM `text_history` WHERE `project` <> 'default';
+DELETE FROM `release_group_membership` WHERE `project` <> 'default';
+DELETE FROM `release_text` WHERE `project` <> 'default';
+DELETE FROM `text_release` WHERE `project` <> 'default';
+DELETE FROM `text_draft` WHERE `project` <> 'default';
+
+ALTER TABLE `text_draft` DROP COLUMN `project`;
+ALTER TABLE `text_draft` ADD CONSTRAINT `text_draft_key_language_key` UNIQUE (`key`, `language`);
+
+ALTER TABLE `release_group_membership` DROP COLUMN `project`;
+ALTER TABLE `release_text` DROP COLUMN `project`;
+ALTER TABLE `text_release` DROP PRIMARY KEY, DROP COLUMN `project`, ADD PRIMARY KEY (`id`);
+ALTER TABLE `release_text` ADD CONSTRAINT `release_text_release_id_fkey`
+  FOREIGN KEY (`release_id`) REFERENCES `text_release`(`id`);
+ALTER TABLE `release_text` ADD CONSTRAINT `release_text_release_id_key_language_key`
+  UNIQUE (`release_id`, `key`, `language`);
+ALTER TABLE `release_group_membership` ADD CONSTRAINT `release_group_membership_release_id_fkey`
+  FOREIGN KEY (`release_id`) REFERENCES `text_release`(`id`);
+ALTER TABLE `release_group_membership` ADD CONSTRAINT `release_group_membership_release_id_group_id_text_key_key`
+  UNIQUE (`release_id`, `group_id`, `text_key`);
+
+ALTER TABLE `text_history` DROP COLUMN `project`;
+
+ALTER TABLE `text_group_membership` DROP COLUMN `project`;
+ALTER TABLE `text_group` DROP PRIMARY KEY, DROP COLUMN `project`, ADD PRIMARY KEY (`id`);
+ALTER TABLE `text_group_membership` ADD CONSTRAINT `text_group_membership_group_id_fkey`
+  FOREIGN KEY (`group_id`) REFERENCES `text_group`(`id`);
+
+ALTER TABLE `translated_text` DROP COLUMN `project`;
+ALTER TABLE `translated_text` ADD CONSTRAINT `translated_text_key_language_key` UNIQUE (`key`, `language`);
+
+DROP TABLE IF EXISTS `project`;
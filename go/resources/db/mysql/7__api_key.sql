@@ -0,0 +1,11 @@
+-- +migrate Up
+CREATE TABLE `api_key` (
+  `id`         VARCHAR(50) PRIMARY KEY,
+  `name`       VARCHAR(100) NOT NULL,
+  `role`       VARCHAR(20) NOT NULL,
+  `key_hash`   VARCHAR(64) NOT NULL UNIQUE,
+  `created_at` TIMESTAMP NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;;
+
+-- +migrate Down
+DROP TABLE IF EXISTS `api_key`;
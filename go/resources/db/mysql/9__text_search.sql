@@ -0,0 +1,5 @@
+-- +migrate Up
+CREATE FULLTEXT INDEX `translated_text_value_search_idx` ON `translated_text`(`value`);
+
+-- +migrate Down
+DROP INDEX `translated_text_value_search_idx` ON `translated_text`;
@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE `text_group` ADD COLUMN `members_changed_at` TIMESTAMP;
+ALTER TABLE `text_release` ADD COLUMN `current_changed_at` TIMESTAMP;
+
+-- +migrate Down
+ALTER TABLE `text_release` DROP COLUMN `current_changed_at`;
+ALTER TABLE `text_group` DROP COLUMN `members_changed_at`;
@@ -0,0 +1,15 @@
+-- +migrate Up
+ALTER TABLE `language` ALTER COLUMN `id` TYPE VARCHAR(35);
+ALTER TABLE `language` ADD COLUMN `english_name` VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE `language` ADD COLUMN `native_name` VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE `language` ADD COLUMN `direction` VARCHAR(3) NOT NULL DEFAULT 'ltr';
+ALTER TABLE `language` ADD COLUMN `fallback` VARCHAR(35) REFERENCES `language`(`id`);
+ALTER TABLE `language` ADD COLUMN `enabled` BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- +migrate Down
+ALTER TABLE `language` DROP COLUMN `enabled`;
+ALTER TABLE `language` DROP COLUMN `fallback`;
+ALTER TABLE `language` DROP COLUMN `direction`;
+ALTER TABLE `language` DROP COLUMN `native_name`;
+ALTER TABLE `language` DROP COLUMN `english_name`;
+ALTER TABLE `language` ALTER COLUMN `id` TYPE VARCHAR(5);
@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE `text_history` (
+  `id`         SERIAL PRIMARY KEY,
+  `key`        VARCHAR(100) NOT NULL,
+  `language`   VARCHAR(50) NOT NULL,
+  `value`      TEXT NOT NULL,
+  `action`     VARCHAR(20) NOT NULL,
+  `changed_by` VARCHAR(100) NOT NULL,
+  `created_at` TIMESTAMP NOT NULL
+);
+
+CREATE INDEX `text_history_key_language_idx` ON `text_history`(`key`, `language`);
+
+INSERT INTO `text_history`(`key`, `language`, `value`, `action`, `changed_by`, `created_at`)
+  SELECT `key`, `language`, `value`, 'CREATED', '', `updated_at` FROM `translated_text`;
+
+-- +migrate Down
+DROP TABLE IF EXISTS `text_history`;
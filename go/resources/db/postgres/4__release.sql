@@ -0,0 +1,33 @@
+-- +migrate Up
+CREATE TABLE `text_release` (
+  `id`          VARCHAR(100) PRIMARY KEY,
+  `description` VARCHAR(255) NOT NULL,
+  `is_current`  BOOLEAN NOT NULL DEFAULT FALSE,
+  `created_at`  TIMESTAMP NOT NULL
+);
+
+CREATE TABLE `release_text` (
+  `id`         SERIAL PRIMARY KEY,
+  `release_id` VARCHAR(100) NOT NULL,
+  `key`        VARCHAR(100) NOT NULL,
+  `language`   VARCHAR(50) NOT NULL,
+  `value`      TEXT NOT NULL,
+  `created_at` TIMESTAMP NOT NULL,
+  `updated_at` TIMESTAMP NOT NULL,
+  FOREIGN KEY (`release_id`) REFERENCES `text_release`(`id`),
+  UNIQUE(`release_id`, `key`, `language`)
+);
+
+CREATE TABLE `release_group_membership` (
+  `id`         SERIAL PRIMARY KEY,
+  `release_id` VARCHAR(100) NOT NULL,
+  `group_id`   VARCHAR(100) NOT NULL,
+  `text_key`   VARCHAR(100) NOT NULL,
+  FOREIGN KEY (`release_id`) REFERENCES `text_release`(`id`),
+  UNIQUE(`release_id`, `group_id`, `text_key`)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `release_group_membership`;
+DROP TABLE IF EXISTS `release_text`;
+DROP TABLE IF EXISTS `text_release`;
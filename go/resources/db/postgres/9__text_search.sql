@@ -0,0 +1,5 @@
+-- +migrate Up
+CREATE INDEX `translated_text_value_search_idx` ON `translated_text` USING GIN (to_tsvector('simple', `value`));
+
+-- +migrate Down
+DROP INDEX IF EXISTS `translated_text_value_search_idx`;
@@ -0,0 +1,27 @@
+-- +migrate Up
+CREATE TABLE `gettext_entry` (
+  `project`             VARCHAR(100) NOT NULL,
+  `text_key`            VARCHAR(100) NOT NULL,
+  `language`            VARCHAR(50) NOT NULL,
+  `msgctxt`             TEXT NOT NULL,
+  `msgid`               TEXT NOT NULL,
+  `msgid_plural`        TEXT NOT NULL,
+  `translator_comments` TEXT NOT NULL,
+  `extracted_comments`  TEXT NOT NULL,
+  `source_references`   TEXT NOT NULL,
+  `flags`               VARCHAR(255) NOT NULL,
+  `updated_at`          DATETIME NOT NULL,
+  PRIMARY KEY (`project`, `text_key`, `language`)
+);
+
+CREATE TABLE `gettext_header` (
+  `project`    VARCHAR(100) NOT NULL,
+  `language`   VARCHAR(50) NOT NULL,
+  `header`     TEXT NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  PRIMARY KEY (`project`, `language`)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `gettext_header`;
+DROP TABLE IF EXISTS `gettext_entry`;
@@ -0,0 +1,6 @@
+-- +migrate Up
+ALTER TABLE `text_group` ADD COLUMN `members_changed_at` DATETIME;
+ALTER TABLE `text_release` ADD COLUMN `current_changed_at` DATETIME;
+
+-- +migrate Down
+-- sqlite cannot drop columns, the nullable columns are left in place.
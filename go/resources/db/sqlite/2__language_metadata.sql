@@ -0,0 +1,16 @@
+-- +migrate Up
+ALTER TABLE `language` ADD COLUMN `english_name` VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE `language` ADD COLUMN `native_name` VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE `language` ADD COLUMN `direction` VARCHAR(3) NOT NULL DEFAULT 'ltr';
+ALTER TABLE `language` ADD COLUMN `fallback` VARCHAR(35) REFERENCES `language`(`id`);
+ALTER TABLE `language` ADD COLUMN `enabled` BOOLEAN NOT NULL DEFAULT 1;
+
+-- +migrate Down
+CREATE TABLE `language_backup` (
+  `id`         VARCHAR(5) NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  PRIMARY KEY (`id`)
+);
+INSERT INTO `language_backup` SELECT `id`, `created_at` FROM `language`;
+DROP TABLE `language`;
+ALTER TABLE `language_backup` RENAME TO `language`;
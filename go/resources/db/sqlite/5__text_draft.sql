@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE `text_draft` (
+  `id`         INTEGER PRIMARY KEY,
+  `key`        VARCHAR(100) NOT NULL,
+  `language`   VARCHAR(50) NOT NULL,
+  `value`      TEXT NOT NULL,
+  `changed_by` VARCHAR(100) NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  FOREIGN KEY (`language`) REFERENCES `language`(`id`),
+  UNIQUE(`key`, `language`)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `text_draft`;
@@ -0,0 +1,127 @@
+-- +migrate Up
+CREATE TABLE `project` (
+  `id`          VARCHAR(100) PRIMARY KEY,
+  `description` VARCHAR(255) NOT NULL,
+  `created_at`  DATETIME NOT NULL
+);
+
+INSERT INTO `project`(`id`, `description`, `created_at`) VALUES ('default', 'Default project', CURRENT_TIMESTAMP);
+
+CREATE TABLE `translated_text_project` (
+  `id`         INTEGER PRIMARY KEY,
+  `project`    VARCHAR(100) NOT NULL DEFAULT 'default',
+  `key`        VARCHAR(100) NOT NULL,
+  `language`   VARCHAR(50) NOT NULL,
+  `value`      TEXT NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  FOREIGN KEY (`project`) REFERENCES `project`(`id`),
+  FOREIGN KEY (`language`) REFERENCES `language`(`id`),
+  UNIQUE(`project`, `key`, `language`)
+);
+INSERT INTO `translated_text_project`(`id`, `key`, `language`, `value`, `created_at`, `updated_at`)
+  SELECT `id`, `key`, `language`, `value`, `created_at`, `updated_at` FROM `translated_text`;
+DROP TABLE `translated_text`;
+ALTER TABLE `translated_text_project` RENAME TO `translated_text`;
+
+CREATE TABLE `text_group_project` (
+  `project`    VARCHAR(100) NOT NULL DEFAULT 'default',
+  `id`         VARCHAR(100) NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  FOREIGN KEY (`project`) REFERENCES `project`(`id`),
+  PRIMARY KEY (`project`, `id`)
+);
+INSERT INTO `text_group_project`(`id`, `created_at`) SELECT `id`, `created_at` FROM `text_group`;
+
+CREATE TABLE `text_group_membership_project` (
+  `id`         INTEGER PRIMARY KEY,
+  `project`    VARCHAR(100) NOT NULL DEFAULT 'default',
+  `text_key`   VARCHAR(100) NOT NULL,
+  `group_id`   VARCHAR(100) NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  FOREIGN KEY (`project`, `group_id`) REFERENCES `text_group`(`project`, `id`)
+);
+INSERT INTO `text_group_membership_project`(`id`, `text_key`, `group_id`, `created_at`)
+  SELECT `id`, `text_key`, `group_id`, `created_at` FROM `text_group_membership`;
+DROP TABLE `text_group_membership`;
+DROP TABLE `text_group`;
+ALTER TABLE `text_group_project` RENAME TO `text_group`;
+ALTER TABLE `text_group_membership_project` RENAME TO `text_group_membership`;
+
+ALTER TABLE `text_history` ADD COLUMN `project` VARCHAR(100) NOT NULL DEFAULT 'default';
+
+CREATE TABLE `text_release_project` (
+  `project`     VARCHAR(100) NOT NULL DEFAULT 'default',
+  `id`          VARCHAR(100) NOT NULL,
+  `description` VARCHAR(255) NOT NULL,
+  `is_current`  BOOLEAN NOT NULL DEFAULT 0,
+  `created_at`  DATETIME NOT NULL,
+  FOREIGN KEY (`project`) REFERENCES `project`(`id`),
+  PRIMARY KEY (`project`, `id`)
+);
+INSERT INTO `text_release_project`(`id`, `description`, `is_current`, `created_at`)
+  SELECT `id`, `description`, `is_current`, `created_at` FROM `text_release`;
+
+CREATE TABLE `release_text_project` (
+  `id`         INTEGER PRIMARY KEY,
+  `project`    VARCHAR(100) NOT NULL DEFAULT 'default',
+  `release_id` VARCHAR(100) NOT NULL,
+  `key`        VARCHAR(100) NOT NULL,
+  `language`   VARCHAR(50) NOT NULL,
+  `value`      TEXT NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  FOREIGN KEY (`project`, `release_id`) REFERENCES `text_release`(`project`, `id`),
+  UNIQUE(`project`, `release_id`, `key`, `language`)
+);
+INSERT INTO `release_text_project`(`id`, `release_id`, `key`, `language`, `value`, `created_at`, `updated_at`)
+  SELECT `id`, `release_id`, `key`, `language`, `value`, `created_at`, `updated_at` FROM `release_text`;
+
+CREATE TABLE `release_group_membership_project` (
+  `id`         INTEGER PRIMARY KEY,
+  `project`    VARCHAR(100) NOT NULL DEFAULT 'default',
+  `release_id` VARCHAR(100) NOT NULL,
+  `group_id`   VARCHAR(100) NOT NULL,
+  `text_key`   VARCHAR(100) NOT NULL,
+  FOREIGN KEY (`project`, `release_id`) REFERENCES `text_release`(`project`, `id`),
+  UNIQUE(`project`, `release_id`, `group_id`, `text_key`)
+);
+INSERT INTO `release_group_membership_project`(`id`, `release_id`, `group_id`, `text_key`)
+  SELECT `id`, `release_id`, `group_id`, `text_key` FROM `release_group_membership`;
+DROP TABLE `release_group_membership`;
+DROP TABLE `release_text`;
+DROP TABLE `text_release`;
+ALTER TABLE `text_release_project` RENAME TO `text_release`;
+ALTER TABLE `release_text_project` RENAME TO `release_text`;
+ALTER TABLE `release_group_membership_project` RENAME TO `release_group_membership`;
+
+CREATE TABLE `text_draft_project` (
+  `id`         INTEGER PRIMARY KEY,
+  `project`    VARCHAR(100) NOT NULL DEFAULT 'default',
+  `key`        VARCHAR(100) NOT NULL,
+  `language`   VARCHAR(50) NOT NULL,
+  `value`      TEXT NOT NULL,
+  `changed_by` VARCHAR(100) NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  `updated_at` DATETIME NOT NULL,
+  FOREIGN KEY (`project`) REFERENCES `project`(`id`),
+  FOREIGN KEY (`language`) REFERENCES `language`(`id`),
+  UNIQUE(`project`, `key`, `language`)
+);
+INSERT INTO `text_draft_project`(`id`, `key`, `language`, `value`, `changed_by`, `created_at`, `updated_at`)
+  SELECT `id`, `key`, `language`, `value`, `changed_by`, `created_at`, `updated_at` FROM `text_draft`;
+DROP TABLE `text_draft`;
+ALTER TABLE `text_draft_project` RENAME TO `text_draft`;
+
+-- +migrate Down
+DELETE FROM `translated_text` WHERE `project` <> 'default';
+DELETE FROM `text_group_membership` WHERE `project` <> 'default';
+DELETE FROM `text_group` WHERE `project` <> 'default';
+DELETE FROM `text_history` WHERE `project` <> 'default';
+DELETE FROM `release_group_membership` WHERE `project` <> 'default';
+DELETE FROM `release_text` WHERE `project` <> 'default';
+DELETE FROM `text_release` WHERE `project` <> 'default';
+DELETE FROM `text_draft` WHERE `project` <> 'default';
+CREATE UNIQUE INDEX `translated_text_key_language_idx` ON `translated_text`(`key`, `language`);
+CREATE UNIQUE INDEX `text_draft_key_language_idx` ON `text_draft`(`key`, `language`);
+DROP TABLE IF EXISTS `project`;
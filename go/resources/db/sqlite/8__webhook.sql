@@ -0,0 +1,31 @@
+-- +migrate Up
+CREATE TABLE `webhook` (
+  `id`         VARCHAR(50) PRIMARY KEY,
+  `project`    VARCHAR(100) NOT NULL,
+  `url`        VARCHAR(2048) NOT NULL,
+  `secret`     VARCHAR(255) NOT NULL,
+  `types`      VARCHAR(255) NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  FOREIGN KEY (`project`) REFERENCES `project`(`id`)
+);
+
+CREATE TABLE `webhook_delivery` (
+  `id`              INTEGER PRIMARY KEY,
+  `webhook_id`      VARCHAR(50) NOT NULL,
+  `event`           VARCHAR(50) NOT NULL,
+  `payload`         TEXT NOT NULL,
+  `status`          VARCHAR(20) NOT NULL,
+  `attempts`        INTEGER NOT NULL,
+  `response_code`   INTEGER NOT NULL,
+  `error`           TEXT NOT NULL,
+  `next_attempt_at` DATETIME NOT NULL,
+  `created_at`      DATETIME NOT NULL,
+  `updated_at`      DATETIME NOT NULL,
+  FOREIGN KEY (`webhook_id`) REFERENCES `webhook`(`id`)
+);
+
+CREATE INDEX `webhook_delivery_status_next_attempt_idx` ON `webhook_delivery`(`status`, `next_attempt_at`);
+
+-- +migrate Down
+DROP TABLE IF EXISTS `webhook_delivery`;
+DROP TABLE IF EXISTS `webhook`;
@@ -0,0 +1,29 @@
+-- +migrate Up
+CREATE VIRTUAL TABLE `text_search` USING fts4(`value`, tokenize=unicode61);
+
+INSERT INTO `text_search`(`docid`, `value`) SELECT `id`, `value` FROM `translated_text`;
+
+-- +migrate StatementBegin
+CREATE TRIGGER `text_search_insert` AFTER INSERT ON `translated_text` BEGIN
+  INSERT INTO `text_search`(`docid`, `value`) VALUES (new.`id`, new.`value`);
+END;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE TRIGGER `text_search_update` AFTER UPDATE ON `translated_text` BEGIN
+  DELETE FROM `text_search` WHERE `docid` = old.`id`;
+  INSERT INTO `text_search`(`docid`, `value`) VALUES (new.`id`, new.`value`);
+END;
+-- +migrate StatementEnd
+
+-- +migrate StatementBegin
+CREATE TRIGGER `text_search_delete` AFTER DELETE ON `translated_text` BEGIN
+  DELETE FROM `text_search` WHERE `docid` = old.`id`;
+END;
+-- +migrate StatementEnd
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS `text_search_delete`;
+DROP TRIGGER IF EXISTS `text_search_update`;
+DROP TRIGGER IF EXISTS `text_search_insert`;
+DROP TABLE IF EXISTS `text_search`;